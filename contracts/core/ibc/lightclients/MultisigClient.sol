@@ -6,7 +6,6 @@ import "@hyperledger-labs/yui-ibc-solidity/contracts/core/IBCHeight.sol";
 import {IBCHost} from "@hyperledger-labs/yui-ibc-solidity/contracts/core/IBCHost.sol";
 import {IBCIdentifier} from "@hyperledger-labs/yui-ibc-solidity/contracts/core/IBCIdentifier.sol";
 import {GoogleProtobufAny as Any} from "@hyperledger-labs/yui-ibc-solidity/contracts/core/types/GoogleProtobufAny.sol";
-import "@hyperledger-labs/yui-ibc-solidity/contracts/lib/ECRecovery.sol";
 import "@hyperledger-labs/yui-ibc-solidity/contracts/lib/Bytes.sol";
 import {
     ClientState,
@@ -18,6 +17,9 @@ import {
 } from "../../types/ethmultisig.sol";
 
 // MultisigClient is a dialect of https://github.com/datachainlab/ibc-multisig-client
+// NOTE: the verification functions of IClient are view functions, so this client cannot record the sequence
+// of the verified proofs and does not provide the replay protection of the Go client.
+// The batch proofs of the Go client are not supported yet.
 contract MultisigClient is IClient {
     using Bytes for bytes;
     using IBCHeight for Height.Data;
@@ -40,8 +42,23 @@ contract MultisigClient is IClient {
         });
     }
 
-    function getConsensusHeight(Height.Data memory) internal pure returns (Height.Data memory) {
-      return Height.Data({revision_number: 0, revision_height: 1});
+    /**
+     * @dev getConsensusHeight returns the height of the consensus state that verifies a proof at the given height.
+     * It is the given height if a consensus state exists at that height, or the latest height if the given height is greater than it.
+     * A proof at a height between two consensus states is rejected, since the IBCHost cannot look up the consensus state below it.
+     * The Go client looks up the consensus state in the same way.
+     */
+    function getConsensusHeight(IBCHost host, string memory clientId, Height.Data memory height) internal view returns (Height.Data memory) {
+        (, bool found) = host.getConsensusState(clientId, height);
+        if (found) {
+            return height;
+        }
+        (ClientState.Data memory clientState, bool ok) = getClientState(host, clientId);
+        require(ok, "client state not found");
+        if (height.gt(clientState.latest_height)) {
+            return clientState.latest_height;
+        }
+        return height;
     }
 
     /**
@@ -52,7 +69,7 @@ contract MultisigClient is IClient {
         string memory clientId,
         Height.Data memory height
     ) public override view returns (uint64, bool) {
-        (ConsensusState.Data memory consensusState, bool found) = getConsensusState(host, clientId, getConsensusHeight(height));
+        (ConsensusState.Data memory consensusState, bool found) = getConsensusState(host, clientId, getConsensusHeight(host, clientId, height));
         if (!found) {
             return (0, false);
         }
@@ -83,18 +100,145 @@ contract MultisigClient is IClient {
         revert("UpdateClient is not supported");
     }
 
+    // the order of the secp256k1 curve and its half
+    uint256 private constant SECP256K1_N = 0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141;
+    uint256 private constant SECP256K1_HALF_N = 0x7FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF5D576E7357A4501DDFE92F46681B20A0;
+    // mask of s in the YParityAndS of a compact signature
+    uint256 private constant SECP256K1_S_MASK = 0x7FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF;
+
+    /**
+     * @dev recoverSigner recovers the signer address from a 65-byte [R || S || V] signature over the hash.
+     * Only canonical signatures are accepted: s must be in the lower half of the curve order and v must be 0 or 1,
+     * where 27 and 28 are normalised in the same way. It returns the zero address for any rejected signature.
+     * The same rules are applied by RecoverSigner of the Go client.
+     */
+    function recoverSigner(bytes32 hash, bytes memory sig) public pure returns (address) {
+        if (sig.length != 65) {
+            return address(0);
+        }
+        bytes32 r;
+        bytes32 s;
+        uint8 v;
+        assembly {
+            r := mload(add(sig, 32))
+            s := mload(add(sig, 64))
+            v := byte(0, mload(add(sig, 96)))
+        }
+        return recoverSigner(hash, r, s, v);
+    }
+
+    /**
+     * @dev recoverCompactSigner recovers the signer address from the index-th 64-byte EIP-2098 [R || YParityAndS] signature
+     * of the concatenated compact signatures with the same rules as recoverSigner.
+     */
+    function recoverCompactSigner(bytes32 hash, bytes memory compactSigs, uint256 index) public pure returns (address) {
+        if (compactSigs.length < (index + 1) * 64) {
+            return address(0);
+        }
+        bytes32 r;
+        bytes32 vs;
+        assembly {
+            let p := add(add(compactSigs, 32), mul(index, 64))
+            r := mload(p)
+            vs := mload(add(p, 32))
+        }
+        return recoverSigner(hash, r, vs & bytes32(SECP256K1_S_MASK), uint8(uint256(vs) >> 255));
+    }
+
+    function recoverSigner(bytes32 hash, bytes32 r, bytes32 s, uint8 v) private pure returns (address) {
+        if (v < 27) {
+            v += 27;
+        }
+        if (v != 27 && v != 28) {
+            return address(0);
+        }
+        if (uint256(r) == 0 || uint256(r) >= SECP256K1_N || uint256(s) == 0 || uint256(s) > SECP256K1_HALF_N) {
+            return address(0);
+        }
+        return ecrecover(hash, v, r, s);
+    }
+
+    /**
+     * @dev verifySignature verifies that distinct signers of the consensus state whose voting powers add up to at least `threshold`
+     * signed over the signBytes. If the powers are empty, every address has a voting power of one.
+     * If the signer bitmap is empty, every address must sign in the order of the consensus state.
+     * The signatures can be of either the legacy or the compact version of the multisig.
+     */
     function verifySignature(ConsensusState.Data memory consensusState, MultiSignature.Data memory multisig, bytes memory signBytes) public pure returns (bool) {
-        require(consensusState.addresses.length == multisig.signatures.length, "signatures length mismatch");
+        uint256 n = consensusState.addresses.length;
+        require(consensusState.powers.length == 0 || consensusState.powers.length == n, "powers length mismatch");
+        uint256 totalPower = 0;
+        for (uint i = 0; i < n; i++) {
+            uint256 power = getPower(consensusState, i);
+            require(power > 0, "power is zero");
+            totalPower += power;
+        }
+        uint256 threshold = consensusState.threshold == 0 ? totalPower : consensusState.threshold;
+        require(threshold > 0 && threshold <= totalPower, "invalid threshold");
+        for (uint i = 0; i < n; i++) {
+            for (uint j = i + 1; j < n; j++) {
+                require(keccak256(consensusState.addresses[i]) != keccak256(consensusState.addresses[j]), "duplicate signer");
+            }
+        }
 
-        for (uint i = 0; i < consensusState.addresses.length; i++) {
-            require(multisig.signatures[i].length > 0, "signature is empty");
-            address addr = ECRecovery.recover(keccak256(signBytes), multisig.signatures[i]);
+        require(multisig.batch.root.length == 0, "batch proofs are not supported");
+        uint256 numSignatures = getNumSignatures(multisig);
+        bytes32 signHash = keccak256(signBytes);
+        if (multisig.signer_bitmap.length == 0) {
+            require(n == numSignatures, "signatures length mismatch");
+            for (uint i = 0; i < n; i++) {
+                address addr = recoverSignerAt(signHash, multisig, i);
+                require(addr != address(0), "invalid signature");
+                require(consensusState.addresses[i].toAddress() == addr, "signer mismatch");
+            }
+            return true;
+        }
+
+        require(multisig.signer_bitmap.length == (n + 7) / 8, "invalid signer bitmap length");
+        uint256 count = 0;
+        uint256 signedPower = 0;
+        for (uint i = 0; i < multisig.signer_bitmap.length * 8; i++) {
+            if ((uint8(multisig.signer_bitmap[i / 8]) >> (i % 8)) & 1 == 0) {
+                continue;
+            }
+            require(i < n, "signer index out of range");
+            require(count < numSignatures, "signatures length mismatch");
+            address addr = recoverSignerAt(signHash, multisig, count);
+            require(addr != address(0), "invalid signature");
             require(consensusState.addresses[i].toAddress() == addr, "signer mismatch");
+            signedPower += getPower(consensusState, i);
+            count++;
         }
+        require(count == numSignatures, "signatures length mismatch");
+        require(signedPower >= threshold, "insufficient voting power");
 
         return true;
     }
 
+    /**
+     * @dev getNumSignatures returns the number of signatures that the multisig carries in its version.
+     */
+    function getNumSignatures(MultiSignature.Data memory multisig) private pure returns (uint256) {
+        if (multisig.version == MultiSignature.Version.VERSION_COMPACT) {
+            require(multisig.signatures.length == 0, "legacy signatures in the compact version");
+            require(multisig.compact_signatures.length % 64 == 0, "invalid compact signatures length");
+            return multisig.compact_signatures.length / 64;
+        }
+        require(multisig.compact_signatures.length == 0, "compact signatures in the legacy version");
+        return multisig.signatures.length;
+    }
+
+    function recoverSignerAt(bytes32 hash, MultiSignature.Data memory multisig, uint256 index) private pure returns (address) {
+        if (multisig.version == MultiSignature.Version.VERSION_COMPACT) {
+            return recoverCompactSigner(hash, multisig.compact_signatures, index);
+        }
+        return recoverSigner(hash, multisig.signatures[index]);
+    }
+
+    function getPower(ConsensusState.Data memory consensusState, uint256 index) private pure returns (uint256) {
+        return consensusState.powers.length == 0 ? 1 : consensusState.powers[index];
+    }
+
     function verifyClientState(
         IBCHost host,
         string memory clientId,
@@ -106,7 +250,7 @@ contract MultisigClient is IClient {
     ) public virtual override view returns (bool) {
         ConsensusState.Data memory consensusState;
         bool found;
-        (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(height));
+        (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(host, clientId, height));
         require(found, "consensus state not found");
         return verifySignature(
             consensusState,
@@ -127,7 +271,7 @@ contract MultisigClient is IClient {
     ) public virtual override view returns (bool) {
         ConsensusState.Data memory consensusState;
         bool found;
-        (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(height));
+        (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(host, clientId, height));
         require(found, "consensus state not found");
         return verifySignature(
             consensusState,
@@ -147,7 +291,7 @@ contract MultisigClient is IClient {
     ) public virtual override view returns (bool) {
         ConsensusState.Data memory consensusState;
         bool found;
-        (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(height));
+        (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(host, clientId, height));
         require(found, "consensus state not found");
         return verifySignature(
             consensusState,
@@ -168,7 +312,7 @@ contract MultisigClient is IClient {
     ) public virtual override view returns (bool) {
         ConsensusState.Data memory consensusState;
         bool found;
-        (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(height));
+        (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(host, clientId, height));
         require(found, "consensus state not found");
         bytes memory signBytes = makeChannelStateSignBytes(height, MultiSignature.decode(proof).timestamp, consensusState.diversifier, portId, channelId, channelBytes, prefix);
         return verifySignature(
@@ -182,8 +326,8 @@ contract MultisigClient is IClient {
         IBCHost host,
         string memory clientId,
         Height.Data calldata height,
-        uint64,
-        uint64,
+        uint64 delayPeriodTime,
+        uint64 delayPeriodBlocks,
         bytes memory prefix,
         bytes memory proof,
         string memory portId,
@@ -193,9 +337,11 @@ contract MultisigClient is IClient {
     ) public virtual override view returns (bool) {
         ConsensusState.Data memory consensusState;
         {
-            bool found;
-            (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(height));
-            require(found, "consensus state not found");
+            bool ok;
+            (consensusState, ok) = getPacketConsensusState(host, clientId, height, delayPeriodTime, delayPeriodBlocks, MultiSignature.decode(proof).timestamp);
+            if (!ok) {
+                return false;
+            }
         }
         bytes memory signBytes = makePacketSignBytes(height, MultiSignature.decode(proof).timestamp, consensusState.diversifier, IBCIdentifier.packetCommitmentKey(portId, channelId, sequence), commitmentBytes, prefix);
         return verifySignature(
@@ -209,8 +355,8 @@ contract MultisigClient is IClient {
         IBCHost host,
         string memory clientId,
         Height.Data calldata height,
-        uint64,
-        uint64,
+        uint64 delayPeriodTime,
+        uint64 delayPeriodBlocks,
         bytes memory prefix,
         bytes memory proof,
         string memory portId,
@@ -220,9 +366,11 @@ contract MultisigClient is IClient {
     ) public virtual override view returns (bool) {
         ConsensusState.Data memory consensusState;
         {
-            bool found;
-            (consensusState, found) = getConsensusState(host, clientId, getConsensusHeight(height));
-            require(found, "consensus state not found");
+            bool ok;
+            (consensusState, ok) = getPacketConsensusState(host, clientId, height, delayPeriodTime, delayPeriodBlocks, MultiSignature.decode(proof).timestamp);
+            if (!ok) {
+                return false;
+            }
         }
         bytes memory signBytes = makePacketAcknowledgementSignBytes(height, MultiSignature.decode(proof).timestamp, consensusState.diversifier, IBCIdentifier.packetAcknowledgementCommitmentKey(portId, channelId, sequence), acknowledgement, prefix);
         return verifySignature(
@@ -232,6 +380,77 @@ contract MultisigClient is IClient {
         );
     }
 
+    /**
+     * @dev getPacketConsensusState returns the consensus state that verifies a packet proof at the given height.
+     * It returns false if the delay period has not passed or the signature timestamp is out of the window.
+     */
+    function getPacketConsensusState(
+        IBCHost host,
+        string memory clientId,
+        Height.Data memory height,
+        uint64 delayPeriodTime,
+        uint64 delayPeriodBlocks,
+        uint64 timestamp
+    ) private view returns (ConsensusState.Data memory consensusState, bool) {
+        Height.Data memory consensusHeight = getConsensusHeight(host, clientId, height);
+        if (!validateDelayPeriod(host, clientId, consensusHeight, delayPeriodTime, delayPeriodBlocks)) {
+            return (consensusState, false);
+        }
+        if (!validateTimestamp(host, clientId, timestamp)) {
+            return (consensusState, false);
+        }
+        bool found;
+        (consensusState, found) = getConsensusState(host, clientId, consensusHeight);
+        require(found, "consensus state not found");
+        return (consensusState, true);
+    }
+
+    /**
+     * @dev validateDelayPeriod returns true if the delay period has passed since the consensus state at the given height was processed.
+     */
+    function validateDelayPeriod(IBCHost host, string memory clientId, Height.Data memory height, uint64 delayPeriodTime, uint64 delayPeriodBlocks) private view returns (bool) {
+        uint64 currentTime = uint64(block.timestamp * 1000 * 1000 * 1000);
+        uint64 validTime = mustGetProcessedTime(host, clientId, height) + delayPeriodTime;
+        if (currentTime < validTime) {
+            return false;
+        }
+        uint64 currentHeight = uint64(block.number);
+        uint64 validHeight = mustGetProcessedHeight(host, clientId, height) + delayPeriodBlocks;
+        if (currentHeight < validHeight) {
+            return false;
+        }
+        return true;
+    }
+
+    /**
+     * @dev validateTimestamp returns true if the signature timestamp is neither later than the block time plus the max clock drift
+     * nor earlier than the block time minus the max signature age.
+     */
+    function validateTimestamp(IBCHost host, string memory clientId, uint64 timestamp) internal view returns (bool) {
+        (ClientState.Data memory clientState, bool found) = getClientState(host, clientId);
+        require(found, "client state not found");
+        uint64 currentTime = uint64(block.timestamp * 1000 * 1000 * 1000);
+        if (clientState.max_clock_drift != 0 && timestamp > currentTime && timestamp - currentTime > clientState.max_clock_drift) {
+            return false;
+        }
+        if (clientState.max_signature_age != 0 && currentTime > timestamp && currentTime - timestamp > clientState.max_signature_age) {
+            return false;
+        }
+        return true;
+    }
+
+    function mustGetProcessedTime(IBCHost host, string memory clientId, Height.Data memory height) internal view returns (uint64) {
+        (uint256 processedTime, bool found) = host.getProcessedTime(clientId, height);
+        require(found, "processed time not found");
+        return uint64(processedTime) * 1000 * 1000 * 1000;
+    }
+
+    function mustGetProcessedHeight(IBCHost host, string memory clientId, Height.Data memory height) internal view returns (uint64) {
+        (uint256 processedHeight, bool found) = host.getProcessedHeight(clientId, height);
+        require(found, "processed height not found");
+        return uint64(processedHeight);
+    }
+
     function getClientState(IBCHost host, string memory clientId) public virtual view returns (ClientState.Data memory clientState, bool found) {
       bytes memory clientStateBytes;
       (clientStateBytes, found) = host.getClientState(clientId);
@@ -11,6 +11,14 @@ library ClientState {
   struct Data {
     Height.Data latest_height;
     Height.Data frozen_height;
+    uint64 max_consensus_states;
+    uint64 consensus_state_max_age;
+    uint64 sequence;
+    uint64 timestamp;
+    uint64 trusting_period;
+    uint64 max_clock_drift;
+    uint64 max_signature_age;
+    bytes batch_root;
   }
 
   // Decoder section
@@ -64,6 +72,30 @@ library ClientState {
       if (fieldId == 2) {
         pointer += _read_frozen_height(pointer, bs, r);
       } else
+      if (fieldId == 3) {
+        pointer += _read_max_consensus_states(pointer, bs, r);
+      } else
+      if (fieldId == 4) {
+        pointer += _read_consensus_state_max_age(pointer, bs, r);
+      } else
+      if (fieldId == 5) {
+        pointer += _read_sequence(pointer, bs, r);
+      } else
+      if (fieldId == 6) {
+        pointer += _read_timestamp(pointer, bs, r);
+      } else
+      if (fieldId == 7) {
+        pointer += _read_trusting_period(pointer, bs, r);
+      } else
+      if (fieldId == 8) {
+        pointer += _read_max_clock_drift(pointer, bs, r);
+      } else
+      if (fieldId == 9) {
+        pointer += _read_max_signature_age(pointer, bs, r);
+      } else
+      if (fieldId == 10) {
+        pointer += _read_batch_root(pointer, bs, r);
+      } else
       {
         pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
       }
@@ -108,6 +140,142 @@ library ClientState {
     return sz;
   }
 
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_max_consensus_states(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.max_consensus_states = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_consensus_state_max_age(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.consensus_state_max_age = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_sequence(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.sequence = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_timestamp(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.timestamp = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_trusting_period(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.trusting_period = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_max_clock_drift(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.max_clock_drift = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_max_signature_age(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.max_signature_age = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_batch_root(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    r.batch_root = x;
+    return sz;
+  }
+
   // struct decoder
   /**
    * @dev The decoder for reading a inner struct field
@@ -179,6 +347,78 @@ library ClientState {
     );
     pointer += Height._encode_nested(r.frozen_height, pointer, bs);
     
+    if (r.max_consensus_states != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      3,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.max_consensus_states, pointer, bs);
+    }
+    if (r.consensus_state_max_age != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      4,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.consensus_state_max_age, pointer, bs);
+    }
+    if (r.sequence != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      5,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.sequence, pointer, bs);
+    }
+    if (r.timestamp != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      6,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.timestamp, pointer, bs);
+    }
+    if (r.trusting_period != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      7,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.trusting_period, pointer, bs);
+    }
+    if (r.max_clock_drift != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      8,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.max_clock_drift, pointer, bs);
+    }
+    if (r.max_signature_age != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      9,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.max_signature_age, pointer, bs);
+    }
+    if (r.batch_root.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      10,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_bytes(r.batch_root, pointer, bs);
+    }
     return pointer - offset;
   }
   // nested encoder
@@ -224,6 +464,14 @@ library ClientState {
     uint256 e;
     e += 1 + ProtoBufRuntime._sz_lendelim(Height._estimate(r.latest_height));
     e += 1 + ProtoBufRuntime._sz_lendelim(Height._estimate(r.frozen_height));
+    e += 1 + ProtoBufRuntime._sz_uint64(r.max_consensus_states);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.consensus_state_max_age);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.sequence);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.timestamp);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.trusting_period);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.max_clock_drift);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.max_signature_age);
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.batch_root.length);
     return e;
   }
   // empty checker
@@ -232,6 +480,38 @@ library ClientState {
     Data memory r
   ) internal pure returns (bool) {
     
+  if (r.max_consensus_states != 0) {
+    return false;
+  }
+
+  if (r.consensus_state_max_age != 0) {
+    return false;
+  }
+
+  if (r.sequence != 0) {
+    return false;
+  }
+
+  if (r.timestamp != 0) {
+    return false;
+  }
+
+  if (r.trusting_period != 0) {
+    return false;
+  }
+
+  if (r.max_clock_drift != 0) {
+    return false;
+  }
+
+  if (r.max_signature_age != 0) {
+    return false;
+  }
+
+  if (r.batch_root.length != 0) {
+    return false;
+  }
+
     return true;
   }
 
@@ -245,6 +525,14 @@ library ClientState {
   function store(Data memory input, Data storage output) internal {
     Height.store(input.latest_height, output.latest_height);
     Height.store(input.frozen_height, output.frozen_height);
+    output.max_consensus_states = input.max_consensus_states;
+    output.consensus_state_max_age = input.consensus_state_max_age;
+    output.sequence = input.sequence;
+    output.timestamp = input.timestamp;
+    output.trusting_period = input.trusting_period;
+    output.max_clock_drift = input.max_clock_drift;
+    output.max_signature_age = input.max_signature_age;
+    output.batch_root = input.batch_root;
 
   }
 
@@ -282,6 +570,8 @@ library ConsensusState {
     bytes[] addresses;
     string diversifier;
     uint64 timestamp;
+    uint64 threshold;
+    uint64[] powers;
   }
 
   // Decoder section
@@ -321,7 +611,7 @@ library ConsensusState {
     returns (Data memory, uint)
   {
     Data memory r;
-    uint[4] memory counters;
+    uint[6] memory counters;
     uint256 fieldId;
     ProtoBufRuntime.WireType wireType;
     uint256 bytesRead;
@@ -339,6 +629,16 @@ library ConsensusState {
       if (fieldId == 3) {
         pointer += _read_timestamp(pointer, bs, r);
       } else
+      if (fieldId == 4) {
+        pointer += _read_threshold(pointer, bs, r);
+      } else
+      if (fieldId == 5) {
+        if (wireType == ProtoBufRuntime.WireType.LengthDelim) {
+          pointer += _read_packed_repeated_powers(pointer, bs, r);
+        } else {
+          pointer += _read_unpacked_repeated_powers(pointer, bs, nil(), counters);
+        }
+      } else
       {
         pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
       }
@@ -349,6 +649,10 @@ library ConsensusState {
       require(r.addresses.length == 0);
       r.addresses = new bytes[](counters[1]);
     }
+    if (counters[5] > 0) {
+      require(r.powers.length == 0);
+      r.powers = new uint64[](counters[5]);
+    }
 
     while (pointer < offset + sz) {
       (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
@@ -356,6 +660,9 @@ library ConsensusState {
       if (fieldId == 1) {
         pointer += _read_unpacked_repeated_addresses(pointer, bs, r, counters);
       } else
+      if (fieldId == 5 && wireType != ProtoBufRuntime.WireType.LengthDelim) {
+        pointer += _read_unpacked_repeated_powers(pointer, bs, r, counters);
+      } else
       {
         pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
       }
@@ -377,7 +684,7 @@ library ConsensusState {
     uint256 p,
     bytes memory bs,
     Data memory r,
-    uint[4] memory counters
+    uint[6] memory counters
   ) internal pure returns (uint) {
     /**
      * if `r` is NULL, then only counting the number of fields.
@@ -426,32 +733,100 @@ library ConsensusState {
     return sz;
   }
 
-
-  // Encoder section
-
   /**
-   * @dev The main encoder for memory
-   * @param r The struct to be encoded
-   * @return The encoded byte array
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
    */
-  function encode(Data memory r) internal pure returns (bytes memory) {
-    bytes memory bs = new bytes(_estimate(r));
-    uint256 sz = _encode(r, 32, bs);
-    assembly {
-      mstore(bs, sz)
-    }
-    return bs;
+  function _read_threshold(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.threshold = x;
+    return sz;
   }
-  // inner encoder
 
   /**
-   * @dev The encoder for internal usage
-   * @param r The struct to be encoded
+   * @dev The decoder for reading a field
    * @param p The offset of bytes array to start decode
    * @param bs The bytes array to be decoded
-   * @return The number of bytes encoded
+   * @param r The in-memory struct
+   * @param counters The counters for repeated fields
+   * @return The number of bytes decoded
    */
-  function _encode(Data memory r, uint256 p, bytes memory bs)
+  function _read_unpacked_repeated_powers(
+    uint256 p,
+    bytes memory bs,
+    Data memory r,
+    uint[6] memory counters
+  ) internal pure returns (uint) {
+    /**
+     * if `r` is NULL, then only counting the number of fields.
+     */
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    if (isNil(r)) {
+      counters[5] += 1;
+    } else {
+      r.powers[r.powers.length - counters[5]] = x;
+      counters[5] -= 1;
+    }
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_packed_repeated_powers(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint256 len, uint256 size) = ProtoBufRuntime._decode_varint(p, bs);
+    p += size;
+    uint256 count = ProtoBufRuntime._count_packed_repeated_varint(p, len, bs);
+    r.powers = new uint64[](count);
+    for (uint256 i = 0; i < count; i++) {
+      (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+      p += sz;
+      r.powers[i] = x;
+    }
+    return size + len;
+  }
+
+
+  // Encoder section
+
+  /**
+   * @dev The main encoder for memory
+   * @param r The struct to be encoded
+   * @return The encoded byte array
+   */
+  function encode(Data memory r) internal pure returns (bytes memory) {
+    bytes memory bs = new bytes(_estimate(r));
+    uint256 sz = _encode(r, 32, bs);
+    assembly {
+      mstore(bs, sz)
+    }
+    return bs;
+  }
+  // inner encoder
+
+  /**
+   * @dev The encoder for internal usage
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode(Data memory r, uint256 p, bytes memory bs)
     internal
     pure
     returns (uint)
@@ -488,6 +863,31 @@ library ConsensusState {
     );
     pointer += ProtoBufRuntime._encode_uint64(r.timestamp, pointer, bs);
     }
+    if (r.threshold != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      4,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.threshold, pointer, bs);
+    }
+    if (r.powers.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      5,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_varint(
+      ProtoBufRuntime._estimate_packed_repeated_uint64(r.powers),
+      pointer,
+      bs
+    );
+    for(i = 0; i < r.powers.length; i++) {
+      pointer += ProtoBufRuntime._encode_uint64(r.powers[i], pointer, bs);
+    }
+    }
     return pointer - offset;
   }
   // nested encoder
@@ -536,6 +936,8 @@ library ConsensusState {
     }
     e += 1 + ProtoBufRuntime._sz_lendelim(bytes(r.diversifier).length);
     e += 1 + ProtoBufRuntime._sz_uint64(r.timestamp);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.threshold);
+    e += 1 + ProtoBufRuntime._sz_lendelim(ProtoBufRuntime._estimate_packed_repeated_uint64(r.powers));
     return e;
   }
   // empty checker
@@ -556,6 +958,14 @@ library ConsensusState {
     return false;
   }
 
+  if (r.threshold != 0) {
+    return false;
+  }
+
+  if (r.powers.length != 0) {
+    return false;
+  }
+
     return true;
   }
 
@@ -570,6 +980,8 @@ library ConsensusState {
     output.addresses = input.addresses;
     output.diversifier = input.diversifier;
     output.timestamp = input.timestamp;
+    output.threshold = input.threshold;
+    output.powers = input.powers;
 
   }
 
@@ -593,6 +1005,25 @@ library ConsensusState {
   }
 
 
+  //array helpers for Powers
+  /**
+   * @dev Add value to an array
+   * @param self The in-memory struct
+   * @param value The value to add
+   */
+  function addPowers(Data memory self, uint64 value) internal pure {
+    /**
+     * First resize the array. Then add the new element to the end.
+     */
+    uint64[] memory tmp = new uint64[](self.powers.length + 1);
+    for (uint256 i = 0; i < self.powers.length; i++) {
+      tmp[i] = self.powers[i];
+    }
+    tmp[self.powers.length] = value;
+    self.powers = tmp;
+  }
+
+
   //utility functions
   /**
    * @dev Return an empty struct
@@ -627,6 +1058,8 @@ library Header {
     MultiSignature.Data signature;
     bytes[] new_addresses;
     string new_diversifier;
+    uint64 new_threshold;
+    uint64[] new_powers;
   }
 
   // Decoder section
@@ -666,7 +1099,7 @@ library Header {
     returns (Data memory, uint)
   {
     Data memory r;
-    uint[6] memory counters;
+    uint[8] memory counters;
     uint256 fieldId;
     ProtoBufRuntime.WireType wireType;
     uint256 bytesRead;
@@ -690,6 +1123,16 @@ library Header {
       if (fieldId == 5) {
         pointer += _read_new_diversifier(pointer, bs, r);
       } else
+      if (fieldId == 6) {
+        pointer += _read_new_threshold(pointer, bs, r);
+      } else
+      if (fieldId == 7) {
+        if (wireType == ProtoBufRuntime.WireType.LengthDelim) {
+          pointer += _read_packed_repeated_new_powers(pointer, bs, r);
+        } else {
+          pointer += _read_unpacked_repeated_new_powers(pointer, bs, nil(), counters);
+        }
+      } else
       {
         pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
       }
@@ -700,6 +1143,10 @@ library Header {
       require(r.new_addresses.length == 0);
       r.new_addresses = new bytes[](counters[4]);
     }
+    if (counters[7] > 0) {
+      require(r.new_powers.length == 0);
+      r.new_powers = new uint64[](counters[7]);
+    }
 
     while (pointer < offset + sz) {
       (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
@@ -707,6 +1154,9 @@ library Header {
       if (fieldId == 4) {
         pointer += _read_unpacked_repeated_new_addresses(pointer, bs, r, counters);
       } else
+      if (fieldId == 7 && wireType != ProtoBufRuntime.WireType.LengthDelim) {
+        pointer += _read_unpacked_repeated_new_powers(pointer, bs, r, counters);
+      } else
       {
         pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
       }
@@ -779,7 +1229,7 @@ library Header {
     uint256 p,
     bytes memory bs,
     Data memory r,
-    uint[6] memory counters
+    uint[8] memory counters
   ) internal pure returns (uint) {
     /**
      * if `r` is NULL, then only counting the number of fields.
@@ -811,6 +1261,74 @@ library Header {
     return sz;
   }
 
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_new_threshold(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.new_threshold = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @param counters The counters for repeated fields
+   * @return The number of bytes decoded
+   */
+  function _read_unpacked_repeated_new_powers(
+    uint256 p,
+    bytes memory bs,
+    Data memory r,
+    uint[8] memory counters
+  ) internal pure returns (uint) {
+    /**
+     * if `r` is NULL, then only counting the number of fields.
+     */
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    if (isNil(r)) {
+      counters[7] += 1;
+    } else {
+      r.new_powers[r.new_powers.length - counters[7]] = x;
+      counters[7] -= 1;
+    }
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_packed_repeated_new_powers(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint256 len, uint256 size) = ProtoBufRuntime._decode_varint(p, bs);
+    p += size;
+    uint256 count = ProtoBufRuntime._count_packed_repeated_varint(p, len, bs);
+    r.new_powers = new uint64[](count);
+    for (uint256 i = 0; i < count; i++) {
+      (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+      p += sz;
+      r.new_powers[i] = x;
+    }
+    return size + len;
+  }
+
   // struct decoder
   /**
    * @dev The decoder for reading a inner struct field
@@ -930,6 +1448,31 @@ library Header {
     );
     pointer += ProtoBufRuntime._encode_string(r.new_diversifier, pointer, bs);
     }
+    if (r.new_threshold != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      6,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.new_threshold, pointer, bs);
+    }
+    if (r.new_powers.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      7,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_varint(
+      ProtoBufRuntime._estimate_packed_repeated_uint64(r.new_powers),
+      pointer,
+      bs
+    );
+    for(i = 0; i < r.new_powers.length; i++) {
+      pointer += ProtoBufRuntime._encode_uint64(r.new_powers[i], pointer, bs);
+    }
+    }
     return pointer - offset;
   }
   // nested encoder
@@ -980,6 +1523,8 @@ library Header {
       e += 1 + ProtoBufRuntime._sz_lendelim(r.new_addresses[i].length);
     }
     e += 1 + ProtoBufRuntime._sz_lendelim(bytes(r.new_diversifier).length);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.new_threshold);
+    e += 1 + ProtoBufRuntime._sz_lendelim(ProtoBufRuntime._estimate_packed_repeated_uint64(r.new_powers));
     return e;
   }
   // empty checker
@@ -1000,6 +1545,14 @@ library Header {
     return false;
   }
 
+  if (r.new_threshold != 0) {
+    return false;
+  }
+
+  if (r.new_powers.length != 0) {
+    return false;
+  }
+
     return true;
   }
 
@@ -1016,6 +1569,8 @@ library Header {
     MultiSignature.store(input.signature, output.signature);
     output.new_addresses = input.new_addresses;
     output.new_diversifier = input.new_diversifier;
+    output.new_threshold = input.new_threshold;
+    output.new_powers = input.new_powers;
 
   }
 
@@ -1039,6 +1594,25 @@ library Header {
   }
 
 
+  //array helpers for NewPowers
+  /**
+   * @dev Add value to an array
+   * @param self The in-memory struct
+   * @param value The value to add
+   */
+  function addNewPowers(Data memory self, uint64 value) internal pure {
+    /**
+     * First resize the array. Then add the new element to the end.
+     */
+    uint64[] memory tmp = new uint64[](self.new_powers.length + 1);
+    for (uint256 i = 0; i < self.new_powers.length; i++) {
+      tmp[i] = self.new_powers[i];
+    }
+    tmp[self.new_powers.length] = value;
+    self.new_powers = tmp;
+  }
+
+
   //utility functions
   /**
    * @dev Return an empty struct
@@ -1065,11 +1639,64 @@ library Header {
 
 library MultiSignature {
 
+  //enum definition
+  // Solidity enum definitions
+  enum Version {
+    VERSION_LEGACY_UNSPECIFIED,
+    VERSION_COMPACT
+  }
+
+
+  // Solidity enum encoder
+  function encode_Version(Version x) internal pure returns (int32) {
+    
+    if (x == Version.VERSION_LEGACY_UNSPECIFIED) {
+      return 0;
+    }
+
+    if (x == Version.VERSION_COMPACT) {
+      return 1;
+    }
+    revert();
+  }
+
+
+  // Solidity enum decoder
+  function decode_Version(int64 x) internal pure returns (Version) {
+    
+    if (x == 0) {
+      return Version.VERSION_LEGACY_UNSPECIFIED;
+    }
+
+    if (x == 1) {
+      return Version.VERSION_COMPACT;
+    }
+    revert();
+  }
+
+
+  /**
+   * @dev The estimator for an packed enum array
+   * @return The number of bytes encoded
+   */
+  function estimate_packed_repeated_Version(
+    Version[] memory a
+  ) internal pure returns (uint256) {
+    uint256 e = 0;
+    for (uint i = 0; i < a.length; i++) {
+      e += ProtoBufRuntime._sz_enum(encode_Version(a[i]));
+    }
+    return e;
+  }
 
   //struct definition
   struct Data {
     bytes[] signatures;
     uint64 timestamp;
+    bytes signer_bitmap;
+    MultiSignature.Version version;
+    bytes compact_signatures;
+    BatchProof.Data batch;
   }
 
   // Decoder section
@@ -1109,7 +1736,7 @@ library MultiSignature {
     returns (Data memory, uint)
   {
     Data memory r;
-    uint[3] memory counters;
+    uint[7] memory counters;
     uint256 fieldId;
     ProtoBufRuntime.WireType wireType;
     uint256 bytesRead;
@@ -1124,6 +1751,18 @@ library MultiSignature {
       if (fieldId == 2) {
         pointer += _read_timestamp(pointer, bs, r);
       } else
+      if (fieldId == 3) {
+        pointer += _read_signer_bitmap(pointer, bs, r);
+      } else
+      if (fieldId == 4) {
+        pointer += _read_version(pointer, bs, r);
+      } else
+      if (fieldId == 5) {
+        pointer += _read_compact_signatures(pointer, bs, r);
+      } else
+      if (fieldId == 6) {
+        pointer += _read_batch(pointer, bs, r);
+      } else
       {
         pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
       }
@@ -1162,7 +1801,7 @@ library MultiSignature {
     uint256 p,
     bytes memory bs,
     Data memory r,
-    uint[3] memory counters
+    uint[7] memory counters
   ) internal pure returns (uint) {
     /**
      * if `r` is NULL, then only counting the number of fields.
@@ -1194,31 +1833,120 @@ library MultiSignature {
     return sz;
   }
 
-
-  // Encoder section
-
   /**
-   * @dev The main encoder for memory
-   * @param r The struct to be encoded
-   * @return The encoded byte array
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
    */
-  function encode(Data memory r) internal pure returns (bytes memory) {
-    bytes memory bs = new bytes(_estimate(r));
-    uint256 sz = _encode(r, 32, bs);
-    assembly {
-      mstore(bs, sz)
-    }
-    return bs;
+  function _read_signer_bitmap(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    r.signer_bitmap = x;
+    return sz;
   }
-  // inner encoder
 
   /**
-   * @dev The encoder for internal usage
-   * @param r The struct to be encoded
+   * @dev The decoder for reading a field
    * @param p The offset of bytes array to start decode
    * @param bs The bytes array to be decoded
-   * @return The number of bytes encoded
-   */
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_version(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (int64 tmp, uint256 sz) = ProtoBufRuntime._decode_enum(p, bs);
+    MultiSignature.Version x = decode_Version(tmp);
+    r.version = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_compact_signatures(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    r.compact_signatures = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_batch(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (BatchProof.Data memory x, uint256 sz) = _decode_BatchProof(p, bs);
+    r.batch = x;
+    return sz;
+  }
+
+  // struct decoder
+  /**
+   * @dev The decoder for reading a inner struct field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The decoded inner-struct
+   * @return The number of bytes used to decode
+   */
+  function _decode_BatchProof(uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (BatchProof.Data memory, uint)
+  {
+    uint256 pointer = p;
+    (uint256 sz, uint256 bytesRead) = ProtoBufRuntime._decode_varint(pointer, bs);
+    pointer += bytesRead;
+    (BatchProof.Data memory r, ) = BatchProof._decode(pointer, bs, sz);
+    return (r, sz + bytesRead);
+  }
+
+
+  // Encoder section
+
+  /**
+   * @dev The main encoder for memory
+   * @param r The struct to be encoded
+   * @return The encoded byte array
+   */
+  function encode(Data memory r) internal pure returns (bytes memory) {
+    bytes memory bs = new bytes(_estimate(r));
+    uint256 sz = _encode(r, 32, bs);
+    assembly {
+      mstore(bs, sz)
+    }
+    return bs;
+  }
+  // inner encoder
+
+  /**
+   * @dev The encoder for internal usage
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
   function _encode(Data memory r, uint256 p, bytes memory bs)
     internal
     pure
@@ -1247,6 +1975,43 @@ library MultiSignature {
     );
     pointer += ProtoBufRuntime._encode_uint64(r.timestamp, pointer, bs);
     }
+    if (r.signer_bitmap.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      3,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_bytes(r.signer_bitmap, pointer, bs);
+    }
+    if (uint(r.version) != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      4,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    int32 _enum_version = encode_Version(r.version);
+    pointer += ProtoBufRuntime._encode_enum(_enum_version, pointer, bs);
+    }
+    if (r.compact_signatures.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      5,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_bytes(r.compact_signatures, pointer, bs);
+    }
+    
+    pointer += ProtoBufRuntime._encode_key(
+      6,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += BatchProof._encode_nested(r.batch, pointer, bs);
+    
     return pointer - offset;
   }
   // nested encoder
@@ -1294,6 +2059,10 @@ library MultiSignature {
       e += 1 + ProtoBufRuntime._sz_lendelim(r.signatures[i].length);
     }
     e += 1 + ProtoBufRuntime._sz_uint64(r.timestamp);
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.signer_bitmap.length);
+    e += 1 + ProtoBufRuntime._sz_enum(encode_Version(r.version));
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.compact_signatures.length);
+    e += 1 + ProtoBufRuntime._sz_lendelim(BatchProof._estimate(r.batch));
     return e;
   }
   // empty checker
@@ -1310,6 +2079,18 @@ library MultiSignature {
     return false;
   }
 
+  if (r.signer_bitmap.length != 0) {
+    return false;
+  }
+
+  if (uint(r.version) != 0) {
+    return false;
+  }
+
+  if (r.compact_signatures.length != 0) {
+    return false;
+  }
+
     return true;
   }
 
@@ -1323,6 +2104,10 @@ library MultiSignature {
   function store(Data memory input, Data storage output) internal {
     output.signatures = input.signatures;
     output.timestamp = input.timestamp;
+    output.signer_bitmap = input.signer_bitmap;
+    output.version = input.version;
+    output.compact_signatures = input.compact_signatures;
+    BatchProof.store(input.batch, output.batch);
 
   }
 
@@ -1370,137 +2155,1845 @@ library MultiSignature {
 }
 //library MultiSignature
 
-library SignBytes {
-
-  //enum definition
-  // Solidity enum definitions
-  enum DataType {
-    DATA_TYPE_UNINITIALIZED_UNSPECIFIED,
-    DATA_TYPE_CLIENT_STATE,
-    DATA_TYPE_CONSENSUS_STATE,
-    DATA_TYPE_CONNECTION_STATE,
-    DATA_TYPE_CHANNEL_STATE,
-    DATA_TYPE_PACKET_COMMITMENT,
-    DATA_TYPE_PACKET_ACKNOWLEDGEMENT,
-    DATA_TYPE_PACKET_RECEIPT_ABSENCE,
-    DATA_TYPE_NEXT_SEQUENCE_RECV,
-    DATA_TYPE_HEADER
-  }
-
-
-  // Solidity enum encoder
-  function encode_DataType(DataType x) internal pure returns (int32) {
-    
-    if (x == DataType.DATA_TYPE_UNINITIALIZED_UNSPECIFIED) {
-      return 0;
-    }
+library BatchProof {
 
-    if (x == DataType.DATA_TYPE_CLIENT_STATE) {
-      return 1;
-    }
 
-    if (x == DataType.DATA_TYPE_CONSENSUS_STATE) {
-      return 2;
-    }
+  //struct definition
+  struct Data {
+    bytes root;
+    uint64 index;
+    uint64 num_leaves;
+    bytes[] siblings;
+  }
 
-    if (x == DataType.DATA_TYPE_CONNECTION_STATE) {
-      return 3;
-    }
+  // Decoder section
 
-    if (x == DataType.DATA_TYPE_CHANNEL_STATE) {
-      return 4;
-    }
+  /**
+   * @dev The main decoder for memory
+   * @param bs The bytes array to be decoded
+   * @return The decoded struct
+   */
+  function decode(bytes memory bs) internal pure returns (Data memory) {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    return x;
+  }
 
-    if (x == DataType.DATA_TYPE_PACKET_COMMITMENT) {
-      return 5;
-    }
+  /**
+   * @dev The main decoder for storage
+   * @param self The in-storage struct
+   * @param bs The bytes array to be decoded
+   */
+  function decode(Data storage self, bytes memory bs) internal {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    store(x, self);
+  }
+  // inner decoder
 
-    if (x == DataType.DATA_TYPE_PACKET_ACKNOWLEDGEMENT) {
-      return 6;
-    }
+  /**
+   * @dev The decoder for internal usage
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param sz The number of bytes expected
+   * @return The decoded struct
+   * @return The number of bytes decoded
+   */
+  function _decode(uint256 p, bytes memory bs, uint256 sz)
+    internal
+    pure
+    returns (Data memory, uint)
+  {
+    Data memory r;
+    uint[5] memory counters;
+    uint256 fieldId;
+    ProtoBufRuntime.WireType wireType;
+    uint256 bytesRead;
+    uint256 offset = p;
+    uint256 pointer = p;
+    while (pointer < offset + sz) {
+      (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
+      pointer += bytesRead;
+      if (fieldId == 1) {
+        pointer += _read_root(pointer, bs, r);
+      } else
+      if (fieldId == 2) {
+        pointer += _read_index(pointer, bs, r);
+      } else
+      if (fieldId == 3) {
+        pointer += _read_num_leaves(pointer, bs, r);
+      } else
+      if (fieldId == 4) {
+        pointer += _read_unpacked_repeated_siblings(pointer, bs, nil(), counters);
+      } else
+      {
+        pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
+      }
 
-    if (x == DataType.DATA_TYPE_PACKET_RECEIPT_ABSENCE) {
-      return 7;
     }
-
-    if (x == DataType.DATA_TYPE_NEXT_SEQUENCE_RECV) {
-      return 8;
+    pointer = offset;
+    if (counters[4] > 0) {
+      require(r.siblings.length == 0);
+      r.siblings = new bytes[](counters[4]);
     }
 
-    if (x == DataType.DATA_TYPE_HEADER) {
-      return 9;
+    while (pointer < offset + sz) {
+      (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
+      pointer += bytesRead;
+      if (fieldId == 4) {
+        pointer += _read_unpacked_repeated_siblings(pointer, bs, r, counters);
+      } else
+      {
+        pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
+      }
     }
-    revert();
+    return (r, sz);
   }
 
+  // field readers
 
-  // Solidity enum decoder
-  function decode_DataType(int64 x) internal pure returns (DataType) {
-    
-    if (x == 0) {
-      return DataType.DATA_TYPE_UNINITIALIZED_UNSPECIFIED;
-    }
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_root(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    r.root = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_index(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.index = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_num_leaves(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.num_leaves = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @param counters The counters for repeated fields
+   * @return The number of bytes decoded
+   */
+  function _read_unpacked_repeated_siblings(
+    uint256 p,
+    bytes memory bs,
+    Data memory r,
+    uint[5] memory counters
+  ) internal pure returns (uint) {
+    /**
+     * if `r` is NULL, then only counting the number of fields.
+     */
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    if (isNil(r)) {
+      counters[4] += 1;
+    } else {
+      r.siblings[r.siblings.length - counters[4]] = x;
+      counters[4] -= 1;
+    }
+    return sz;
+  }
+
+
+  // Encoder section
+
+  /**
+   * @dev The main encoder for memory
+   * @param r The struct to be encoded
+   * @return The encoded byte array
+   */
+  function encode(Data memory r) internal pure returns (bytes memory) {
+    bytes memory bs = new bytes(_estimate(r));
+    uint256 sz = _encode(r, 32, bs);
+    assembly {
+      mstore(bs, sz)
+    }
+    return bs;
+  }
+  // inner encoder
+
+  /**
+   * @dev The encoder for internal usage
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    uint256 offset = p;
+    uint256 pointer = p;
+    uint256 i;
+    if (r.root.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      1,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_bytes(r.root, pointer, bs);
+    }
+    if (r.index != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      2,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.index, pointer, bs);
+    }
+    if (r.num_leaves != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      3,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.num_leaves, pointer, bs);
+    }
+    if (r.siblings.length != 0) {
+    for(i = 0; i < r.siblings.length; i++) {
+      pointer += ProtoBufRuntime._encode_key(
+        4,
+        ProtoBufRuntime.WireType.LengthDelim,
+        pointer,
+        bs)
+      ;
+      pointer += ProtoBufRuntime._encode_bytes(r.siblings[i], pointer, bs);
+    }
+    }
+    return pointer - offset;
+  }
+  // nested encoder
+
+  /**
+   * @dev The encoder for inner struct
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode_nested(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    /**
+     * First encoded `r` into a temporary array, and encode the actual size used.
+     * Then copy the temporary array into `bs`.
+     */
+    uint256 offset = p;
+    uint256 pointer = p;
+    bytes memory tmp = new bytes(_estimate(r));
+    uint256 tmpAddr = ProtoBufRuntime.getMemoryAddress(tmp);
+    uint256 bsAddr = ProtoBufRuntime.getMemoryAddress(bs);
+    uint256 size = _encode(r, 32, tmp);
+    pointer += ProtoBufRuntime._encode_varint(size, pointer, bs);
+    ProtoBufRuntime.copyBytes(tmpAddr + 32, bsAddr + pointer, size);
+    pointer += size;
+    delete tmp;
+    return pointer - offset;
+  }
+  // estimator
+
+  /**
+   * @dev The estimator for a struct
+   * @param r The struct to be encoded
+   * @return The number of bytes encoded in estimation
+   */
+  function _estimate(
+    Data memory r
+  ) internal pure returns (uint) {
+    uint256 e;uint256 i;
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.root.length);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.index);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.num_leaves);
+    for(i = 0; i < r.siblings.length; i++) {
+      e += 1 + ProtoBufRuntime._sz_lendelim(r.siblings[i].length);
+    }
+    return e;
+  }
+  // empty checker
+
+  function _empty(
+    Data memory r
+  ) internal pure returns (bool) {
+    
+  if (r.root.length != 0) {
+    return false;
+  }
+
+  if (r.index != 0) {
+    return false;
+  }
+
+  if (r.num_leaves != 0) {
+    return false;
+  }
+
+  if (r.siblings.length != 0) {
+    return false;
+  }
+
+    return true;
+  }
+
+
+  //store function
+  /**
+   * @dev Store in-memory struct to storage
+   * @param input The in-memory struct
+   * @param output The in-storage struct
+   */
+  function store(Data memory input, Data storage output) internal {
+    output.root = input.root;
+    output.index = input.index;
+    output.num_leaves = input.num_leaves;
+    output.siblings = input.siblings;
+
+  }
+
+
+  //array helpers for Siblings
+  /**
+   * @dev Add value to an array
+   * @param self The in-memory struct
+   * @param value The value to add
+   */
+  function addSiblings(Data memory self, bytes memory value) internal pure {
+    /**
+     * First resize the array. Then add the new element to the end.
+     */
+    bytes[] memory tmp = new bytes[](self.siblings.length + 1);
+    for (uint256 i = 0; i < self.siblings.length; i++) {
+      tmp[i] = self.siblings[i];
+    }
+    tmp[self.siblings.length] = value;
+    self.siblings = tmp;
+  }
+
+
+  //utility functions
+  /**
+   * @dev Return an empty struct
+   * @return r The empty struct
+   */
+  function nil() internal pure returns (Data memory r) {
+    assembly {
+      r := 0
+    }
+  }
+
+  /**
+   * @dev Test whether a struct is empty
+   * @param x The struct to be tested
+   * @return r True if it is empty
+   */
+  function isNil(Data memory x) internal pure returns (bool r) {
+    assembly {
+      r := iszero(x)
+    }
+  }
+}
+//library BatchProof
+
+library SignBytes {
+
+  //enum definition
+  // Solidity enum definitions
+  enum DataType {
+    DATA_TYPE_UNINITIALIZED_UNSPECIFIED,
+    DATA_TYPE_CLIENT_STATE,
+    DATA_TYPE_CONSENSUS_STATE,
+    DATA_TYPE_CONNECTION_STATE,
+    DATA_TYPE_CHANNEL_STATE,
+    DATA_TYPE_PACKET_COMMITMENT,
+    DATA_TYPE_PACKET_ACKNOWLEDGEMENT,
+    DATA_TYPE_PACKET_RECEIPT_ABSENCE,
+    DATA_TYPE_NEXT_SEQUENCE_RECV,
+    DATA_TYPE_HEADER,
+    DATA_TYPE_UPGRADE,
+    DATA_TYPE_BATCH
+  }
+
+
+  // Solidity enum encoder
+  function encode_DataType(DataType x) internal pure returns (int32) {
+    
+    if (x == DataType.DATA_TYPE_UNINITIALIZED_UNSPECIFIED) {
+      return 0;
+    }
+
+    if (x == DataType.DATA_TYPE_CLIENT_STATE) {
+      return 1;
+    }
+
+    if (x == DataType.DATA_TYPE_CONSENSUS_STATE) {
+      return 2;
+    }
+
+    if (x == DataType.DATA_TYPE_CONNECTION_STATE) {
+      return 3;
+    }
+
+    if (x == DataType.DATA_TYPE_CHANNEL_STATE) {
+      return 4;
+    }
+
+    if (x == DataType.DATA_TYPE_PACKET_COMMITMENT) {
+      return 5;
+    }
+
+    if (x == DataType.DATA_TYPE_PACKET_ACKNOWLEDGEMENT) {
+      return 6;
+    }
+
+    if (x == DataType.DATA_TYPE_PACKET_RECEIPT_ABSENCE) {
+      return 7;
+    }
+
+    if (x == DataType.DATA_TYPE_NEXT_SEQUENCE_RECV) {
+      return 8;
+    }
+
+    if (x == DataType.DATA_TYPE_HEADER) {
+      return 9;
+    }
+
+    if (x == DataType.DATA_TYPE_UPGRADE) {
+      return 10;
+    }
+
+    if (x == DataType.DATA_TYPE_BATCH) {
+      return 11;
+    }
+    revert();
+  }
+
+
+  // Solidity enum decoder
+  function decode_DataType(int64 x) internal pure returns (DataType) {
+    
+    if (x == 0) {
+      return DataType.DATA_TYPE_UNINITIALIZED_UNSPECIFIED;
+    }
 
     if (x == 1) {
       return DataType.DATA_TYPE_CLIENT_STATE;
     }
 
-    if (x == 2) {
-      return DataType.DATA_TYPE_CONSENSUS_STATE;
+    if (x == 2) {
+      return DataType.DATA_TYPE_CONSENSUS_STATE;
+    }
+
+    if (x == 3) {
+      return DataType.DATA_TYPE_CONNECTION_STATE;
+    }
+
+    if (x == 4) {
+      return DataType.DATA_TYPE_CHANNEL_STATE;
+    }
+
+    if (x == 5) {
+      return DataType.DATA_TYPE_PACKET_COMMITMENT;
+    }
+
+    if (x == 6) {
+      return DataType.DATA_TYPE_PACKET_ACKNOWLEDGEMENT;
+    }
+
+    if (x == 7) {
+      return DataType.DATA_TYPE_PACKET_RECEIPT_ABSENCE;
+    }
+
+    if (x == 8) {
+      return DataType.DATA_TYPE_NEXT_SEQUENCE_RECV;
+    }
+
+    if (x == 9) {
+      return DataType.DATA_TYPE_HEADER;
+    }
+
+    if (x == 10) {
+      return DataType.DATA_TYPE_UPGRADE;
+    }
+
+    if (x == 11) {
+      return DataType.DATA_TYPE_BATCH;
+    }
+    revert();
+  }
+
+
+  /**
+   * @dev The estimator for an packed enum array
+   * @return The number of bytes encoded
+   */
+  function estimate_packed_repeated_DataType(
+    DataType[] memory a
+  ) internal pure returns (uint256) {
+    uint256 e = 0;
+    for (uint i = 0; i < a.length; i++) {
+      e += ProtoBufRuntime._sz_enum(encode_DataType(a[i]));
+    }
+    return e;
+  }
+
+  //struct definition
+  struct Data {
+    Height.Data height;
+    uint64 timestamp;
+    string diversifier;
+    SignBytes.DataType data_type;
+    bytes data;
+  }
+
+  // Decoder section
+
+  /**
+   * @dev The main decoder for memory
+   * @param bs The bytes array to be decoded
+   * @return The decoded struct
+   */
+  function decode(bytes memory bs) internal pure returns (Data memory) {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    return x;
+  }
+
+  /**
+   * @dev The main decoder for storage
+   * @param self The in-storage struct
+   * @param bs The bytes array to be decoded
+   */
+  function decode(Data storage self, bytes memory bs) internal {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    store(x, self);
+  }
+  // inner decoder
+
+  /**
+   * @dev The decoder for internal usage
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param sz The number of bytes expected
+   * @return The decoded struct
+   * @return The number of bytes decoded
+   */
+  function _decode(uint256 p, bytes memory bs, uint256 sz)
+    internal
+    pure
+    returns (Data memory, uint)
+  {
+    Data memory r;
+    uint256 fieldId;
+    ProtoBufRuntime.WireType wireType;
+    uint256 bytesRead;
+    uint256 offset = p;
+    uint256 pointer = p;
+    while (pointer < offset + sz) {
+      (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
+      pointer += bytesRead;
+      if (fieldId == 1) {
+        pointer += _read_height(pointer, bs, r);
+      } else
+      if (fieldId == 2) {
+        pointer += _read_timestamp(pointer, bs, r);
+      } else
+      if (fieldId == 3) {
+        pointer += _read_diversifier(pointer, bs, r);
+      } else
+      if (fieldId == 4) {
+        pointer += _read_data_type(pointer, bs, r);
+      } else
+      if (fieldId == 5) {
+        pointer += _read_data(pointer, bs, r);
+      } else
+      {
+        pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
+      }
+
+    }
+    return (r, sz);
+  }
+
+  // field readers
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_height(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (Height.Data memory x, uint256 sz) = _decode_Height(p, bs);
+    r.height = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_timestamp(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.timestamp = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_diversifier(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (string memory x, uint256 sz) = ProtoBufRuntime._decode_string(p, bs);
+    r.diversifier = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_data_type(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (int64 tmp, uint256 sz) = ProtoBufRuntime._decode_enum(p, bs);
+    SignBytes.DataType x = decode_DataType(tmp);
+    r.data_type = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_data(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    r.data = x;
+    return sz;
+  }
+
+  // struct decoder
+  /**
+   * @dev The decoder for reading a inner struct field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The decoded inner-struct
+   * @return The number of bytes used to decode
+   */
+  function _decode_Height(uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (Height.Data memory, uint)
+  {
+    uint256 pointer = p;
+    (uint256 sz, uint256 bytesRead) = ProtoBufRuntime._decode_varint(pointer, bs);
+    pointer += bytesRead;
+    (Height.Data memory r, ) = Height._decode(pointer, bs, sz);
+    return (r, sz + bytesRead);
+  }
+
+
+  // Encoder section
+
+  /**
+   * @dev The main encoder for memory
+   * @param r The struct to be encoded
+   * @return The encoded byte array
+   */
+  function encode(Data memory r) internal pure returns (bytes memory) {
+    bytes memory bs = new bytes(_estimate(r));
+    uint256 sz = _encode(r, 32, bs);
+    assembly {
+      mstore(bs, sz)
+    }
+    return bs;
+  }
+  // inner encoder
+
+  /**
+   * @dev The encoder for internal usage
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    uint256 offset = p;
+    uint256 pointer = p;
+    
+    
+    pointer += ProtoBufRuntime._encode_key(
+      1,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += Height._encode_nested(r.height, pointer, bs);
+    
+    if (r.timestamp != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      2,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.timestamp, pointer, bs);
+    }
+    if (bytes(r.diversifier).length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      3,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_string(r.diversifier, pointer, bs);
+    }
+    if (uint(r.data_type) != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      4,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    int32 _enum_data_type = encode_DataType(r.data_type);
+    pointer += ProtoBufRuntime._encode_enum(_enum_data_type, pointer, bs);
+    }
+    if (r.data.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      5,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_bytes(r.data, pointer, bs);
+    }
+    return pointer - offset;
+  }
+  // nested encoder
+
+  /**
+   * @dev The encoder for inner struct
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode_nested(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    /**
+     * First encoded `r` into a temporary array, and encode the actual size used.
+     * Then copy the temporary array into `bs`.
+     */
+    uint256 offset = p;
+    uint256 pointer = p;
+    bytes memory tmp = new bytes(_estimate(r));
+    uint256 tmpAddr = ProtoBufRuntime.getMemoryAddress(tmp);
+    uint256 bsAddr = ProtoBufRuntime.getMemoryAddress(bs);
+    uint256 size = _encode(r, 32, tmp);
+    pointer += ProtoBufRuntime._encode_varint(size, pointer, bs);
+    ProtoBufRuntime.copyBytes(tmpAddr + 32, bsAddr + pointer, size);
+    pointer += size;
+    delete tmp;
+    return pointer - offset;
+  }
+  // estimator
+
+  /**
+   * @dev The estimator for a struct
+   * @param r The struct to be encoded
+   * @return The number of bytes encoded in estimation
+   */
+  function _estimate(
+    Data memory r
+  ) internal pure returns (uint) {
+    uint256 e;
+    e += 1 + ProtoBufRuntime._sz_lendelim(Height._estimate(r.height));
+    e += 1 + ProtoBufRuntime._sz_uint64(r.timestamp);
+    e += 1 + ProtoBufRuntime._sz_lendelim(bytes(r.diversifier).length);
+    e += 1 + ProtoBufRuntime._sz_enum(encode_DataType(r.data_type));
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.data.length);
+    return e;
+  }
+  // empty checker
+
+  function _empty(
+    Data memory r
+  ) internal pure returns (bool) {
+    
+  if (r.timestamp != 0) {
+    return false;
+  }
+
+  if (bytes(r.diversifier).length != 0) {
+    return false;
+  }
+
+  if (uint(r.data_type) != 0) {
+    return false;
+  }
+
+  if (r.data.length != 0) {
+    return false;
+  }
+
+    return true;
+  }
+
+
+  //store function
+  /**
+   * @dev Store in-memory struct to storage
+   * @param input The in-memory struct
+   * @param output The in-storage struct
+   */
+  function store(Data memory input, Data storage output) internal {
+    Height.store(input.height, output.height);
+    output.timestamp = input.timestamp;
+    output.diversifier = input.diversifier;
+    output.data_type = input.data_type;
+    output.data = input.data;
+
+  }
+
+
+
+  //utility functions
+  /**
+   * @dev Return an empty struct
+   * @return r The empty struct
+   */
+  function nil() internal pure returns (Data memory r) {
+    assembly {
+      r := 0
+    }
+  }
+
+  /**
+   * @dev Test whether a struct is empty
+   * @param x The struct to be tested
+   * @return r True if it is empty
+   */
+  function isNil(Data memory x) internal pure returns (bool r) {
+    assembly {
+      r := iszero(x)
+    }
+  }
+}
+//library SignBytes
+
+library HeaderData {
+
+
+  //struct definition
+  struct Data {
+    bytes[] new_addresses;
+    string new_diversifier;
+    uint64 new_threshold;
+    uint64[] new_powers;
+  }
+
+  // Decoder section
+
+  /**
+   * @dev The main decoder for memory
+   * @param bs The bytes array to be decoded
+   * @return The decoded struct
+   */
+  function decode(bytes memory bs) internal pure returns (Data memory) {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    return x;
+  }
+
+  /**
+   * @dev The main decoder for storage
+   * @param self The in-storage struct
+   * @param bs The bytes array to be decoded
+   */
+  function decode(Data storage self, bytes memory bs) internal {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    store(x, self);
+  }
+  // inner decoder
+
+  /**
+   * @dev The decoder for internal usage
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param sz The number of bytes expected
+   * @return The decoded struct
+   * @return The number of bytes decoded
+   */
+  function _decode(uint256 p, bytes memory bs, uint256 sz)
+    internal
+    pure
+    returns (Data memory, uint)
+  {
+    Data memory r;
+    uint[5] memory counters;
+    uint256 fieldId;
+    ProtoBufRuntime.WireType wireType;
+    uint256 bytesRead;
+    uint256 offset = p;
+    uint256 pointer = p;
+    while (pointer < offset + sz) {
+      (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
+      pointer += bytesRead;
+      if (fieldId == 1) {
+        pointer += _read_unpacked_repeated_new_addresses(pointer, bs, nil(), counters);
+      } else
+      if (fieldId == 2) {
+        pointer += _read_new_diversifier(pointer, bs, r);
+      } else
+      if (fieldId == 3) {
+        pointer += _read_new_threshold(pointer, bs, r);
+      } else
+      if (fieldId == 4) {
+        if (wireType == ProtoBufRuntime.WireType.LengthDelim) {
+          pointer += _read_packed_repeated_new_powers(pointer, bs, r);
+        } else {
+          pointer += _read_unpacked_repeated_new_powers(pointer, bs, nil(), counters);
+        }
+      } else
+      {
+        pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
+      }
+
+    }
+    pointer = offset;
+    if (counters[1] > 0) {
+      require(r.new_addresses.length == 0);
+      r.new_addresses = new bytes[](counters[1]);
+    }
+    if (counters[4] > 0) {
+      require(r.new_powers.length == 0);
+      r.new_powers = new uint64[](counters[4]);
+    }
+
+    while (pointer < offset + sz) {
+      (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
+      pointer += bytesRead;
+      if (fieldId == 1) {
+        pointer += _read_unpacked_repeated_new_addresses(pointer, bs, r, counters);
+      } else
+      if (fieldId == 4 && wireType != ProtoBufRuntime.WireType.LengthDelim) {
+        pointer += _read_unpacked_repeated_new_powers(pointer, bs, r, counters);
+      } else
+      {
+        pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
+      }
+    }
+    return (r, sz);
+  }
+
+  // field readers
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @param counters The counters for repeated fields
+   * @return The number of bytes decoded
+   */
+  function _read_unpacked_repeated_new_addresses(
+    uint256 p,
+    bytes memory bs,
+    Data memory r,
+    uint[5] memory counters
+  ) internal pure returns (uint) {
+    /**
+     * if `r` is NULL, then only counting the number of fields.
+     */
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    if (isNil(r)) {
+      counters[1] += 1;
+    } else {
+      r.new_addresses[r.new_addresses.length - counters[1]] = x;
+      counters[1] -= 1;
+    }
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_new_diversifier(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (string memory x, uint256 sz) = ProtoBufRuntime._decode_string(p, bs);
+    r.new_diversifier = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_new_threshold(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    r.new_threshold = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @param counters The counters for repeated fields
+   * @return The number of bytes decoded
+   */
+  function _read_unpacked_repeated_new_powers(
+    uint256 p,
+    bytes memory bs,
+    Data memory r,
+    uint[5] memory counters
+  ) internal pure returns (uint) {
+    /**
+     * if `r` is NULL, then only counting the number of fields.
+     */
+    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+    if (isNil(r)) {
+      counters[4] += 1;
+    } else {
+      r.new_powers[r.new_powers.length - counters[4]] = x;
+      counters[4] -= 1;
+    }
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_packed_repeated_new_powers(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (uint256 len, uint256 size) = ProtoBufRuntime._decode_varint(p, bs);
+    p += size;
+    uint256 count = ProtoBufRuntime._count_packed_repeated_varint(p, len, bs);
+    r.new_powers = new uint64[](count);
+    for (uint256 i = 0; i < count; i++) {
+      (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
+      p += sz;
+      r.new_powers[i] = x;
+    }
+    return size + len;
+  }
+
+
+  // Encoder section
+
+  /**
+   * @dev The main encoder for memory
+   * @param r The struct to be encoded
+   * @return The encoded byte array
+   */
+  function encode(Data memory r) internal pure returns (bytes memory) {
+    bytes memory bs = new bytes(_estimate(r));
+    uint256 sz = _encode(r, 32, bs);
+    assembly {
+      mstore(bs, sz)
+    }
+    return bs;
+  }
+  // inner encoder
+
+  /**
+   * @dev The encoder for internal usage
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    uint256 offset = p;
+    uint256 pointer = p;
+    uint256 i;
+    if (r.new_addresses.length != 0) {
+    for(i = 0; i < r.new_addresses.length; i++) {
+      pointer += ProtoBufRuntime._encode_key(
+        1,
+        ProtoBufRuntime.WireType.LengthDelim,
+        pointer,
+        bs)
+      ;
+      pointer += ProtoBufRuntime._encode_bytes(r.new_addresses[i], pointer, bs);
+    }
+    }
+    if (bytes(r.new_diversifier).length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      2,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_string(r.new_diversifier, pointer, bs);
+    }
+    if (r.new_threshold != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      3,
+      ProtoBufRuntime.WireType.Varint,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_uint64(r.new_threshold, pointer, bs);
+    }
+    if (r.new_powers.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      4,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_varint(
+      ProtoBufRuntime._estimate_packed_repeated_uint64(r.new_powers),
+      pointer,
+      bs
+    );
+    for(i = 0; i < r.new_powers.length; i++) {
+      pointer += ProtoBufRuntime._encode_uint64(r.new_powers[i], pointer, bs);
+    }
+    }
+    return pointer - offset;
+  }
+  // nested encoder
+
+  /**
+   * @dev The encoder for inner struct
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode_nested(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    /**
+     * First encoded `r` into a temporary array, and encode the actual size used.
+     * Then copy the temporary array into `bs`.
+     */
+    uint256 offset = p;
+    uint256 pointer = p;
+    bytes memory tmp = new bytes(_estimate(r));
+    uint256 tmpAddr = ProtoBufRuntime.getMemoryAddress(tmp);
+    uint256 bsAddr = ProtoBufRuntime.getMemoryAddress(bs);
+    uint256 size = _encode(r, 32, tmp);
+    pointer += ProtoBufRuntime._encode_varint(size, pointer, bs);
+    ProtoBufRuntime.copyBytes(tmpAddr + 32, bsAddr + pointer, size);
+    pointer += size;
+    delete tmp;
+    return pointer - offset;
+  }
+  // estimator
+
+  /**
+   * @dev The estimator for a struct
+   * @param r The struct to be encoded
+   * @return The number of bytes encoded in estimation
+   */
+  function _estimate(
+    Data memory r
+  ) internal pure returns (uint) {
+    uint256 e;uint256 i;
+    for(i = 0; i < r.new_addresses.length; i++) {
+      e += 1 + ProtoBufRuntime._sz_lendelim(r.new_addresses[i].length);
+    }
+    e += 1 + ProtoBufRuntime._sz_lendelim(bytes(r.new_diversifier).length);
+    e += 1 + ProtoBufRuntime._sz_uint64(r.new_threshold);
+    e += 1 + ProtoBufRuntime._sz_lendelim(ProtoBufRuntime._estimate_packed_repeated_uint64(r.new_powers));
+    return e;
+  }
+  // empty checker
+
+  function _empty(
+    Data memory r
+  ) internal pure returns (bool) {
+    
+  if (r.new_addresses.length != 0) {
+    return false;
+  }
+
+  if (bytes(r.new_diversifier).length != 0) {
+    return false;
+  }
+
+  if (r.new_threshold != 0) {
+    return false;
+  }
+
+  if (r.new_powers.length != 0) {
+    return false;
+  }
+
+    return true;
+  }
+
+
+  //store function
+  /**
+   * @dev Store in-memory struct to storage
+   * @param input The in-memory struct
+   * @param output The in-storage struct
+   */
+  function store(Data memory input, Data storage output) internal {
+    output.new_addresses = input.new_addresses;
+    output.new_diversifier = input.new_diversifier;
+    output.new_threshold = input.new_threshold;
+    output.new_powers = input.new_powers;
+
+  }
+
+
+  //array helpers for NewAddresses
+  /**
+   * @dev Add value to an array
+   * @param self The in-memory struct
+   * @param value The value to add
+   */
+  function addNewAddresses(Data memory self, bytes memory value) internal pure {
+    /**
+     * First resize the array. Then add the new element to the end.
+     */
+    bytes[] memory tmp = new bytes[](self.new_addresses.length + 1);
+    for (uint256 i = 0; i < self.new_addresses.length; i++) {
+      tmp[i] = self.new_addresses[i];
+    }
+    tmp[self.new_addresses.length] = value;
+    self.new_addresses = tmp;
+  }
+
+
+  //array helpers for NewPowers
+  /**
+   * @dev Add value to an array
+   * @param self The in-memory struct
+   * @param value The value to add
+   */
+  function addNewPowers(Data memory self, uint64 value) internal pure {
+    /**
+     * First resize the array. Then add the new element to the end.
+     */
+    uint64[] memory tmp = new uint64[](self.new_powers.length + 1);
+    for (uint256 i = 0; i < self.new_powers.length; i++) {
+      tmp[i] = self.new_powers[i];
+    }
+    tmp[self.new_powers.length] = value;
+    self.new_powers = tmp;
+  }
+
+
+  //utility functions
+  /**
+   * @dev Return an empty struct
+   * @return r The empty struct
+   */
+  function nil() internal pure returns (Data memory r) {
+    assembly {
+      r := 0
+    }
+  }
+
+  /**
+   * @dev Test whether a struct is empty
+   * @param x The struct to be tested
+   * @return r True if it is empty
+   */
+  function isNil(Data memory x) internal pure returns (bool r) {
+    assembly {
+      r := iszero(x)
+    }
+  }
+}
+//library HeaderData
+
+library BatchData {
+
+
+  //struct definition
+  struct Data {
+    bytes root;
+  }
+
+  // Decoder section
+
+  /**
+   * @dev The main decoder for memory
+   * @param bs The bytes array to be decoded
+   * @return The decoded struct
+   */
+  function decode(bytes memory bs) internal pure returns (Data memory) {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    return x;
+  }
+
+  /**
+   * @dev The main decoder for storage
+   * @param self The in-storage struct
+   * @param bs The bytes array to be decoded
+   */
+  function decode(Data storage self, bytes memory bs) internal {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    store(x, self);
+  }
+  // inner decoder
+
+  /**
+   * @dev The decoder for internal usage
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param sz The number of bytes expected
+   * @return The decoded struct
+   * @return The number of bytes decoded
+   */
+  function _decode(uint256 p, bytes memory bs, uint256 sz)
+    internal
+    pure
+    returns (Data memory, uint)
+  {
+    Data memory r;
+    uint256 fieldId;
+    ProtoBufRuntime.WireType wireType;
+    uint256 bytesRead;
+    uint256 offset = p;
+    uint256 pointer = p;
+    while (pointer < offset + sz) {
+      (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
+      pointer += bytesRead;
+      if (fieldId == 1) {
+        pointer += _read_root(pointer, bs, r);
+      } else
+      {
+        pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
+      }
+
+    }
+    return (r, sz);
+  }
+
+  // field readers
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_root(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    r.root = x;
+    return sz;
+  }
+
+
+  // Encoder section
+
+  /**
+   * @dev The main encoder for memory
+   * @param r The struct to be encoded
+   * @return The encoded byte array
+   */
+  function encode(Data memory r) internal pure returns (bytes memory) {
+    bytes memory bs = new bytes(_estimate(r));
+    uint256 sz = _encode(r, 32, bs);
+    assembly {
+      mstore(bs, sz)
+    }
+    return bs;
+  }
+  // inner encoder
+
+  /**
+   * @dev The encoder for internal usage
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    uint256 offset = p;
+    uint256 pointer = p;
+    
+    if (r.root.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      1,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_bytes(r.root, pointer, bs);
+    }
+    return pointer - offset;
+  }
+  // nested encoder
+
+  /**
+   * @dev The encoder for inner struct
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode_nested(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    /**
+     * First encoded `r` into a temporary array, and encode the actual size used.
+     * Then copy the temporary array into `bs`.
+     */
+    uint256 offset = p;
+    uint256 pointer = p;
+    bytes memory tmp = new bytes(_estimate(r));
+    uint256 tmpAddr = ProtoBufRuntime.getMemoryAddress(tmp);
+    uint256 bsAddr = ProtoBufRuntime.getMemoryAddress(bs);
+    uint256 size = _encode(r, 32, tmp);
+    pointer += ProtoBufRuntime._encode_varint(size, pointer, bs);
+    ProtoBufRuntime.copyBytes(tmpAddr + 32, bsAddr + pointer, size);
+    pointer += size;
+    delete tmp;
+    return pointer - offset;
+  }
+  // estimator
+
+  /**
+   * @dev The estimator for a struct
+   * @param r The struct to be encoded
+   * @return The number of bytes encoded in estimation
+   */
+  function _estimate(
+    Data memory r
+  ) internal pure returns (uint) {
+    uint256 e;
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.root.length);
+    return e;
+  }
+  // empty checker
+
+  function _empty(
+    Data memory r
+  ) internal pure returns (bool) {
+    
+  if (r.root.length != 0) {
+    return false;
+  }
+
+    return true;
+  }
+
+
+  //store function
+  /**
+   * @dev Store in-memory struct to storage
+   * @param input The in-memory struct
+   * @param output The in-storage struct
+   */
+  function store(Data memory input, Data storage output) internal {
+    output.root = input.root;
+
+  }
+
+
+
+  //utility functions
+  /**
+   * @dev Return an empty struct
+   * @return r The empty struct
+   */
+  function nil() internal pure returns (Data memory r) {
+    assembly {
+      r := 0
+    }
+  }
+
+  /**
+   * @dev Test whether a struct is empty
+   * @param x The struct to be tested
+   * @return r True if it is empty
+   */
+  function isNil(Data memory x) internal pure returns (bool r) {
+    assembly {
+      r := iszero(x)
     }
+  }
+}
+//library BatchData
 
-    if (x == 3) {
-      return DataType.DATA_TYPE_CONNECTION_STATE;
+library UpgradeData {
+
+
+  //struct definition
+  struct Data {
+    bytes client_state;
+    bytes consensus_state;
+  }
+
+  // Decoder section
+
+  /**
+   * @dev The main decoder for memory
+   * @param bs The bytes array to be decoded
+   * @return The decoded struct
+   */
+  function decode(bytes memory bs) internal pure returns (Data memory) {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    return x;
+  }
+
+  /**
+   * @dev The main decoder for storage
+   * @param self The in-storage struct
+   * @param bs The bytes array to be decoded
+   */
+  function decode(Data storage self, bytes memory bs) internal {
+    (Data memory x, ) = _decode(32, bs, bs.length);
+    store(x, self);
+  }
+  // inner decoder
+
+  /**
+   * @dev The decoder for internal usage
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param sz The number of bytes expected
+   * @return The decoded struct
+   * @return The number of bytes decoded
+   */
+  function _decode(uint256 p, bytes memory bs, uint256 sz)
+    internal
+    pure
+    returns (Data memory, uint)
+  {
+    Data memory r;
+    uint256 fieldId;
+    ProtoBufRuntime.WireType wireType;
+    uint256 bytesRead;
+    uint256 offset = p;
+    uint256 pointer = p;
+    while (pointer < offset + sz) {
+      (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
+      pointer += bytesRead;
+      if (fieldId == 1) {
+        pointer += _read_client_state(pointer, bs, r);
+      } else
+      if (fieldId == 2) {
+        pointer += _read_consensus_state(pointer, bs, r);
+      } else
+      {
+        pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
+      }
+
+    }
+    return (r, sz);
+  }
+
+  // field readers
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_client_state(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    r.client_state = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_consensus_state(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    r.consensus_state = x;
+    return sz;
+  }
+
+
+  // Encoder section
+
+  /**
+   * @dev The main encoder for memory
+   * @param r The struct to be encoded
+   * @return The encoded byte array
+   */
+  function encode(Data memory r) internal pure returns (bytes memory) {
+    bytes memory bs = new bytes(_estimate(r));
+    uint256 sz = _encode(r, 32, bs);
+    assembly {
+      mstore(bs, sz)
+    }
+    return bs;
+  }
+  // inner encoder
+
+  /**
+   * @dev The encoder for internal usage
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    uint256 offset = p;
+    uint256 pointer = p;
+    
+    if (r.client_state.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      1,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_bytes(r.client_state, pointer, bs);
+    }
+    if (r.consensus_state.length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      2,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_bytes(r.consensus_state, pointer, bs);
     }
+    return pointer - offset;
+  }
+  // nested encoder
+
+  /**
+   * @dev The encoder for inner struct
+   * @param r The struct to be encoded
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The number of bytes encoded
+   */
+  function _encode_nested(Data memory r, uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (uint)
+  {
+    /**
+     * First encoded `r` into a temporary array, and encode the actual size used.
+     * Then copy the temporary array into `bs`.
+     */
+    uint256 offset = p;
+    uint256 pointer = p;
+    bytes memory tmp = new bytes(_estimate(r));
+    uint256 tmpAddr = ProtoBufRuntime.getMemoryAddress(tmp);
+    uint256 bsAddr = ProtoBufRuntime.getMemoryAddress(bs);
+    uint256 size = _encode(r, 32, tmp);
+    pointer += ProtoBufRuntime._encode_varint(size, pointer, bs);
+    ProtoBufRuntime.copyBytes(tmpAddr + 32, bsAddr + pointer, size);
+    pointer += size;
+    delete tmp;
+    return pointer - offset;
+  }
+  // estimator
+
+  /**
+   * @dev The estimator for a struct
+   * @param r The struct to be encoded
+   * @return The number of bytes encoded in estimation
+   */
+  function _estimate(
+    Data memory r
+  ) internal pure returns (uint) {
+    uint256 e;
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.client_state.length);
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.consensus_state.length);
+    return e;
+  }
+  // empty checker
+
+  function _empty(
+    Data memory r
+  ) internal pure returns (bool) {
+    
+  if (r.client_state.length != 0) {
+    return false;
+  }
+
+  if (r.consensus_state.length != 0) {
+    return false;
+  }
+
+    return true;
+  }
 
-    if (x == 4) {
-      return DataType.DATA_TYPE_CHANNEL_STATE;
-    }
 
-    if (x == 5) {
-      return DataType.DATA_TYPE_PACKET_COMMITMENT;
-    }
+  //store function
+  /**
+   * @dev Store in-memory struct to storage
+   * @param input The in-memory struct
+   * @param output The in-storage struct
+   */
+  function store(Data memory input, Data storage output) internal {
+    output.client_state = input.client_state;
+    output.consensus_state = input.consensus_state;
 
-    if (x == 6) {
-      return DataType.DATA_TYPE_PACKET_ACKNOWLEDGEMENT;
-    }
+  }
 
-    if (x == 7) {
-      return DataType.DATA_TYPE_PACKET_RECEIPT_ABSENCE;
-    }
 
-    if (x == 8) {
-      return DataType.DATA_TYPE_NEXT_SEQUENCE_RECV;
-    }
 
-    if (x == 9) {
-      return DataType.DATA_TYPE_HEADER;
+  //utility functions
+  /**
+   * @dev Return an empty struct
+   * @return r The empty struct
+   */
+  function nil() internal pure returns (Data memory r) {
+    assembly {
+      r := 0
     }
-    revert();
   }
 
-
   /**
-   * @dev The estimator for an packed enum array
-   * @return The number of bytes encoded
+   * @dev Test whether a struct is empty
+   * @param x The struct to be tested
+   * @return r True if it is empty
    */
-  function estimate_packed_repeated_DataType(
-    DataType[] memory a
-  ) internal pure returns (uint256) {
-    uint256 e = 0;
-    for (uint i = 0; i < a.length; i++) {
-      e += ProtoBufRuntime._sz_enum(encode_DataType(a[i]));
+  function isNil(Data memory x) internal pure returns (bool r) {
+    assembly {
+      r := iszero(x)
     }
-    return e;
   }
+}
+//library UpgradeData
+
+library StateData {
+
 
   //struct definition
   struct Data {
-    Height.Data height;
-    uint64 timestamp;
-    string diversifier;
-    SignBytes.DataType data_type;
-    bytes data;
+    bytes path;
+    bytes value;
   }
 
   // Decoder section
@@ -1549,19 +4042,10 @@ library SignBytes {
       (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
       pointer += bytesRead;
       if (fieldId == 1) {
-        pointer += _read_height(pointer, bs, r);
+        pointer += _read_path(pointer, bs, r);
       } else
       if (fieldId == 2) {
-        pointer += _read_timestamp(pointer, bs, r);
-      } else
-      if (fieldId == 3) {
-        pointer += _read_diversifier(pointer, bs, r);
-      } else
-      if (fieldId == 4) {
-        pointer += _read_data_type(pointer, bs, r);
-      } else
-      if (fieldId == 5) {
-        pointer += _read_data(pointer, bs, r);
+        pointer += _read_value(pointer, bs, r);
       } else
       {
         pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
@@ -1580,65 +4064,13 @@ library SignBytes {
    * @param r The in-memory struct
    * @return The number of bytes decoded
    */
-  function _read_height(
-    uint256 p,
-    bytes memory bs,
-    Data memory r
-  ) internal pure returns (uint) {
-    (Height.Data memory x, uint256 sz) = _decode_Height(p, bs);
-    r.height = x;
-    return sz;
-  }
-
-  /**
-   * @dev The decoder for reading a field
-   * @param p The offset of bytes array to start decode
-   * @param bs The bytes array to be decoded
-   * @param r The in-memory struct
-   * @return The number of bytes decoded
-   */
-  function _read_timestamp(
-    uint256 p,
-    bytes memory bs,
-    Data memory r
-  ) internal pure returns (uint) {
-    (uint64 x, uint256 sz) = ProtoBufRuntime._decode_uint64(p, bs);
-    r.timestamp = x;
-    return sz;
-  }
-
-  /**
-   * @dev The decoder for reading a field
-   * @param p The offset of bytes array to start decode
-   * @param bs The bytes array to be decoded
-   * @param r The in-memory struct
-   * @return The number of bytes decoded
-   */
-  function _read_diversifier(
-    uint256 p,
-    bytes memory bs,
-    Data memory r
-  ) internal pure returns (uint) {
-    (string memory x, uint256 sz) = ProtoBufRuntime._decode_string(p, bs);
-    r.diversifier = x;
-    return sz;
-  }
-
-  /**
-   * @dev The decoder for reading a field
-   * @param p The offset of bytes array to start decode
-   * @param bs The bytes array to be decoded
-   * @param r The in-memory struct
-   * @return The number of bytes decoded
-   */
-  function _read_data_type(
+  function _read_path(
     uint256 p,
     bytes memory bs,
     Data memory r
   ) internal pure returns (uint) {
-    (int64 tmp, uint256 sz) = ProtoBufRuntime._decode_enum(p, bs);
-    SignBytes.DataType x = decode_DataType(tmp);
-    r.data_type = x;
+    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
+    r.path = x;
     return sz;
   }
 
@@ -1649,36 +4081,16 @@ library SignBytes {
    * @param r The in-memory struct
    * @return The number of bytes decoded
    */
-  function _read_data(
+  function _read_value(
     uint256 p,
     bytes memory bs,
     Data memory r
   ) internal pure returns (uint) {
     (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
-    r.data = x;
+    r.value = x;
     return sz;
   }
 
-  // struct decoder
-  /**
-   * @dev The decoder for reading a inner struct field
-   * @param p The offset of bytes array to start decode
-   * @param bs The bytes array to be decoded
-   * @return The decoded inner-struct
-   * @return The number of bytes used to decode
-   */
-  function _decode_Height(uint256 p, bytes memory bs)
-    internal
-    pure
-    returns (Height.Data memory, uint)
-  {
-    uint256 pointer = p;
-    (uint256 sz, uint256 bytesRead) = ProtoBufRuntime._decode_varint(pointer, bs);
-    pointer += bytesRead;
-    (Height.Data memory r, ) = Height._decode(pointer, bs, sz);
-    return (r, sz + bytesRead);
-  }
-
 
   // Encoder section
 
@@ -1712,51 +4124,23 @@ library SignBytes {
     uint256 offset = p;
     uint256 pointer = p;
     
-    
+    if (r.path.length != 0) {
     pointer += ProtoBufRuntime._encode_key(
       1,
       ProtoBufRuntime.WireType.LengthDelim,
       pointer,
       bs
     );
-    pointer += Height._encode_nested(r.height, pointer, bs);
-    
-    if (r.timestamp != 0) {
-    pointer += ProtoBufRuntime._encode_key(
-      2,
-      ProtoBufRuntime.WireType.Varint,
-      pointer,
-      bs
-    );
-    pointer += ProtoBufRuntime._encode_uint64(r.timestamp, pointer, bs);
-    }
-    if (bytes(r.diversifier).length != 0) {
-    pointer += ProtoBufRuntime._encode_key(
-      3,
-      ProtoBufRuntime.WireType.LengthDelim,
-      pointer,
-      bs
-    );
-    pointer += ProtoBufRuntime._encode_string(r.diversifier, pointer, bs);
-    }
-    if (uint(r.data_type) != 0) {
-    pointer += ProtoBufRuntime._encode_key(
-      4,
-      ProtoBufRuntime.WireType.Varint,
-      pointer,
-      bs
-    );
-    int32 _enum_data_type = encode_DataType(r.data_type);
-    pointer += ProtoBufRuntime._encode_enum(_enum_data_type, pointer, bs);
+    pointer += ProtoBufRuntime._encode_bytes(r.path, pointer, bs);
     }
-    if (r.data.length != 0) {
+    if (r.value.length != 0) {
     pointer += ProtoBufRuntime._encode_key(
-      5,
+      2,
       ProtoBufRuntime.WireType.LengthDelim,
       pointer,
       bs
     );
-    pointer += ProtoBufRuntime._encode_bytes(r.data, pointer, bs);
+    pointer += ProtoBufRuntime._encode_bytes(r.value, pointer, bs);
     }
     return pointer - offset;
   }
@@ -1801,11 +4185,8 @@ library SignBytes {
     Data memory r
   ) internal pure returns (uint) {
     uint256 e;
-    e += 1 + ProtoBufRuntime._sz_lendelim(Height._estimate(r.height));
-    e += 1 + ProtoBufRuntime._sz_uint64(r.timestamp);
-    e += 1 + ProtoBufRuntime._sz_lendelim(bytes(r.diversifier).length);
-    e += 1 + ProtoBufRuntime._sz_enum(encode_DataType(r.data_type));
-    e += 1 + ProtoBufRuntime._sz_lendelim(r.data.length);
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.path.length);
+    e += 1 + ProtoBufRuntime._sz_lendelim(r.value.length);
     return e;
   }
   // empty checker
@@ -1814,19 +4195,11 @@ library SignBytes {
     Data memory r
   ) internal pure returns (bool) {
     
-  if (r.timestamp != 0) {
-    return false;
-  }
-
-  if (bytes(r.diversifier).length != 0) {
-    return false;
-  }
-
-  if (uint(r.data_type) != 0) {
+  if (r.path.length != 0) {
     return false;
   }
 
-  if (r.data.length != 0) {
+  if (r.value.length != 0) {
     return false;
   }
 
@@ -1841,11 +4214,8 @@ library SignBytes {
    * @param output The in-storage struct
    */
   function store(Data memory input, Data storage output) internal {
-    Height.store(input.height, output.height);
-    output.timestamp = input.timestamp;
-    output.diversifier = input.diversifier;
-    output.data_type = input.data_type;
-    output.data = input.data;
+    output.path = input.path;
+    output.value = input.value;
 
   }
 
@@ -1873,15 +4243,17 @@ library SignBytes {
     }
   }
 }
-//library SignBytes
+//library StateData
 
-library HeaderData {
+library Misbehaviour {
 
 
   //struct definition
   struct Data {
-    bytes[] new_addresses;
-    string new_diversifier;
+    string client_id;
+    Height.Data height;
+    SignatureAndData.Data signature_one;
+    SignatureAndData.Data signature_two;
   }
 
   // Decoder section
@@ -1915,47 +4287,36 @@ library HeaderData {
    * @return The decoded struct
    * @return The number of bytes decoded
    */
-  function _decode(uint256 p, bytes memory bs, uint256 sz)
-    internal
-    pure
-    returns (Data memory, uint)
-  {
-    Data memory r;
-    uint[3] memory counters;
-    uint256 fieldId;
-    ProtoBufRuntime.WireType wireType;
-    uint256 bytesRead;
-    uint256 offset = p;
-    uint256 pointer = p;
-    while (pointer < offset + sz) {
-      (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
-      pointer += bytesRead;
-      if (fieldId == 1) {
-        pointer += _read_unpacked_repeated_new_addresses(pointer, bs, nil(), counters);
-      } else
-      if (fieldId == 2) {
-        pointer += _read_new_diversifier(pointer, bs, r);
-      } else
-      {
-        pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
-      }
-
-    }
-    pointer = offset;
-    if (counters[1] > 0) {
-      require(r.new_addresses.length == 0);
-      r.new_addresses = new bytes[](counters[1]);
-    }
-
+  function _decode(uint256 p, bytes memory bs, uint256 sz)
+    internal
+    pure
+    returns (Data memory, uint)
+  {
+    Data memory r;
+    uint256 fieldId;
+    ProtoBufRuntime.WireType wireType;
+    uint256 bytesRead;
+    uint256 offset = p;
+    uint256 pointer = p;
     while (pointer < offset + sz) {
       (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
       pointer += bytesRead;
       if (fieldId == 1) {
-        pointer += _read_unpacked_repeated_new_addresses(pointer, bs, r, counters);
+        pointer += _read_client_id(pointer, bs, r);
+      } else
+      if (fieldId == 2) {
+        pointer += _read_height(pointer, bs, r);
+      } else
+      if (fieldId == 3) {
+        pointer += _read_signature_one(pointer, bs, r);
+      } else
+      if (fieldId == 4) {
+        pointer += _read_signature_two(pointer, bs, r);
       } else
       {
         pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
       }
+
     }
     return (r, sz);
   }
@@ -1967,25 +4328,15 @@ library HeaderData {
    * @param p The offset of bytes array to start decode
    * @param bs The bytes array to be decoded
    * @param r The in-memory struct
-   * @param counters The counters for repeated fields
    * @return The number of bytes decoded
    */
-  function _read_unpacked_repeated_new_addresses(
+  function _read_client_id(
     uint256 p,
     bytes memory bs,
-    Data memory r,
-    uint[3] memory counters
+    Data memory r
   ) internal pure returns (uint) {
-    /**
-     * if `r` is NULL, then only counting the number of fields.
-     */
-    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
-    if (isNil(r)) {
-      counters[1] += 1;
-    } else {
-      r.new_addresses[r.new_addresses.length - counters[1]] = x;
-      counters[1] -= 1;
-    }
+    (string memory x, uint256 sz) = ProtoBufRuntime._decode_string(p, bs);
+    r.client_id = x;
     return sz;
   }
 
@@ -1996,16 +4347,89 @@ library HeaderData {
    * @param r The in-memory struct
    * @return The number of bytes decoded
    */
-  function _read_new_diversifier(
+  function _read_height(
     uint256 p,
     bytes memory bs,
     Data memory r
   ) internal pure returns (uint) {
-    (string memory x, uint256 sz) = ProtoBufRuntime._decode_string(p, bs);
-    r.new_diversifier = x;
+    (Height.Data memory x, uint256 sz) = _decode_Height(p, bs);
+    r.height = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_signature_one(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (SignatureAndData.Data memory x, uint256 sz) = _decode_SignatureAndData(p, bs);
+    r.signature_one = x;
+    return sz;
+  }
+
+  /**
+   * @dev The decoder for reading a field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @param r The in-memory struct
+   * @return The number of bytes decoded
+   */
+  function _read_signature_two(
+    uint256 p,
+    bytes memory bs,
+    Data memory r
+  ) internal pure returns (uint) {
+    (SignatureAndData.Data memory x, uint256 sz) = _decode_SignatureAndData(p, bs);
+    r.signature_two = x;
     return sz;
   }
 
+  // struct decoder
+  /**
+   * @dev The decoder for reading a inner struct field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The decoded inner-struct
+   * @return The number of bytes used to decode
+   */
+  function _decode_Height(uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (Height.Data memory, uint)
+  {
+    uint256 pointer = p;
+    (uint256 sz, uint256 bytesRead) = ProtoBufRuntime._decode_varint(pointer, bs);
+    pointer += bytesRead;
+    (Height.Data memory r, ) = Height._decode(pointer, bs, sz);
+    return (r, sz + bytesRead);
+  }
+
+  /**
+   * @dev The decoder for reading a inner struct field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The decoded inner-struct
+   * @return The number of bytes used to decode
+   */
+  function _decode_SignatureAndData(uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (SignatureAndData.Data memory, uint)
+  {
+    uint256 pointer = p;
+    (uint256 sz, uint256 bytesRead) = ProtoBufRuntime._decode_varint(pointer, bs);
+    pointer += bytesRead;
+    (SignatureAndData.Data memory r, ) = SignatureAndData._decode(pointer, bs, sz);
+    return (r, sz + bytesRead);
+  }
+
 
   // Encoder section
 
@@ -2038,27 +4462,43 @@ library HeaderData {
   {
     uint256 offset = p;
     uint256 pointer = p;
-    uint256 i;
-    if (r.new_addresses.length != 0) {
-    for(i = 0; i < r.new_addresses.length; i++) {
-      pointer += ProtoBufRuntime._encode_key(
-        1,
-        ProtoBufRuntime.WireType.LengthDelim,
-        pointer,
-        bs)
-      ;
-      pointer += ProtoBufRuntime._encode_bytes(r.new_addresses[i], pointer, bs);
-    }
+    
+    if (bytes(r.client_id).length != 0) {
+    pointer += ProtoBufRuntime._encode_key(
+      1,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += ProtoBufRuntime._encode_string(r.client_id, pointer, bs);
     }
-    if (bytes(r.new_diversifier).length != 0) {
+    
     pointer += ProtoBufRuntime._encode_key(
       2,
       ProtoBufRuntime.WireType.LengthDelim,
       pointer,
       bs
     );
-    pointer += ProtoBufRuntime._encode_string(r.new_diversifier, pointer, bs);
-    }
+    pointer += Height._encode_nested(r.height, pointer, bs);
+    
+    
+    pointer += ProtoBufRuntime._encode_key(
+      3,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += SignatureAndData._encode_nested(r.signature_one, pointer, bs);
+    
+    
+    pointer += ProtoBufRuntime._encode_key(
+      4,
+      ProtoBufRuntime.WireType.LengthDelim,
+      pointer,
+      bs
+    );
+    pointer += SignatureAndData._encode_nested(r.signature_two, pointer, bs);
+    
     return pointer - offset;
   }
   // nested encoder
@@ -2101,11 +4541,11 @@ library HeaderData {
   function _estimate(
     Data memory r
   ) internal pure returns (uint) {
-    uint256 e;uint256 i;
-    for(i = 0; i < r.new_addresses.length; i++) {
-      e += 1 + ProtoBufRuntime._sz_lendelim(r.new_addresses[i].length);
-    }
-    e += 1 + ProtoBufRuntime._sz_lendelim(bytes(r.new_diversifier).length);
+    uint256 e;
+    e += 1 + ProtoBufRuntime._sz_lendelim(bytes(r.client_id).length);
+    e += 1 + ProtoBufRuntime._sz_lendelim(Height._estimate(r.height));
+    e += 1 + ProtoBufRuntime._sz_lendelim(SignatureAndData._estimate(r.signature_one));
+    e += 1 + ProtoBufRuntime._sz_lendelim(SignatureAndData._estimate(r.signature_two));
     return e;
   }
   // empty checker
@@ -2114,11 +4554,7 @@ library HeaderData {
     Data memory r
   ) internal pure returns (bool) {
     
-  if (r.new_addresses.length != 0) {
-    return false;
-  }
-
-  if (bytes(r.new_diversifier).length != 0) {
+  if (bytes(r.client_id).length != 0) {
     return false;
   }
 
@@ -2133,30 +4569,14 @@ library HeaderData {
    * @param output The in-storage struct
    */
   function store(Data memory input, Data storage output) internal {
-    output.new_addresses = input.new_addresses;
-    output.new_diversifier = input.new_diversifier;
+    output.client_id = input.client_id;
+    Height.store(input.height, output.height);
+    SignatureAndData.store(input.signature_one, output.signature_one);
+    SignatureAndData.store(input.signature_two, output.signature_two);
 
   }
 
 
-  //array helpers for NewAddresses
-  /**
-   * @dev Add value to an array
-   * @param self The in-memory struct
-   * @param value The value to add
-   */
-  function addNewAddresses(Data memory self, bytes memory value) internal pure {
-    /**
-     * First resize the array. Then add the new element to the end.
-     */
-    bytes[] memory tmp = new bytes[](self.new_addresses.length + 1);
-    for (uint256 i = 0; i < self.new_addresses.length; i++) {
-      tmp[i] = self.new_addresses[i];
-    }
-    tmp[self.new_addresses.length] = value;
-    self.new_addresses = tmp;
-  }
-
 
   //utility functions
   /**
@@ -2180,15 +4600,15 @@ library HeaderData {
     }
   }
 }
-//library HeaderData
+//library Misbehaviour
 
-library StateData {
+library SignatureAndData {
 
 
   //struct definition
   struct Data {
-    bytes path;
-    bytes value;
+    MultiSignature.Data signature;
+    SignBytes.Data sign_bytes;
   }
 
   // Decoder section
@@ -2237,10 +4657,10 @@ library StateData {
       (fieldId, wireType, bytesRead) = ProtoBufRuntime._decode_key(pointer, bs);
       pointer += bytesRead;
       if (fieldId == 1) {
-        pointer += _read_path(pointer, bs, r);
+        pointer += _read_signature(pointer, bs, r);
       } else
       if (fieldId == 2) {
-        pointer += _read_value(pointer, bs, r);
+        pointer += _read_sign_bytes(pointer, bs, r);
       } else
       {
         pointer += ProtoBufRuntime._skip_field_decode(wireType, pointer, bs);
@@ -2259,13 +4679,13 @@ library StateData {
    * @param r The in-memory struct
    * @return The number of bytes decoded
    */
-  function _read_path(
+  function _read_signature(
     uint256 p,
     bytes memory bs,
     Data memory r
   ) internal pure returns (uint) {
-    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
-    r.path = x;
+    (MultiSignature.Data memory x, uint256 sz) = _decode_MultiSignature(p, bs);
+    r.signature = x;
     return sz;
   }
 
@@ -2276,16 +4696,55 @@ library StateData {
    * @param r The in-memory struct
    * @return The number of bytes decoded
    */
-  function _read_value(
+  function _read_sign_bytes(
     uint256 p,
     bytes memory bs,
     Data memory r
   ) internal pure returns (uint) {
-    (bytes memory x, uint256 sz) = ProtoBufRuntime._decode_bytes(p, bs);
-    r.value = x;
+    (SignBytes.Data memory x, uint256 sz) = _decode_SignBytes(p, bs);
+    r.sign_bytes = x;
     return sz;
   }
 
+  // struct decoder
+  /**
+   * @dev The decoder for reading a inner struct field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The decoded inner-struct
+   * @return The number of bytes used to decode
+   */
+  function _decode_MultiSignature(uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (MultiSignature.Data memory, uint)
+  {
+    uint256 pointer = p;
+    (uint256 sz, uint256 bytesRead) = ProtoBufRuntime._decode_varint(pointer, bs);
+    pointer += bytesRead;
+    (MultiSignature.Data memory r, ) = MultiSignature._decode(pointer, bs, sz);
+    return (r, sz + bytesRead);
+  }
+
+  /**
+   * @dev The decoder for reading a inner struct field
+   * @param p The offset of bytes array to start decode
+   * @param bs The bytes array to be decoded
+   * @return The decoded inner-struct
+   * @return The number of bytes used to decode
+   */
+  function _decode_SignBytes(uint256 p, bytes memory bs)
+    internal
+    pure
+    returns (SignBytes.Data memory, uint)
+  {
+    uint256 pointer = p;
+    (uint256 sz, uint256 bytesRead) = ProtoBufRuntime._decode_varint(pointer, bs);
+    pointer += bytesRead;
+    (SignBytes.Data memory r, ) = SignBytes._decode(pointer, bs, sz);
+    return (r, sz + bytesRead);
+  }
+
 
   // Encoder section
 
@@ -2319,24 +4778,24 @@ library StateData {
     uint256 offset = p;
     uint256 pointer = p;
     
-    if (r.path.length != 0) {
+    
     pointer += ProtoBufRuntime._encode_key(
       1,
       ProtoBufRuntime.WireType.LengthDelim,
       pointer,
       bs
     );
-    pointer += ProtoBufRuntime._encode_bytes(r.path, pointer, bs);
-    }
-    if (r.value.length != 0) {
+    pointer += MultiSignature._encode_nested(r.signature, pointer, bs);
+    
+    
     pointer += ProtoBufRuntime._encode_key(
       2,
       ProtoBufRuntime.WireType.LengthDelim,
       pointer,
       bs
     );
-    pointer += ProtoBufRuntime._encode_bytes(r.value, pointer, bs);
-    }
+    pointer += SignBytes._encode_nested(r.sign_bytes, pointer, bs);
+    
     return pointer - offset;
   }
   // nested encoder
@@ -2380,8 +4839,8 @@ library StateData {
     Data memory r
   ) internal pure returns (uint) {
     uint256 e;
-    e += 1 + ProtoBufRuntime._sz_lendelim(r.path.length);
-    e += 1 + ProtoBufRuntime._sz_lendelim(r.value.length);
+    e += 1 + ProtoBufRuntime._sz_lendelim(MultiSignature._estimate(r.signature));
+    e += 1 + ProtoBufRuntime._sz_lendelim(SignBytes._estimate(r.sign_bytes));
     return e;
   }
   // empty checker
@@ -2390,14 +4849,6 @@ library StateData {
     Data memory r
   ) internal pure returns (bool) {
     
-  if (r.path.length != 0) {
-    return false;
-  }
-
-  if (r.value.length != 0) {
-    return false;
-  }
-
     return true;
   }
 
@@ -2409,8 +4860,8 @@ library StateData {
    * @param output The in-storage struct
    */
   function store(Data memory input, Data storage output) internal {
-    output.path = input.path;
-    output.value = input.value;
+    MultiSignature.store(input.signature, output.signature);
+    SignBytes.store(input.sign_bytes, output.sign_bytes);
 
   }
 
@@ -2438,4 +4889,4 @@ library StateData {
     }
   }
 }
-//library StateData
\ No newline at end of file
+//library SignatureAndData
\ No newline at end of file
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"bytes"
+	"time"
+
 	ics23 "github.com/confio/ics23/go"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -17,7 +20,40 @@ func (cs *ClientState) GetLatestHeight() exported.Height {
 	return clienttypes.Height(cs.LatestHeight)
 }
 
+// Validate performs basic validation of the client state fields.
+// The max clock drift and the max signature age must not exceed the trusting period if they are set,
+// since a signature out of the trusting period is not trusted anyway.
 func (cs *ClientState) Validate() error {
+	if cs.GetLatestHeight().IsZero() {
+		return sdkerrors.Wrap(ErrInvalidHeight, "latest height cannot be zero")
+	}
+	if cs.TrustingPeriod != 0 {
+		if cs.MaxClockDrift > cs.TrustingPeriod {
+			return sdkerrors.Wrapf(ErrInvalidPeriod, "max clock drift must not be greater than the trusting period (%d > %d)", cs.MaxClockDrift, cs.TrustingPeriod)
+		}
+		if cs.MaxSignatureAge > cs.TrustingPeriod {
+			return sdkerrors.Wrapf(ErrInvalidPeriod, "max signature age must not be greater than the trusting period (%d > %d)", cs.MaxSignatureAge, cs.TrustingPeriod)
+		}
+	}
+	return nil
+}
+
+// ValidateInitial checks that the client state has none of the values that only a client in use can have.
+// It must be checked on creation of a client, such as when a MsgCreateClient is built. It is not a part of
+// Validate and Initialize, as a client that has verified proofs has them when it is exported and imported again.
+func (cs *ClientState) ValidateInitial() error {
+	if !clienttypes.Height(cs.FrozenHeight).IsZero() {
+		return sdkerrors.Wrap(ErrInvalidHeight, "a new client cannot be frozen")
+	}
+	if cs.Sequence != 0 {
+		return sdkerrors.Wrapf(ErrInvalidSequence, "sequence of a new client must be zero: %d", cs.Sequence)
+	}
+	if len(cs.BatchRoot) != 0 {
+		return sdkerrors.Wrap(ErrInvalidSequence, "a new client cannot have a batch root")
+	}
+	if cs.Timestamp != 0 {
+		return sdkerrors.Wrapf(ErrInvalidTimestamp, "timestamp of a new client must be zero: %d", cs.Timestamp)
+	}
 	return nil
 }
 
@@ -28,7 +64,28 @@ func (cs *ClientState) GetProofSpecs() []*ics23.ProofSpec {
 // Initialization function
 // Clients must validate the initial consensus state, and may store any client-specific metadata
 // necessary for correct light client operation
-func (cs *ClientState) Initialize(_ sdk.Context, _ codec.BinaryCodec, _ sdk.KVStore, _ exported.ConsensusState) error {
+func (cs *ClientState) Initialize(ctx sdk.Context, _ codec.BinaryCodec, clientStore sdk.KVStore, consState exported.ConsensusState) error {
+	if err := cs.Validate(); err != nil {
+		return err
+	}
+	msConsState, ok := consState.(*ConsensusState)
+	if !ok {
+		return sdkerrors.Wrapf(clienttypes.ErrInvalidConsensus, "invalid initial consensus state. expected type: %T, got: %T",
+			&ConsensusState{}, consState)
+	}
+	if err := msConsState.ValidateBasic(); err != nil {
+		return err
+	}
+	// the consensus state itself is stored by the client keeper.
+	// The metadata imported with the client from a genesis is kept, so the delay periods do not restart.
+	height := cs.GetLatestHeight()
+	_, foundTime := GetProcessedTime(clientStore, height)
+	_, foundHeight := GetProcessedHeight(clientStore, height)
+	if foundTime && foundHeight {
+		setIterationKey(clientStore, height)
+	} else {
+		setConsensusMetadata(ctx, clientStore, height)
+	}
 	return nil
 }
 
@@ -39,40 +96,39 @@ func (cs *ClientState) Status(ctx sdk.Context, clientStore sdk.KVStore, cdc code
 		return exported.Frozen
 	}
 
-	return exported.Active
-}
-
-// Genesis function
-func (cs *ClientState) ExportMetadata(_ sdk.KVStore) []exported.GenesisMetadata {
-	panic("not implemented") // TODO: Implement
-}
+	if cs.TrustingPeriod == 0 {
+		return exported.Active
+	}
 
-// Update and Misbehaviour functions
-func (cs *ClientState) CheckHeaderAndUpdateState(_ sdk.Context, _ codec.BinaryCodec, _ sdk.KVStore, _ exported.Header) (exported.ClientState, exported.ConsensusState, error) {
-	panic("not implemented") // TODO: Implement
-}
+	// get latest consensus state from clientStore to check for expiry
+	consState, err := getConsensusState(clientStore, cdc, cs.GetLatestHeight())
+	if err != nil {
+		return exported.Unknown
+	}
 
-func (cs *ClientState) CheckMisbehaviourAndUpdateState(_ sdk.Context, _ codec.BinaryCodec, _ sdk.KVStore, _ exported.Misbehaviour) (exported.ClientState, error) {
-	panic("not implemented") // TODO: Implement
-}
+	if cs.IsExpired(consState.Timestamp, ctx.BlockTime()) {
+		return exported.Expired
+	}
 
-func (cs *ClientState) CheckSubstituteAndUpdateState(ctx sdk.Context, cdc codec.BinaryCodec, subjectClientStore sdk.KVStore, substituteClientStore sdk.KVStore, substituteClient exported.ClientState) (exported.ClientState, error) {
-	panic("not implemented") // TODO: Implement
+	return exported.Active
 }
 
-// Upgrade functions
-// NOTE: proof heights are not included as upgrade to a new revision is expected to pass only on the last
-// height committed by the current revision. Clients are responsible for ensuring that the planned last
-// height of the current revision is somehow encoded in the proof verification process.
-// This is to ensure that no premature upgrades occur, since upgrade plans committed to by the counterparty
-// may be cancelled or modified before the last planned height.
-func (cs *ClientState) VerifyUpgradeAndUpdateState(ctx sdk.Context, cdc codec.BinaryCodec, store sdk.KVStore, newClient exported.ClientState, newConsState exported.ConsensusState, proofUpgradeClient []byte, proofUpgradeConsState []byte) (exported.ClientState, exported.ConsensusState, error) {
-	panic("not implemented") // TODO: Implement
+// IsExpired returns whether or not the client has passed the trusting period since the given
+// timestamp (in nanoseconds) of the latest consensus state.
+func (cs *ClientState) IsExpired(latestTimestamp uint64, now time.Time) bool {
+	if cs.TrustingPeriod == 0 {
+		return false
+	}
+	expirationTime := time.Unix(0, int64(latestTimestamp)).Add(time.Duration(cs.TrustingPeriod))
+	return !expirationTime.After(now)
 }
 
 // Utility function that zeroes out any client customizable fields in client state
 // Ledger enforced fields are maintained while all custom fields are zero values
 // Used to verify upgrades
+// The pruning parameters, the trusting period and the signature timestamp window are custom
+// fields chosen by each host, and the sequence of the verified proofs is not a part of the
+// upgraded client.
 func (cs *ClientState) ZeroCustomFields() exported.ClientState {
 	return &ClientState{
 		LatestHeight: cs.LatestHeight,
@@ -93,7 +149,11 @@ func (cs ClientState) VerifyClientState(store sdk.KVStore, cdc codec.BinaryCodec
 	if err != nil {
 		return err
 	}
-	return VerifySignature(cons.GetAddresses(), sigData, signBz)
+	if err := verifyStateSignature(cdc, cons, sigData, signBz); err != nil {
+		return err
+	}
+	setSequence(store, cdc, cs, height, sigData)
+	return nil
 }
 
 func (cs ClientState) VerifyClientConsensusState(store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, counterpartyClientIdentifier string, consensusHeight exported.Height, prefix exported.Prefix, proof []byte, consensusState exported.ConsensusState) error {
@@ -109,37 +169,260 @@ func (cs ClientState) VerifyClientConsensusState(store sdk.KVStore, cdc codec.Bi
 	if err != nil {
 		return err
 	}
-	return VerifySignature(cons.GetAddresses(), sigData, signBz)
+	if err := verifyStateSignature(cdc, cons, sigData, signBz); err != nil {
+		return err
+	}
+	setSequence(store, cdc, cs, height, sigData)
+	return nil
 }
 
-func (cs *ClientState) VerifyConnectionState(store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, prefix exported.Prefix, proof []byte, connectionID string, connectionEnd exported.ConnectionI) error {
-	panic("not implemented") // TODO: Implement
+func (cs ClientState) VerifyConnectionState(store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, prefix exported.Prefix, proof []byte, connectionID string, connectionEnd exported.ConnectionI) error {
+	cons, sigData, err := produceVerificationArgs(store, cdc, cs, height, prefix, proof)
+	if err != nil {
+		return err
+	}
+	path, err := ConnectionCommitmentKey(prefix.Bytes(), connectionID)
+	if err != nil {
+		return err
+	}
+	signBz, err := ConnectionStateSignBytes(cdc, height.(clienttypes.Height), sigData.Timestamp, cons.Diversifier, path, connectionEnd)
+	if err != nil {
+		return err
+	}
+	if err := verifyStateSignature(cdc, cons, sigData, signBz); err != nil {
+		return err
+	}
+	setSequence(store, cdc, cs, height, sigData)
+	return nil
 }
 
-func (cs *ClientState) VerifyChannelState(store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, prefix exported.Prefix, proof []byte, portID string, channelID string, channel exported.ChannelI) error {
-	panic("not implemented") // TODO: Implement
+func (cs ClientState) VerifyChannelState(store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, prefix exported.Prefix, proof []byte, portID string, channelID string, channel exported.ChannelI) error {
+	cons, sigData, err := produceVerificationArgs(store, cdc, cs, height, prefix, proof)
+	if err != nil {
+		return err
+	}
+	path, err := ChannelCommitmentKey(prefix.Bytes(), portID, channelID)
+	if err != nil {
+		return err
+	}
+	signBz, err := ChannelStateSignBytes(cdc, height.(clienttypes.Height), sigData.Timestamp, cons.Diversifier, path, channel)
+	if err != nil {
+		return err
+	}
+	if err := verifyStateSignature(cdc, cons, sigData, signBz); err != nil {
+		return err
+	}
+	setSequence(store, cdc, cs, height, sigData)
+	return nil
 }
 
-func (cs *ClientState) VerifyPacketCommitment(ctx sdk.Context, store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, prefix exported.Prefix, proof []byte, portID string, channelID string, sequence uint64, commitmentBytes []byte) error {
-	panic("not implemented") // TODO: Implement
+func (cs ClientState) VerifyPacketCommitment(ctx sdk.Context, store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, prefix exported.Prefix, proof []byte, portID string, channelID string, sequence uint64, commitmentBytes []byte) error {
+	cons, sigData, err := produceVerificationArgs(store, cdc, cs, height, prefix, proof)
+	if err != nil {
+		return err
+	}
+	if err := checkActive(ctx, store, cdc, cs); err != nil {
+		return err
+	}
+	if err := checkSignatureTimestamp(ctx, cs, sigData.Timestamp); err != nil {
+		return err
+	}
+	if err := verifyDelayPeriodPassed(ctx, store, cs.GetLatestHeight(), height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+	path, err := PacketCommitmentKey(prefix.Bytes(), portID, channelID, sequence)
+	if err != nil {
+		return err
+	}
+	signBz, err := PacketCommitmentSignBytes(cdc, height.(clienttypes.Height), sigData.Timestamp, cons.Diversifier, path, commitmentBytes)
+	if err != nil {
+		return err
+	}
+	if err := verifyStateSignature(cdc, cons, sigData, signBz); err != nil {
+		return err
+	}
+	setSequence(store, cdc, cs, height, sigData)
+	return nil
 }
 
-func (cs *ClientState) VerifyPacketAcknowledgement(ctx sdk.Context, store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, prefix exported.Prefix, proof []byte, portID string, channelID string, sequence uint64, acknowledgement []byte) error {
-	panic("not implemented") // TODO: Implement
+func (cs ClientState) VerifyPacketAcknowledgement(ctx sdk.Context, store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, prefix exported.Prefix, proof []byte, portID string, channelID string, sequence uint64, acknowledgement []byte) error {
+	cons, sigData, err := produceVerificationArgs(store, cdc, cs, height, prefix, proof)
+	if err != nil {
+		return err
+	}
+	if err := checkActive(ctx, store, cdc, cs); err != nil {
+		return err
+	}
+	if err := checkSignatureTimestamp(ctx, cs, sigData.Timestamp); err != nil {
+		return err
+	}
+	if err := verifyDelayPeriodPassed(ctx, store, cs.GetLatestHeight(), height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+	path, err := PacketAcknowledgementCommitmentKey(prefix.Bytes(), portID, channelID, sequence)
+	if err != nil {
+		return err
+	}
+	signBz, err := PacketAcknowledgementSignBytes(cdc, height.(clienttypes.Height), sigData.Timestamp, cons.Diversifier, path, acknowledgement)
+	if err != nil {
+		return err
+	}
+	if err := verifyStateSignature(cdc, cons, sigData, signBz); err != nil {
+		return err
+	}
+	setSequence(store, cdc, cs, height, sigData)
+	return nil
+}
+
+func (cs ClientState) VerifyPacketReceiptAbsence(ctx sdk.Context, store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, prefix exported.Prefix, proof []byte, portID string, channelID string, sequence uint64) error {
+	cons, sigData, err := produceVerificationArgs(store, cdc, cs, height, prefix, proof)
+	if err != nil {
+		return err
+	}
+	if err := checkActive(ctx, store, cdc, cs); err != nil {
+		return err
+	}
+	if err := checkSignatureTimestamp(ctx, cs, sigData.Timestamp); err != nil {
+		return err
+	}
+	if err := verifyDelayPeriodPassed(ctx, store, cs.GetLatestHeight(), height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+	path, err := PacketReceiptCommitmentKey(prefix.Bytes(), portID, channelID, sequence)
+	if err != nil {
+		return err
+	}
+	signBz, err := PacketReceiptAbsenceSignBytes(cdc, height.(clienttypes.Height), sigData.Timestamp, cons.Diversifier, path)
+	if err != nil {
+		return err
+	}
+	if err := verifyStateSignature(cdc, cons, sigData, signBz); err != nil {
+		return err
+	}
+	setSequence(store, cdc, cs, height, sigData)
+	return nil
+}
+
+func (cs ClientState) VerifyNextSequenceRecv(ctx sdk.Context, store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, prefix exported.Prefix, proof []byte, portID string, channelID string, nextSequenceRecv uint64) error {
+	cons, sigData, err := produceVerificationArgs(store, cdc, cs, height, prefix, proof)
+	if err != nil {
+		return err
+	}
+	if err := checkActive(ctx, store, cdc, cs); err != nil {
+		return err
+	}
+	if err := checkSignatureTimestamp(ctx, cs, sigData.Timestamp); err != nil {
+		return err
+	}
+	if err := verifyDelayPeriodPassed(ctx, store, cs.GetLatestHeight(), height, delayTimePeriod, delayBlockPeriod); err != nil {
+		return err
+	}
+	path, err := NextSequenceRecvCommitmentKey(prefix.Bytes(), portID, channelID)
+	if err != nil {
+		return err
+	}
+	signBz, err := NextSequenceRecvSignBytes(cdc, height.(clienttypes.Height), sigData.Timestamp, cons.Diversifier, path, nextSequenceRecv)
+	if err != nil {
+		return err
+	}
+	if err := verifyStateSignature(cdc, cons, sigData, signBz); err != nil {
+		return err
+	}
+	setSequence(store, cdc, cs, height, sigData)
+	return nil
 }
 
-func (cs *ClientState) VerifyPacketReceiptAbsence(ctx sdk.Context, store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, prefix exported.Prefix, proof []byte, portID string, channelID string, sequence uint64) error {
-	panic("not implemented") // TODO: Implement
+// verifyStateSignature verifies the multisig over the sign bytes of a state.
+// If the multisig carries a batch proof, the signers signed the root of a batch instead,
+// and the state data of the sign bytes must be a leaf of the batch.
+func verifyStateSignature(cdc codec.BinaryCodec, cons *ConsensusState, multiSig *MultiSignature, signBz []byte) error {
+	if multiSig.Batch == nil {
+		return cons.VerifySignature(multiSig, signBz)
+	}
+	var signBytes SignBytes
+	if err := cdc.Unmarshal(signBz, &signBytes); err != nil {
+		return err
+	}
+	if err := multiSig.Batch.Verify(signBytes.Data); err != nil {
+		return err
+	}
+	batchSignBz, err := BatchSignBytes(cdc, clienttypes.Height(signBytes.Height), signBytes.Timestamp, signBytes.Diversifier, multiSig.Batch.Root)
+	if err != nil {
+		return err
+	}
+	return cons.VerifySignature(multiSig, batchSignBz)
 }
 
-func (cs *ClientState) VerifyNextSequenceRecv(ctx sdk.Context, store sdk.KVStore, cdc codec.BinaryCodec, height exported.Height, delayTimePeriod uint64, delayBlockPeriod uint64, prefix exported.Prefix, proof []byte, portID string, channelID string, nextSequenceRecv uint64) error {
-	panic("not implemented") // TODO: Implement
+// checkActive returns an error if the client is frozen or expired at the block time.
+// The client keeper checks the status as well, but the packet verification functions
+// are given the context to check it on their own.
+func checkActive(ctx sdk.Context, store sdk.KVStore, cdc codec.BinaryCodec, cs ClientState) error {
+	if status := cs.Status(ctx, store, cdc); status != exported.Active {
+		return sdkerrors.Wrapf(clienttypes.ErrClientNotActive, "client status is %s", status)
+	}
+	return nil
+}
+
+// checkSignatureTimestamp returns an error if the signature timestamp is later than the block time
+// plus the max clock drift, or earlier than the block time minus the max signature age.
+func checkSignatureTimestamp(ctx sdk.Context, cs ClientState, timestamp uint64) error {
+	now := uint64(ctx.BlockTime().UnixNano())
+	if cs.MaxClockDrift != 0 && timestamp > now && timestamp-now > cs.MaxClockDrift {
+		return sdkerrors.Wrapf(
+			ErrInvalidTimestamp, "signature timestamp is too far in the future (timestamp %d, block time %d, max clock drift %d)",
+			timestamp, now, cs.MaxClockDrift,
+		)
+	}
+	if cs.MaxSignatureAge != 0 && now > timestamp && now-timestamp > cs.MaxSignatureAge {
+		return sdkerrors.Wrapf(
+			ErrInvalidTimestamp, "signature is too old (timestamp %d, block time %d, max signature age %d)",
+			timestamp, now, cs.MaxSignatureAge,
+		)
+	}
+	return nil
+}
+
+// verifyDelayPeriodPassed will ensure that at least delayTimePeriod amount of time and delayBlockPeriod number of blocks have passed
+// since the consensus state which is valid at the proof height was created on this chain.
+func verifyDelayPeriodPassed(ctx sdk.Context, store sdk.KVStore, latestHeight, proofHeight exported.Height, delayTimePeriod, delayBlockPeriod uint64) error {
+	consensusHeight, err := getConsensusHeightForHeight(store, latestHeight, proofHeight)
+	if err != nil {
+		return err
+	}
+
+	// check that executing chain's timestamp has passed consensusState's processed time + delay time period
+	processedTime, ok := GetProcessedTime(store, consensusHeight)
+	if !ok {
+		return sdkerrors.Wrapf(ErrProcessedTimeNotFound, "processed time not found for height: %s", consensusHeight)
+	}
+	currentTimestamp := uint64(ctx.BlockTime().UnixNano())
+	validTime := processedTime + delayTimePeriod
+	// NOTE: delay time period is inclusive, so if currentTimestamp is validTime, then we return no error
+	if currentTimestamp < validTime {
+		return sdkerrors.Wrapf(ErrDelayPeriodNotPassed, "cannot verify packet until time: %d, current time: %d",
+			validTime, currentTimestamp)
+	}
+
+	// check that executing chain's height has passed consensusState's processed height + delay block period
+	processedHeight, ok := GetProcessedHeight(store, consensusHeight)
+	if !ok {
+		return sdkerrors.Wrapf(ErrProcessedHeightNotFound, "processed height not found for height: %s", consensusHeight)
+	}
+	currentHeight := clienttypes.GetSelfHeight(ctx)
+	validHeight := clienttypes.NewHeight(processedHeight.GetRevisionNumber(), processedHeight.GetRevisionHeight()+delayBlockPeriod)
+	// NOTE: delay block period is inclusive, so if currentHeight is validHeight, then we return no error
+	if currentHeight.LT(validHeight) {
+		return sdkerrors.Wrapf(ErrDelayPeriodNotPassed, "cannot verify packet until height: %s, current height: %s",
+			validHeight, currentHeight)
+	}
+	return nil
 }
 
 // produceVerificationArgs perfoms the basic checks on the arguments that are
-// shared between the verification functions and returns the public key of the
-// consensus state, the unmarshalled proof representing the signature and timestamp
-// along with the solo-machine sequence encoded in the proofHeight.
+// shared between the verification functions and returns the consensus state which
+// is valid at the proof height and the unmarshalled proof representing the signature
+// and timestamp. The sequence encoded in the proofHeight must be newer than the
+// sequence of the proofs verified so far.
 func produceVerificationArgs(
 	store sdk.KVStore,
 	cdc codec.BinaryCodec,
@@ -154,7 +437,11 @@ func produceVerificationArgs(
 		return nil, nil, err
 	}
 
-	cons, err := getConsensusState(store, cdc, height)
+	if err := checkSequence(cs, height, &multiSig); err != nil {
+		return nil, nil, err
+	}
+
+	cons, err := getConsensusStateForHeight(store, cdc, cs.GetLatestHeight(), height)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -165,3 +452,45 @@ func produceVerificationArgs(
 
 	return cons, &multiSig, nil
 }
+
+// checkSequence checks that the proof with the sequence encoded in the height is newer than the
+// proofs verified so far. A sequence is used only once, as the signers sign different data with
+// the same sequence only if they misbehave. The leaves of the batch verified at the latest sequence
+// can share it, which allows the connection handshake to verify several states at one proof height.
+func checkSequence(cs ClientState, height exported.Height, multiSig *MultiSignature) error {
+	if height.GetRevisionNumber() != cs.GetLatestHeight().GetRevisionNumber() {
+		return sdkerrors.Wrapf(
+			ErrInvalidSequence, "proof height revision must be the same as the latest height revision (%d != %d)",
+			height.GetRevisionNumber(), cs.GetLatestHeight().GetRevisionNumber(),
+		)
+	}
+	sequence := height.GetRevisionHeight()
+	switch {
+	case sequence == cs.Sequence && multiSig.Batch != nil:
+		if len(cs.BatchRoot) == 0 || !bytes.Equal(cs.BatchRoot, multiSig.Batch.Root) {
+			return sdkerrors.Wrapf(ErrInvalidSequence, "proof sequence %d has already been used for another batch or state", sequence)
+		}
+	case sequence == cs.Sequence:
+		return sdkerrors.Wrapf(ErrInvalidSequence, "proof sequence %d has already been used", sequence)
+	case sequence < cs.Sequence:
+		return sdkerrors.Wrapf(ErrInvalidSequence, "proof sequence is older than the latest sequence (%d < %d)", sequence, cs.Sequence)
+	case sequence > cs.Sequence && multiSig.Timestamp < cs.Timestamp:
+		return sdkerrors.Wrapf(ErrInvalidTimestamp, "proof timestamp is less than the latest timestamp (%d < %d)", multiSig.Timestamp, cs.Timestamp)
+	}
+	return nil
+}
+
+// setSequence records the sequence, timestamp and batch root of the verified proof and stores the client state.
+func setSequence(store sdk.KVStore, cdc codec.BinaryCodec, cs ClientState, height exported.Height, multiSig *MultiSignature) {
+	if sequence := height.GetRevisionHeight(); sequence > cs.Sequence {
+		cs.Sequence = sequence
+		cs.BatchRoot = nil
+	}
+	if multiSig.Batch != nil {
+		cs.BatchRoot = multiSig.Batch.Root
+	}
+	if multiSig.Timestamp > cs.Timestamp {
+		cs.Timestamp = multiSig.Timestamp
+	}
+	setClientState(store, cdc, &cs)
+}
@@ -20,4 +20,8 @@ func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
 		(*exported.Header)(nil),
 		&Header{},
 	)
+	registry.RegisterImplementations(
+		(*exported.Misbehaviour)(nil),
+		&Misbehaviour{},
+	)
 }
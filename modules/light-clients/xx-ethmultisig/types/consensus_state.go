@@ -1,6 +1,9 @@
 package types
 
 import (
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/ibc-go/modules/core/exported"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -20,7 +23,17 @@ func (cs *ConsensusState) GetTimestamp() uint64 {
 	return cs.Timestamp
 }
 
+// ValidateBasic defines basic validation for the multisig consensus state.
 func (cs *ConsensusState) ValidateBasic() error {
+	if err := validateSignerSet(cs.Addresses, cs.Powers, cs.Threshold); err != nil {
+		return err
+	}
+	if strings.TrimSpace(cs.Diversifier) == "" {
+		return sdkerrors.Wrap(ErrInvalidDiversifier, "diversifier cannot be blank")
+	}
+	if cs.Timestamp == 0 {
+		return sdkerrors.Wrap(ErrInvalidTimestamp, "timestamp cannot be 0")
+	}
 	return nil
 }
 
@@ -31,3 +44,67 @@ func (cs *ConsensusState) GetAddresses() []common.Address {
 	}
 	return addrs
 }
+
+// GetPowers returns the voting power of each address.
+// Every address has a voting power of one if the powers are not set.
+func (cs *ConsensusState) GetPowers() []uint64 {
+	powers, _, err := normalizePowers(len(cs.Addresses), cs.Powers)
+	if err != nil {
+		return cs.Powers
+	}
+	return powers
+}
+
+// GetTotalPower returns the sum of the voting powers of the addresses.
+func (cs *ConsensusState) GetTotalPower() uint64 {
+	var total uint64
+	for _, power := range cs.GetPowers() {
+		total += power
+	}
+	return total
+}
+
+// GetThreshold returns the minimum total voting power of the signers required for a valid proof.
+// A zero threshold requires the total power, that is, every address must sign.
+func (cs *ConsensusState) GetThreshold() uint64 {
+	if cs.Threshold == 0 {
+		return cs.GetTotalPower()
+	}
+	return cs.Threshold
+}
+
+// VerifySignature verifies that signers of the consensus state with at least the threshold power signed over the sign bytes.
+func (cs *ConsensusState) VerifySignature(multiSig *MultiSignature, signBytes []byte) error {
+	return VerifyWeightedSignature(cs.GetAddresses(), cs.Powers, cs.GetThreshold(), multiSig, signBytes)
+}
+
+// validateSignerSet checks that the signer set is not empty, every address is a non-zero
+// 20 bytes address without duplicates, every address has a positive voting power
+// and the threshold does not exceed the total power.
+func validateSignerSet(addresses [][]byte, powers []uint64, threshold uint64) error {
+	if len(addresses) == 0 {
+		return sdkerrors.Wrap(ErrInvalidSignerSet, "addresses cannot be empty")
+	}
+	seen := make(map[common.Address]bool, len(addresses))
+	for i, bz := range addresses {
+		if len(bz) != common.AddressLength {
+			return sdkerrors.Wrapf(ErrInvalidAddress, "address must be %d bytes long: index=%d length=%d", common.AddressLength, i, len(bz))
+		}
+		addr := common.BytesToAddress(bz)
+		if addr == (common.Address{}) {
+			return sdkerrors.Wrapf(ErrInvalidAddress, "address cannot be zero: index=%d", i)
+		}
+		if seen[addr] {
+			return sdkerrors.Wrapf(ErrDuplicateSigner, "address %v", addr)
+		}
+		seen[addr] = true
+	}
+	_, totalPower, err := normalizePowers(len(addresses), powers)
+	if err != nil {
+		return err
+	}
+	if threshold > totalPower {
+		return sdkerrors.Wrapf(ErrInvalidThreshold, "threshold must not be greater than the total power (%d > %d)", threshold, totalPower)
+	}
+	return nil
+}
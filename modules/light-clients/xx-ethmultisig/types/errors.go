@@ -5,6 +5,23 @@ import (
 )
 
 var (
-	ErrInvalidProof          = sdkerrors.Register(ModuleName, 1, "invalid Multisig proof")
-	ErrInvalidSignatureCount = sdkerrors.Register(ModuleName, 2, "invalid signature count")
+	ErrInvalidProof            = sdkerrors.Register(ModuleName, 1, "invalid Multisig proof")
+	ErrInvalidSignatureCount   = sdkerrors.Register(ModuleName, 2, "invalid signature count")
+	ErrInvalidSignatureAndData = sdkerrors.Register(ModuleName, 3, "invalid signature and data")
+	ErrInvalidThreshold        = sdkerrors.Register(ModuleName, 4, "invalid threshold")
+	ErrDuplicateSigner         = sdkerrors.Register(ModuleName, 5, "duplicate signer")
+	ErrInvalidSignerBitmap     = sdkerrors.Register(ModuleName, 6, "invalid signer bitmap")
+	ErrInvalidSignerSet        = sdkerrors.Register(ModuleName, 7, "invalid signer set")
+	ErrInvalidAddress          = sdkerrors.Register(ModuleName, 8, "invalid signer address")
+	ErrInvalidDiversifier      = sdkerrors.Register(ModuleName, 9, "invalid diversifier")
+	ErrInvalidTimestamp        = sdkerrors.Register(ModuleName, 10, "invalid timestamp")
+	ErrInvalidHeight           = sdkerrors.Register(ModuleName, 11, "invalid height")
+	ErrInvalidSignature        = sdkerrors.Register(ModuleName, 12, "invalid signature")
+	ErrProcessedTimeNotFound   = sdkerrors.Register(ModuleName, 13, "processed time not found")
+	ErrProcessedHeightNotFound = sdkerrors.Register(ModuleName, 14, "processed height not found")
+	ErrDelayPeriodNotPassed    = sdkerrors.Register(ModuleName, 15, "packet-specified delay period has not been reached")
+	ErrInvalidSequence         = sdkerrors.Register(ModuleName, 16, "invalid sequence")
+	ErrInsufficientPower       = sdkerrors.Register(ModuleName, 17, "insufficient voting power")
+	ErrInvalidBatchProof       = sdkerrors.Register(ModuleName, 18, "invalid batch proof")
+	ErrInvalidPeriod           = sdkerrors.Register(ModuleName, 19, "invalid period")
 )
@@ -25,6 +25,34 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// Version defines the encoding of the signatures.
+type MultiSignature_Version int32
+
+const (
+	// 65-byte [R || S || V] signatures in the signatures field
+	LEGACY MultiSignature_Version = 0
+	// 64-byte EIP-2098 [R || YParityAndS] signatures concatenated in the compact_signatures field
+	COMPACT MultiSignature_Version = 1
+)
+
+var MultiSignature_Version_name = map[int32]string{
+	0: "VERSION_LEGACY_UNSPECIFIED",
+	1: "VERSION_COMPACT",
+}
+
+var MultiSignature_Version_value = map[string]int32{
+	"VERSION_LEGACY_UNSPECIFIED": 0,
+	"VERSION_COMPACT":            1,
+}
+
+func (x MultiSignature_Version) String() string {
+	return proto.EnumName(MultiSignature_Version_name, int32(x))
+}
+
+func (MultiSignature_Version) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d9eac80789dcd4f5, []int{3, 0}
+}
+
 // DataType defines the type of multisig proof being created. This is done
 // to preserve uniqueness of different data sign byte encodings.
 type SignBytes_DataType int32
@@ -50,19 +78,25 @@ const (
 	NEXTSEQUENCERECV SignBytes_DataType = 8
 	// Data type for header verification
 	HEADER SignBytes_DataType = 9
+	// Data type for client upgrade verification
+	UPGRADE SignBytes_DataType = 10
+	// Data type for batch root verification
+	BATCH SignBytes_DataType = 11
 )
 
 var SignBytes_DataType_name = map[int32]string{
-	0: "DATA_TYPE_UNINITIALIZED_UNSPECIFIED",
-	1: "DATA_TYPE_CLIENT_STATE",
-	2: "DATA_TYPE_CONSENSUS_STATE",
-	3: "DATA_TYPE_CONNECTION_STATE",
-	4: "DATA_TYPE_CHANNEL_STATE",
-	5: "DATA_TYPE_PACKET_COMMITMENT",
-	6: "DATA_TYPE_PACKET_ACKNOWLEDGEMENT",
-	7: "DATA_TYPE_PACKET_RECEIPT_ABSENCE",
-	8: "DATA_TYPE_NEXT_SEQUENCE_RECV",
-	9: "DATA_TYPE_HEADER",
+	0:  "DATA_TYPE_UNINITIALIZED_UNSPECIFIED",
+	1:  "DATA_TYPE_CLIENT_STATE",
+	2:  "DATA_TYPE_CONSENSUS_STATE",
+	3:  "DATA_TYPE_CONNECTION_STATE",
+	4:  "DATA_TYPE_CHANNEL_STATE",
+	5:  "DATA_TYPE_PACKET_COMMITMENT",
+	6:  "DATA_TYPE_PACKET_ACKNOWLEDGEMENT",
+	7:  "DATA_TYPE_PACKET_RECEIPT_ABSENCE",
+	8:  "DATA_TYPE_NEXT_SEQUENCE_RECV",
+	9:  "DATA_TYPE_HEADER",
+	10: "DATA_TYPE_UPGRADE",
+	11: "DATA_TYPE_BATCH",
 }
 
 var SignBytes_DataType_value = map[string]int32{
@@ -76,6 +110,8 @@ var SignBytes_DataType_value = map[string]int32{
 	"DATA_TYPE_PACKET_RECEIPT_ABSENCE":    7,
 	"DATA_TYPE_NEXT_SEQUENCE_RECV":        8,
 	"DATA_TYPE_HEADER":                    9,
+	"DATA_TYPE_UPGRADE":                   10,
+	"DATA_TYPE_BATCH":                     11,
 }
 
 func (x SignBytes_DataType) String() string {
@@ -83,12 +119,34 @@ func (x SignBytes_DataType) String() string {
 }
 
 func (SignBytes_DataType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_d9eac80789dcd4f5, []int{4, 0}
+	return fileDescriptor_d9eac80789dcd4f5, []int{5, 0}
 }
 
 type ClientState struct {
 	LatestHeight client.Height `protobuf:"bytes,1,opt,name=latest_height,json=latestHeight,proto3" json:"latest_height"`
 	FrozenHeight client.Height `protobuf:"bytes,2,opt,name=frozen_height,json=frozenHeight,proto3" json:"frozen_height" yaml:"frozen_height"`
+	// maximum number of consensus states to keep. zero means no limit.
+	MaxConsensusStates uint64 `protobuf:"varint,3,opt,name=max_consensus_states,json=maxConsensusStates,proto3" json:"max_consensus_states,omitempty" yaml:"max_consensus_states"`
+	// consensus states older than this age (in nanoseconds) relative to the latest
+	// consensus state are pruned. zero means no limit.
+	ConsensusStateMaxAge uint64 `protobuf:"varint,4,opt,name=consensus_state_max_age,json=consensusStateMaxAge,proto3" json:"consensus_state_max_age,omitempty" yaml:"consensus_state_max_age"`
+	// sequence of the latest verified proof, which is carried in the revision height of the proof height.
+	// each verification must use a newer sequence than this.
+	Sequence uint64 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// greatest timestamp of the verified proofs.
+	Timestamp uint64 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// the client expires if the latest consensus state is older than this period (in nanoseconds)
+	// relative to the block time. zero means that the client never expires.
+	TrustingPeriod uint64 `protobuf:"varint,7,opt,name=trusting_period,json=trustingPeriod,proto3" json:"trusting_period,omitempty" yaml:"trusting_period"`
+	// signatures with a timestamp later than the block time plus this drift (in nanoseconds)
+	// are rejected. zero means no limit.
+	MaxClockDrift uint64 `protobuf:"varint,8,opt,name=max_clock_drift,json=maxClockDrift,proto3" json:"max_clock_drift,omitempty" yaml:"max_clock_drift"`
+	// signatures with a timestamp earlier than the block time minus this age (in nanoseconds)
+	// are rejected. zero means no limit.
+	MaxSignatureAge uint64 `protobuf:"varint,9,opt,name=max_signature_age,json=maxSignatureAge,proto3" json:"max_signature_age,omitempty" yaml:"max_signature_age"`
+	// root of the batch verified at the sequence. the other leaves of the batch can be verified
+	// at the sequence regardless of their data types.
+	BatchRoot []byte `protobuf:"bytes,10,opt,name=batch_root,json=batchRoot,proto3" json:"batch_root,omitempty" yaml:"batch_root"`
 }
 
 func (m *ClientState) Reset()         { *m = ClientState{} }
@@ -128,6 +186,12 @@ type ConsensusState struct {
 	Addresses   [][]byte `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty" yaml:"addresses"`
 	Diversifier string   `protobuf:"bytes,2,opt,name=diversifier,proto3" json:"diversifier,omitempty"`
 	Timestamp   uint64   `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// minimum total voting power of the signers required for a valid proof.
+	// zero means the total power of the addresses, that is, every address must sign.
+	Threshold uint64 `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// voting power of each address, where powers[i] corresponds to addresses[i].
+	// if empty, every address has a voting power of one.
+	Powers []uint64 `protobuf:"varint,5,rep,packed,name=powers,proto3" json:"powers,omitempty"`
 }
 
 func (m *ConsensusState) Reset()         { *m = ConsensusState{} }
@@ -171,6 +235,8 @@ type Header struct {
 	Signature      *MultiSignature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 	NewAddresses   [][]byte        `protobuf:"bytes,4,rep,name=new_addresses,json=newAddresses,proto3" json:"new_addresses,omitempty" yaml:"new_addresses"`
 	NewDiversifier string          `protobuf:"bytes,5,opt,name=new_diversifier,json=newDiversifier,proto3" json:"new_diversifier,omitempty" yaml:"new_diversifier"`
+	NewThreshold   uint64          `protobuf:"varint,6,opt,name=new_threshold,json=newThreshold,proto3" json:"new_threshold,omitempty" yaml:"new_threshold"`
+	NewPowers      []uint64        `protobuf:"varint,7,rep,packed,name=new_powers,json=newPowers,proto3" json:"new_powers,omitempty" yaml:"new_powers"`
 }
 
 func (m *Header) Reset()         { *m = Header{} }
@@ -209,6 +275,17 @@ var xxx_messageInfo_Header proto.InternalMessageInfo
 type MultiSignature struct {
 	Signatures [][]byte `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures,omitempty"`
 	Timestamp  uint64   `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// bitmap of the signers that took part, where bit i (the i%8-th least
+	// significant bit of byte i/8) corresponds to addresses[i] of the consensus state.
+	// signatures are ordered by ascending signer index.
+	// if empty, every address must sign in the order of the consensus state.
+	SignerBitmap []byte                 `protobuf:"bytes,3,opt,name=signer_bitmap,json=signerBitmap,proto3" json:"signer_bitmap,omitempty" yaml:"signer_bitmap"`
+	Version      MultiSignature_Version `protobuf:"varint,4,opt,name=version,proto3,enum=MultiSignature_Version" json:"version,omitempty"`
+	// concatenated 64-byte signatures of the compact version, ordered in the same way as signatures.
+	CompactSignatures []byte `protobuf:"bytes,5,opt,name=compact_signatures,json=compactSignatures,proto3" json:"compact_signatures,omitempty" yaml:"compact_signatures"`
+	// if set, the signatures are over the batch root instead of the state,
+	// and the state is proven to be a leaf of the batch.
+	Batch *BatchProof `protobuf:"bytes,6,opt,name=batch,proto3" json:"batch,omitempty"`
 }
 
 func (m *MultiSignature) Reset()         { *m = MultiSignature{} }
@@ -258,13 +335,85 @@ func (m *MultiSignature) GetTimestamp() uint64 {
 	return 0
 }
 
+func (m *MultiSignature) GetSignerBitmap() []byte {
+	if m != nil {
+		return m.SignerBitmap
+	}
+	return nil
+}
+
+func (m *MultiSignature) GetVersion() MultiSignature_Version {
+	if m != nil {
+		return m.Version
+	}
+	return LEGACY
+}
+
+func (m *MultiSignature) GetCompactSignatures() []byte {
+	if m != nil {
+		return m.CompactSignatures
+	}
+	return nil
+}
+
+func (m *MultiSignature) GetBatch() *BatchProof {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+// BatchProof is a Merkle inclusion proof of a StateData leaf in a batch of states signed at once.
+// The leaves are hashed as keccak256(0x00 || leaf) and the nodes as keccak256(0x01 || left || right).
+// The last node of a level with an odd number of nodes is promoted to the next level.
+type BatchProof struct {
+	Root      []byte `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Index     uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	NumLeaves uint64 `protobuf:"varint,3,opt,name=num_leaves,json=numLeaves,proto3" json:"num_leaves,omitempty" yaml:"num_leaves"`
+	// sibling hashes from the leaf to the root, excluding the levels where the node is promoted
+	Siblings [][]byte `protobuf:"bytes,4,rep,name=siblings,proto3" json:"siblings,omitempty"`
+}
+
+func (m *BatchProof) Reset()         { *m = BatchProof{} }
+func (m *BatchProof) String() string { return proto.CompactTextString(m) }
+func (*BatchProof) ProtoMessage()    {}
+func (*BatchProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9eac80789dcd4f5, []int{4}
+}
+func (m *BatchProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchProof.Merge(m, src)
+}
+func (m *BatchProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchProof proto.InternalMessageInfo
+
 type SignBytes struct {
 	Height      client.Height `protobuf:"bytes,1,opt,name=height,proto3" json:"height"`
 	Timestamp   uint64        `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Diversifier string        `protobuf:"bytes,3,opt,name=diversifier,proto3" json:"diversifier,omitempty"`
 	// type of the data used
 	DataType SignBytes_DataType `protobuf:"varint,4,opt,name=data_type,json=dataType,proto3,enum=SignBytes_DataType" json:"data_type,omitempty" yaml:"data_type"`
-	// marshaled HeaderData or StateData
+	// marshaled HeaderData, UpgradeData, BatchData or StateData
 	Data []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
 }
 
@@ -272,7 +421,7 @@ func (m *SignBytes) Reset()         { *m = SignBytes{} }
 func (m *SignBytes) String() string { return proto.CompactTextString(m) }
 func (*SignBytes) ProtoMessage()    {}
 func (*SignBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d9eac80789dcd4f5, []int{4}
+	return fileDescriptor_d9eac80789dcd4f5, []int{5}
 }
 func (m *SignBytes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -307,13 +456,17 @@ type HeaderData struct {
 	NewAddresses [][]byte `protobuf:"bytes,1,rep,name=new_addresses,json=newAddresses,proto3" json:"new_addresses,omitempty" yaml:"new_addresses"`
 	// header diversifier
 	NewDiversifier string `protobuf:"bytes,2,opt,name=new_diversifier,json=newDiversifier,proto3" json:"new_diversifier,omitempty" yaml:"new_diversifier"`
+	// header threshold
+	NewThreshold uint64 `protobuf:"varint,3,opt,name=new_threshold,json=newThreshold,proto3" json:"new_threshold,omitempty" yaml:"new_threshold"`
+	// header voting powers
+	NewPowers []uint64 `protobuf:"varint,4,rep,packed,name=new_powers,json=newPowers,proto3" json:"new_powers,omitempty" yaml:"new_powers"`
 }
 
 func (m *HeaderData) Reset()         { *m = HeaderData{} }
 func (m *HeaderData) String() string { return proto.CompactTextString(m) }
 func (*HeaderData) ProtoMessage()    {}
 func (*HeaderData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d9eac80789dcd4f5, []int{5}
+	return fileDescriptor_d9eac80789dcd4f5, []int{6}
 }
 func (m *HeaderData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -342,6 +495,86 @@ func (m *HeaderData) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_HeaderData proto.InternalMessageInfo
 
+// BatchData returns the SignBytes data for batch verification.
+type BatchData struct {
+	// Merkle root over the StateData leaves of the batch
+	Root []byte `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+}
+
+func (m *BatchData) Reset()         { *m = BatchData{} }
+func (m *BatchData) String() string { return proto.CompactTextString(m) }
+func (*BatchData) ProtoMessage()    {}
+func (*BatchData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9eac80789dcd4f5, []int{7}
+}
+func (m *BatchData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchData.Merge(m, src)
+}
+func (m *BatchData) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchData) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchData proto.InternalMessageInfo
+
+// UpgradeData returns the SignBytes data for upgrade verification.
+type UpgradeData struct {
+	// upgraded client state with its custom fields zeroed
+	ClientState []byte `protobuf:"bytes,1,opt,name=client_state,json=clientState,proto3" json:"client_state,omitempty" yaml:"client_state"`
+	// upgraded consensus state
+	ConsensusState []byte `protobuf:"bytes,2,opt,name=consensus_state,json=consensusState,proto3" json:"consensus_state,omitempty" yaml:"consensus_state"`
+}
+
+func (m *UpgradeData) Reset()         { *m = UpgradeData{} }
+func (m *UpgradeData) String() string { return proto.CompactTextString(m) }
+func (*UpgradeData) ProtoMessage()    {}
+func (*UpgradeData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9eac80789dcd4f5, []int{8}
+}
+func (m *UpgradeData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpgradeData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpgradeData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpgradeData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpgradeData.Merge(m, src)
+}
+func (m *UpgradeData) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpgradeData) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpgradeData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpgradeData proto.InternalMessageInfo
+
 type StateData struct {
 	Path  []byte `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -351,7 +584,7 @@ func (m *StateData) Reset()         { *m = StateData{} }
 func (m *StateData) String() string { return proto.CompactTextString(m) }
 func (*StateData) ProtoMessage()    {}
 func (*StateData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d9eac80789dcd4f5, []int{6}
+	return fileDescriptor_d9eac80789dcd4f5, []int{9}
 }
 func (m *StateData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -380,15 +613,103 @@ func (m *StateData) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StateData proto.InternalMessageInfo
 
+// Misbehaviour defines misbehaviour for a multisig which consists of
+// two signatures over different messages at the same height.
+type Misbehaviour struct {
+	ClientId     string            `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty" yaml:"client_id"`
+	Height       client.Height     `protobuf:"bytes,2,opt,name=height,proto3" json:"height"`
+	SignatureOne *SignatureAndData `protobuf:"bytes,3,opt,name=signature_one,json=signatureOne,proto3" json:"signature_one,omitempty" yaml:"signature_one"`
+	SignatureTwo *SignatureAndData `protobuf:"bytes,4,opt,name=signature_two,json=signatureTwo,proto3" json:"signature_two,omitempty" yaml:"signature_two"`
+}
+
+func (m *Misbehaviour) Reset()         { *m = Misbehaviour{} }
+func (m *Misbehaviour) String() string { return proto.CompactTextString(m) }
+func (*Misbehaviour) ProtoMessage()    {}
+func (*Misbehaviour) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9eac80789dcd4f5, []int{10}
+}
+func (m *Misbehaviour) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Misbehaviour) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Misbehaviour.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Misbehaviour) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Misbehaviour.Merge(m, src)
+}
+func (m *Misbehaviour) XXX_Size() int {
+	return m.Size()
+}
+func (m *Misbehaviour) XXX_DiscardUnknown() {
+	xxx_messageInfo_Misbehaviour.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Misbehaviour proto.InternalMessageInfo
+
+// SignatureAndData contains a multisig signature and the sign bytes
+// that were signed over to create that signature.
+type SignatureAndData struct {
+	Signature *MultiSignature `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	SignBytes *SignBytes      `protobuf:"bytes,2,opt,name=sign_bytes,json=signBytes,proto3" json:"sign_bytes,omitempty" yaml:"sign_bytes"`
+}
+
+func (m *SignatureAndData) Reset()         { *m = SignatureAndData{} }
+func (m *SignatureAndData) String() string { return proto.CompactTextString(m) }
+func (*SignatureAndData) ProtoMessage()    {}
+func (*SignatureAndData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9eac80789dcd4f5, []int{11}
+}
+func (m *SignatureAndData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignatureAndData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignatureAndData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignatureAndData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignatureAndData.Merge(m, src)
+}
+func (m *SignatureAndData) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignatureAndData) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignatureAndData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignatureAndData proto.InternalMessageInfo
+
 func init() {
+	proto.RegisterEnum("MultiSignature_Version", MultiSignature_Version_name, MultiSignature_Version_value)
 	proto.RegisterEnum("SignBytes_DataType", SignBytes_DataType_name, SignBytes_DataType_value)
 	proto.RegisterType((*ClientState)(nil), "ClientState")
 	proto.RegisterType((*ConsensusState)(nil), "ConsensusState")
 	proto.RegisterType((*Header)(nil), "Header")
 	proto.RegisterType((*MultiSignature)(nil), "MultiSignature")
+	proto.RegisterType((*BatchProof)(nil), "BatchProof")
 	proto.RegisterType((*SignBytes)(nil), "SignBytes")
 	proto.RegisterType((*HeaderData)(nil), "HeaderData")
+	proto.RegisterType((*BatchData)(nil), "BatchData")
+	proto.RegisterType((*UpgradeData)(nil), "UpgradeData")
 	proto.RegisterType((*StateData)(nil), "StateData")
+	proto.RegisterType((*Misbehaviour)(nil), "Misbehaviour")
+	proto.RegisterType((*SignatureAndData)(nil), "SignatureAndData")
 }
 
 func init() {
@@ -396,66 +717,110 @@ func init() {
 }
 
 var fileDescriptor_d9eac80789dcd4f5 = []byte{
-	// 942 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x41, 0x6f, 0xe3, 0x44,
-	0x14, 0x8e, 0x5b, 0x37, 0x6d, 0x26, 0x69, 0x1a, 0x0d, 0xa1, 0x64, 0x4d, 0x95, 0x58, 0x46, 0xa0,
-	0x1c, 0x36, 0xb1, 0xb6, 0x08, 0x84, 0x16, 0x01, 0x72, 0x9c, 0x59, 0x6a, 0xb6, 0x75, 0x8b, 0xe3,
-	0x02, 0xbb, 0x17, 0xcb, 0x49, 0xa6, 0x89, 0x45, 0x62, 0x47, 0x9e, 0x49, 0xdb, 0x70, 0xe4, 0x80,
-	0x56, 0x16, 0x48, 0x48, 0x48, 0xdc, 0x22, 0x21, 0xf1, 0x1b, 0xf8, 0x0f, 0x7b, 0xdc, 0x23, 0xa7,
-	0x0a, 0xb5, 0xff, 0xa0, 0xbf, 0x00, 0xd9, 0xe3, 0xc4, 0x4e, 0x8b, 0xf6, 0x00, 0x9c, 0x3c, 0xef,
-	0xfb, 0xbe, 0xf7, 0xe6, 0xf9, 0x3d, 0x3f, 0x3f, 0xf0, 0xc8, 0xe9, 0xf6, 0xe4, 0x91, 0x33, 0x18,
-	0xd2, 0xde, 0xc8, 0xc1, 0x2e, 0x25, 0x32, 0xa6, 0xc3, 0xf1, 0x74, 0x44, 0x1d, 0xe2, 0x0c, 0xe4,
-	0xf3, 0x47, 0x69, 0xb3, 0x39, 0xf1, 0x3d, 0xea, 0x09, 0x12, 0xf1, 0x46, 0x4e, 0xdf, 0xa1, 0xb3,
-	0x46, 0x64, 0x77, 0xa7, 0x67, 0x0d, 0x7c, 0x49, 0xb1, 0x4b, 0x1c, 0xcf, 0x25, 0xb1, 0xa6, 0x3c,
-	0xf0, 0x06, 0x5e, 0x74, 0x94, 0xc3, 0x53, 0x8c, 0x3e, 0x9c, 0x4d, 0x9d, 0x86, 0xd3, 0xed, 0x35,
-	0x16, 0x11, 0xe4, 0xc9, 0xb7, 0x03, 0x39, 0xcc, 0x80, 0x5d, 0x2e, 0xab, 0xd1, 0x83, 0xa9, 0xa5,
-	0x5f, 0x38, 0x90, 0x67, 0x40, 0x87, 0xda, 0x14, 0xc3, 0x7d, 0xb0, 0x3d, 0xb2, 0x29, 0x26, 0xd4,
-	0x1a, 0xe2, 0x30, 0xe1, 0x0a, 0x27, 0x72, 0xf5, 0xfc, 0xfe, 0x66, 0xf3, 0x20, 0x32, 0x5b, 0xfc,
-	0xcb, 0xab, 0x5a, 0xc6, 0x28, 0x30, 0x0d, 0xc3, 0xe0, 0x17, 0x60, 0xfb, 0xcc, 0xf7, 0xbe, 0xc3,
-	0xee, 0xc2, 0x67, 0x6d, 0xd5, 0x67, 0x2f, 0xf4, 0xb9, 0xbd, 0xaa, 0x95, 0x67, 0xf6, 0x78, 0xf4,
-	0x58, 0x5a, 0xd1, 0x4a, 0x46, 0x81, 0xd9, 0x4c, 0xfb, 0x98, 0x7f, 0xf1, 0x5b, 0x2d, 0x23, 0x05,
-	0x1c, 0x28, 0xaa, 0x9e, 0x4b, 0xb0, 0x4b, 0xa6, 0x64, 0x91, 0x58, 0xce, 0xee, 0xf7, 0x7d, 0x4c,
-	0x08, 0x26, 0x15, 0x4e, 0x5c, 0xaf, 0x17, 0x5a, 0xe5, 0xdb, 0xab, 0x5a, 0x89, 0xc5, 0x5c, 0x52,
-	0x92, 0x91, 0xc8, 0xa0, 0x08, 0xf2, 0x7d, 0xe7, 0x1c, 0xfb, 0xc4, 0x39, 0x73, 0xb0, 0x1f, 0xa5,
-	0x95, 0x33, 0xd2, 0x10, 0xdc, 0x03, 0x39, 0xea, 0x8c, 0x31, 0xa1, 0xf6, 0x78, 0x52, 0x59, 0x17,
-	0xb9, 0x3a, 0x6f, 0x24, 0x40, 0x9c, 0xcc, 0x0f, 0x6b, 0x20, 0x7b, 0x80, 0xed, 0x3e, 0xf6, 0xe1,
-	0xbb, 0x20, 0xfb, 0xba, 0xb2, 0xc4, 0xe4, 0x6a, 0xd4, 0xb5, 0x3b, 0x51, 0x61, 0x03, 0xe4, 0x88,
-	0x33, 0x70, 0x6d, 0x3a, 0xf5, 0x71, 0x74, 0x67, 0x7e, 0x7f, 0xa7, 0x79, 0x14, 0xb6, 0xbf, 0xb3,
-	0x80, 0x8d, 0x44, 0x01, 0x3f, 0x01, 0xdb, 0x2e, 0xbe, 0xb0, 0x92, 0x97, 0xe7, 0xa3, 0x97, 0xaf,
-	0x24, 0x05, 0x5d, 0xa1, 0x25, 0xa3, 0xe0, 0xe2, 0x0b, 0x65, 0x59, 0x03, 0x15, 0xec, 0x84, 0x7c,
-	0xba, 0x0e, 0x1b, 0x61, 0x1d, 0x5a, 0xc2, 0xed, 0x55, 0x6d, 0x37, 0x09, 0x90, 0x12, 0x48, 0x46,
-	0xd1, 0xc5, 0x17, 0xed, 0x04, 0x88, 0x0b, 0xa1, 0x83, 0xe2, 0x6a, 0x9a, 0xb0, 0x0a, 0xc0, 0x32,
-	0xd1, 0xb8, 0x2b, 0x46, 0x0a, 0x79, 0x7d, 0x21, 0xa4, 0x9b, 0x0d, 0x90, 0x0b, 0x63, 0xb5, 0x66,
-	0x14, 0x93, 0xff, 0xa7, 0xb6, 0x77, 0x3a, 0xbe, 0x7e, 0xbf, 0xe3, 0x4f, 0x40, 0xae, 0x6f, 0x53,
-	0xdb, 0xa2, 0xb3, 0x09, 0xae, 0xf0, 0x22, 0x57, 0x2f, 0xee, 0xbf, 0xd1, 0x5c, 0x66, 0xd1, 0x6c,
-	0xdb, 0xd4, 0x36, 0x67, 0x13, 0x9c, 0xfe, 0xb8, 0x96, 0x7a, 0xc9, 0xd8, 0xea, 0xc7, 0x3c, 0x84,
-	0x80, 0x0f, 0xcf, 0x51, 0x31, 0x0b, 0x46, 0x74, 0x96, 0x7e, 0xe4, 0xc1, 0xd6, 0x22, 0x00, 0xfc,
-	0x08, 0xbc, 0xd3, 0x56, 0x4c, 0xc5, 0x32, 0x9f, 0x9d, 0x20, 0xeb, 0x54, 0xd7, 0x74, 0xcd, 0xd4,
-	0x94, 0x43, 0xed, 0x39, 0x6a, 0x5b, 0xa7, 0x7a, 0xe7, 0x04, 0xa9, 0xda, 0x13, 0x0d, 0xb5, 0x4b,
-	0x19, 0x61, 0x27, 0x98, 0x8b, 0xf9, 0x14, 0x04, 0xdf, 0x03, 0xbb, 0x89, 0xa7, 0x7a, 0xa8, 0x21,
-	0xdd, 0xb4, 0x3a, 0xa6, 0x62, 0xa2, 0x12, 0x27, 0x80, 0x60, 0x2e, 0x66, 0x19, 0x06, 0x1f, 0x82,
-	0x07, 0x29, 0xdd, 0xb1, 0xde, 0x41, 0x7a, 0xe7, 0xb4, 0x13, 0x4b, 0xd7, 0x84, 0xed, 0x60, 0x2e,
-	0xe6, 0x96, 0x30, 0x6c, 0x02, 0x61, 0x45, 0xad, 0x23, 0xd5, 0xd4, 0x8e, 0xf5, 0x58, 0xbe, 0x2e,
-	0x14, 0x83, 0xb9, 0x08, 0x12, 0x1c, 0xd6, 0xc1, 0x5b, 0x29, 0xfd, 0x81, 0xa2, 0xeb, 0xe8, 0x30,
-	0x16, 0xf3, 0x42, 0x3e, 0x98, 0x8b, 0x9b, 0x31, 0x08, 0x3f, 0x00, 0x6f, 0x27, 0xca, 0x13, 0x45,
-	0x7d, 0x8a, 0x4c, 0x4b, 0x3d, 0x3e, 0x3a, 0xd2, 0xcc, 0x23, 0xa4, 0x9b, 0xa5, 0x0d, 0xa1, 0x1c,
-	0xcc, 0xc5, 0x12, 0x23, 0x12, 0x1c, 0x7e, 0x06, 0xc4, 0x7b, 0x6e, 0x8a, 0xfa, 0x54, 0x3f, 0xfe,
-	0xfa, 0x10, 0xb5, 0x3f, 0x47, 0x91, 0x6f, 0x56, 0x78, 0x10, 0xcc, 0xc5, 0x37, 0x19, 0x7b, 0x87,
-	0x84, 0x9f, 0xfe, 0x43, 0x00, 0x03, 0xa9, 0x48, 0x3b, 0x31, 0x2d, 0xa5, 0xd5, 0x41, 0xba, 0x8a,
-	0x4a, 0x9b, 0x42, 0x25, 0x98, 0x8b, 0x65, 0xc6, 0xc6, 0x64, 0xcc, 0xc1, 0x0f, 0xc1, 0x5e, 0xe2,
-	0xaf, 0xa3, 0x6f, 0x4c, 0xab, 0x83, 0xbe, 0x3c, 0x0d, 0xa9, 0x30, 0xcc, 0x57, 0xa5, 0x2d, 0x96,
-	0x78, 0xc8, 0x2c, 0x88, 0x10, 0x87, 0x22, 0x28, 0x25, 0x7e, 0x07, 0x48, 0x69, 0x23, 0xa3, 0x94,
-	0x63, 0x9d, 0x61, 0x96, 0xc0, 0xbf, 0xf8, 0xbd, 0x9a, 0x89, 0xa7, 0xe6, 0x57, 0x0e, 0x00, 0xf6,
-	0xfb, 0x08, 0x3f, 0x8d, 0xfb, 0xe3, 0xcc, 0xfd, 0xd7, 0x71, 0x5e, 0xfb, 0x97, 0xe3, 0xfc, 0x31,
-	0xc8, 0x45, 0xbf, 0xd6, 0x28, 0x2d, 0x08, 0xf8, 0x89, 0x4d, 0x87, 0xd1, 0xec, 0x15, 0x8c, 0xe8,
-	0x0c, 0xcb, 0x60, 0xe3, 0xdc, 0x1e, 0x4d, 0x71, 0x74, 0x43, 0xc1, 0x60, 0x06, 0x73, 0x6e, 0xfd,
-	0xc4, 0x7d, 0xff, 0x47, 0x65, 0x17, 0x94, 0x7b, 0x9e, 0x4b, 0x7d, 0xbb, 0x47, 0x89, 0xdc, 0xf3,
-	0x7c, 0x2c, 0x87, 0x53, 0x42, 0x5e, 0x5e, 0x57, 0xb9, 0x57, 0xd7, 0x55, 0xee, 0xaf, 0xeb, 0x2a,
-	0xf7, 0xf3, 0x4d, 0x35, 0xf3, 0xea, 0xa6, 0x9a, 0xf9, 0xf3, 0xa6, 0x9a, 0x79, 0xfe, 0x6c, 0xe0,
-	0xd0, 0xe1, 0xb4, 0xdb, 0xec, 0x79, 0x63, 0x39, 0x1c, 0x98, 0xde, 0xd0, 0x76, 0xdc, 0x91, 0xdd,
-	0x0d, 0xb7, 0x53, 0x23, 0xb5, 0x03, 0x1b, 0xf1, 0xa6, 0x1a, 0x7b, 0xfd, 0xe9, 0x08, 0x13, 0xb6,
-	0x3a, 0x1b, 0x8b, 0xdd, 0x79, 0x79, 0x99, 0xd6, 0xb2, 0x2b, 0xbb, 0xd9, 0x68, 0x9d, 0xbd, 0xff,
-	0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x00, 0x52, 0x57, 0x85, 0x6b, 0x07, 0x00, 0x00,
+	// 1637 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcd, 0x8f, 0xe3, 0x48,
+	0x15, 0x6f, 0x77, 0xa7, 0x3f, 0xfc, 0x92, 0xee, 0x4e, 0xd7, 0xf6, 0xce, 0x64, 0xb2, 0x43, 0x62,
+	0x8c, 0x16, 0xb5, 0xd0, 0x76, 0xa2, 0x69, 0x16, 0x84, 0x06, 0x2d, 0x90, 0x38, 0xde, 0xe9, 0xb0,
+	0xdd, 0xe9, 0x6c, 0xc5, 0x3d, 0x30, 0x2b, 0x21, 0xcb, 0x89, 0xab, 0x13, 0x6b, 0x13, 0x3b, 0xd8,
+	0x4e, 0x7f, 0x70, 0x44, 0x1c, 0x96, 0x20, 0xd0, 0x0a, 0x71, 0x8d, 0x84, 0xc4, 0x81, 0xbf, 0x80,
+	0x3b, 0xc7, 0x3d, 0xce, 0x91, 0x53, 0x84, 0x66, 0xfe, 0x83, 0x5c, 0xb9, 0xa0, 0xfa, 0x88, 0xed,
+	0x78, 0x86, 0x5d, 0x8d, 0x86, 0x53, 0xea, 0xbd, 0xdf, 0xaf, 0x9e, 0x5f, 0x7e, 0xf5, 0xfc, 0x5e,
+	0x19, 0x1e, 0x39, 0xdd, 0x5e, 0x75, 0xe8, 0xf4, 0x07, 0x61, 0x6f, 0xe8, 0x10, 0x37, 0x0c, 0xaa,
+	0x24, 0x1c, 0x8c, 0x26, 0xc3, 0xd0, 0x09, 0x9c, 0x7e, 0xf5, 0xfa, 0x51, 0xd2, 0xac, 0x8c, 0x7d,
+	0x2f, 0xf4, 0x8a, 0x6a, 0xe0, 0x0d, 0x1d, 0xdb, 0x09, 0xef, 0x8e, 0x99, 0xdd, 0x9d, 0x5c, 0x1d,
+	0x93, 0xdb, 0x90, 0xb8, 0x81, 0xe3, 0xb9, 0x81, 0xe0, 0x1c, 0xf6, 0xbd, 0xbe, 0xc7, 0x96, 0x55,
+	0xba, 0x12, 0xde, 0x0f, 0xee, 0x26, 0xce, 0xb1, 0xd3, 0xed, 0x1d, 0x2f, 0x23, 0x54, 0xc7, 0x9f,
+	0xf7, 0xab, 0x34, 0x03, 0xfe, 0xf0, 0xaa, 0xc6, 0x7e, 0x38, 0x5b, 0xfd, 0xdd, 0x26, 0x64, 0xb9,
+	0xa3, 0x13, 0x5a, 0x21, 0x41, 0x27, 0xb0, 0x3b, 0xb4, 0x42, 0x12, 0x84, 0xe6, 0x80, 0xd0, 0x84,
+	0x0b, 0x92, 0x22, 0x1d, 0x65, 0x4f, 0xb6, 0x2b, 0xa7, 0xcc, 0xac, 0x67, 0xbe, 0x9a, 0x97, 0xd7,
+	0x70, 0x8e, 0x73, 0xb8, 0x0f, 0xfd, 0x1c, 0x76, 0xaf, 0x7c, 0xef, 0x37, 0xc4, 0x5d, 0xee, 0x59,
+	0x5f, 0xdd, 0xf3, 0x90, 0xee, 0x59, 0xcc, 0xcb, 0x87, 0x77, 0xd6, 0x68, 0xf8, 0x58, 0x5d, 0xe1,
+	0xaa, 0x38, 0xc7, 0x6d, 0x11, 0xeb, 0x53, 0x38, 0x1c, 0x59, 0xb7, 0x66, 0xcf, 0x73, 0x03, 0xe2,
+	0x06, 0x93, 0xc0, 0x0c, 0x68, 0x5a, 0x41, 0x61, 0x43, 0x91, 0x8e, 0x32, 0xf5, 0xf2, 0x62, 0x5e,
+	0x7e, 0x8f, 0x47, 0x79, 0x1d, 0x4b, 0xc5, 0x68, 0x64, 0xdd, 0x6a, 0x4b, 0x2f, 0xfb, 0x47, 0x01,
+	0x7a, 0x06, 0xf7, 0x53, 0x44, 0x93, 0x6e, 0xb6, 0xfa, 0xa4, 0x90, 0x61, 0x51, 0xd5, 0xc5, 0xbc,
+	0x5c, 0xe2, 0x51, 0xff, 0x07, 0x51, 0xc5, 0x87, 0xbd, 0x95, 0xa8, 0xe7, 0xd6, 0x6d, 0xad, 0x4f,
+	0x50, 0x11, 0x76, 0x02, 0xf2, 0xeb, 0x09, 0x71, 0x7b, 0xa4, 0xb0, 0x49, 0x63, 0xe1, 0xc8, 0x46,
+	0x0f, 0x41, 0x0e, 0x9d, 0x11, 0x09, 0x42, 0x6b, 0x34, 0x2e, 0x6c, 0x31, 0x30, 0x76, 0x20, 0x0d,
+	0xf6, 0x43, 0x7f, 0x12, 0x84, 0x8e, 0xdb, 0x37, 0xc7, 0xc4, 0x77, 0x3c, 0xbb, 0xb0, 0xcd, 0x92,
+	0x29, 0x2e, 0xe6, 0xe5, 0x7b, 0x3c, 0x99, 0x14, 0x41, 0xc5, 0x7b, 0x4b, 0x4f, 0x9b, 0x39, 0x50,
+	0x1d, 0xf6, 0x99, 0x0c, 0x43, 0xaf, 0xf7, 0xb9, 0x69, 0xfb, 0xce, 0x55, 0x58, 0xd8, 0x49, 0x07,
+	0x49, 0x11, 0x54, 0xbc, 0x4b, 0x25, 0xa2, 0x8e, 0x06, 0xb5, 0xd1, 0x29, 0x1c, 0x50, 0x4a, 0xe0,
+	0xf4, 0x5d, 0x2b, 0x9c, 0xf8, 0x84, 0xe9, 0x22, 0xb3, 0x28, 0x0f, 0x17, 0xf3, 0x72, 0x21, 0x8e,
+	0xb2, 0x42, 0x51, 0x31, 0x7d, 0x74, 0x67, 0xe9, 0xa2, 0x62, 0x7c, 0x08, 0xd0, 0xb5, 0xc2, 0xde,
+	0xc0, 0xf4, 0x3d, 0x2f, 0x2c, 0x80, 0x22, 0x1d, 0xe5, 0xea, 0xef, 0x2e, 0xe6, 0xe5, 0x03, 0x1e,
+	0x22, 0xc6, 0x54, 0x2c, 0x33, 0x03, 0x7b, 0x5e, 0xf8, 0x38, 0xf3, 0xc5, 0x5f, 0xcb, 0x6b, 0xea,
+	0x3f, 0x25, 0xd8, 0x5b, 0x3d, 0x37, 0x74, 0x02, 0xb2, 0x65, 0xdb, 0x3e, 0x09, 0x02, 0x12, 0x14,
+	0x24, 0x65, 0xe3, 0x28, 0x57, 0x3f, 0x5c, 0xcc, 0xcb, 0x79, 0x1e, 0x2d, 0x82, 0x54, 0x1c, 0xd3,
+	0x90, 0x02, 0x59, 0xdb, 0xb9, 0x26, 0x7e, 0xe0, 0x5c, 0x39, 0xc4, 0x67, 0x75, 0x28, 0xe3, 0xa4,
+	0x6b, 0xf5, 0x54, 0x36, 0xd2, 0xa7, 0x42, 0xd1, 0x81, 0x4f, 0x82, 0x81, 0x37, 0xb4, 0x79, 0x71,
+	0xe0, 0xd8, 0x81, 0xee, 0xc1, 0xd6, 0xd8, 0xbb, 0x21, 0x7e, 0x50, 0xd8, 0x54, 0x36, 0x8e, 0x32,
+	0x58, 0x58, 0xe2, 0x2f, 0xfc, 0x67, 0x1d, 0xb6, 0x4e, 0x89, 0x65, 0x13, 0x1f, 0xbd, 0x0f, 0x5b,
+	0x5f, 0xf7, 0xf6, 0x08, 0x70, 0x35, 0x97, 0xf5, 0x74, 0x2e, 0xc7, 0x20, 0x47, 0x8a, 0xb3, 0x4c,
+	0xb3, 0x27, 0xfb, 0x95, 0x73, 0xda, 0x25, 0x22, 0xd5, 0x71, 0xcc, 0x40, 0x1f, 0xc1, 0xae, 0x4b,
+	0x6e, 0xcc, 0x58, 0xb2, 0x0c, 0x93, 0xac, 0x10, 0xbf, 0x77, 0x2b, 0xb0, 0x8a, 0x73, 0x2e, 0xb9,
+	0xa9, 0x45, 0xca, 0x69, 0xb0, 0x4f, 0xf1, 0xa4, 0x7a, 0xb4, 0xa0, 0xe5, 0x64, 0x29, 0xa5, 0x08,
+	0x2a, 0xde, 0x73, 0xc9, 0x4d, 0x23, 0x21, 0xae, 0xc8, 0x21, 0x96, 0x90, 0x95, 0x7d, 0x3a, 0x87,
+	0x08, 0xe6, 0x39, 0x18, 0x91, 0xbe, 0x1f, 0x02, 0x50, 0x5c, 0x68, 0xbc, 0x4d, 0x35, 0x4e, 0x16,
+	0x50, 0x8c, 0xa9, 0x58, 0x76, 0xc9, 0x4d, 0x3b, 0xa9, 0xfe, 0x97, 0x1b, 0xb0, 0xb7, 0x2a, 0x0e,
+	0x2a, 0x01, 0x44, 0xf2, 0x88, 0x0a, 0xc2, 0x09, 0xcf, 0x37, 0xc8, 0xff, 0x11, 0xec, 0x52, 0x2e,
+	0xf1, 0xcd, 0xae, 0x13, 0x8e, 0x2c, 0x5e, 0x2c, 0x2b, 0x7a, 0xae, 0xc0, 0x2a, 0xce, 0x71, 0xbb,
+	0xce, 0x4c, 0xf4, 0x08, 0xb6, 0x99, 0x2e, 0x9e, 0xcb, 0xea, 0x68, 0xef, 0xe4, 0x7e, 0xea, 0xec,
+	0x2a, 0x4f, 0x39, 0x8c, 0x97, 0x3c, 0x74, 0x06, 0xa8, 0xe7, 0x8d, 0xc6, 0x56, 0x2f, 0x34, 0x13,
+	0x79, 0x6f, 0xb2, 0xc7, 0x7e, 0x6b, 0x31, 0x2f, 0x3f, 0x58, 0xb6, 0xa8, 0x34, 0x47, 0xc5, 0x07,
+	0xc2, 0xd9, 0x89, 0xff, 0xdd, 0xb7, 0x61, 0x93, 0xbd, 0x64, 0xec, 0x0c, 0xb2, 0x27, 0xd9, 0x4a,
+	0x9d, 0x5a, 0x6d, 0xdf, 0xf3, 0xae, 0x30, 0x47, 0xd4, 0x5f, 0xc1, 0xb6, 0x48, 0x02, 0x7d, 0x0f,
+	0x8a, 0x4f, 0x75, 0xdc, 0x69, 0x5e, 0xb4, 0xcc, 0x33, 0xfd, 0x49, 0x4d, 0x7b, 0x66, 0x5e, 0xb6,
+	0x3a, 0x6d, 0x5d, 0x6b, 0x7e, 0xdc, 0xd4, 0x1b, 0xf9, 0xb5, 0x22, 0x4c, 0x67, 0xca, 0x16, 0x47,
+	0x90, 0x02, 0xfb, 0x4b, 0xae, 0x76, 0x71, 0xde, 0xae, 0x69, 0x46, 0x5e, 0x2a, 0x66, 0xa7, 0x33,
+	0x65, 0x5b, 0x98, 0xc5, 0xcc, 0x17, 0x7f, 0x2b, 0xad, 0xa9, 0x7f, 0x92, 0x00, 0xe2, 0x87, 0x22,
+	0x04, 0x19, 0xd6, 0x18, 0xe8, 0x2b, 0x91, 0xc3, 0x6c, 0x8d, 0x0e, 0x61, 0xd3, 0x71, 0x6d, 0x72,
+	0x2b, 0xe4, 0xe7, 0x06, 0xab, 0x83, 0xc9, 0xc8, 0x1c, 0x12, 0xeb, 0x3a, 0xea, 0xfc, 0xc9, 0x3a,
+	0x88, 0x30, 0x5a, 0x07, 0x93, 0xd1, 0x19, 0x5b, 0xb3, 0x5e, 0xec, 0x74, 0x87, 0x8e, 0xdb, 0x17,
+	0xb5, 0x8f, 0x23, 0x5b, 0xd4, 0xc8, 0xdf, 0xb7, 0x40, 0xa6, 0x0a, 0xd5, 0xef, 0xe8, 0x58, 0xf8,
+	0xbf, 0xbc, 0xa4, 0xa9, 0x86, 0xb3, 0xf1, 0x6a, 0xc3, 0xf9, 0x18, 0x64, 0xdb, 0x0a, 0x2d, 0x33,
+	0xbc, 0x1b, 0x13, 0x51, 0x0a, 0xef, 0x54, 0xa2, 0x2c, 0x2a, 0x0d, 0x2b, 0xb4, 0x8c, 0xbb, 0x31,
+	0x49, 0xf6, 0xb6, 0x88, 0xaf, 0xe2, 0x1d, 0x5b, 0xe0, 0x54, 0x3e, 0xba, 0xe6, 0xf5, 0x80, 0xd9,
+	0x5a, 0x7d, 0x9e, 0x81, 0x9d, 0x65, 0x00, 0xf4, 0x23, 0xf8, 0x4e, 0xa3, 0x66, 0xd4, 0x4c, 0xe3,
+	0x59, 0x5b, 0x37, 0x2f, 0x5b, 0xcd, 0x56, 0xd3, 0x68, 0xd6, 0xce, 0x9a, 0x9f, 0xe9, 0x8d, 0xd4,
+	0x59, 0xee, 0x4f, 0x67, 0x4a, 0x36, 0xe1, 0x42, 0xdf, 0x85, 0x7b, 0xf1, 0x4e, 0xed, 0xac, 0xa9,
+	0xb7, 0x0c, 0xb3, 0x63, 0xd4, 0x0c, 0x3d, 0x2f, 0xf1, 0x83, 0xe7, 0x3e, 0xf4, 0x01, 0x3c, 0x48,
+	0xf0, 0x2e, 0x5a, 0x1d, 0xbd, 0xd5, 0xb9, 0xec, 0x08, 0xea, 0x7a, 0x71, 0x77, 0x3a, 0x53, 0xe4,
+	0xc8, 0x8d, 0x2a, 0x50, 0x5c, 0x61, 0xb7, 0x74, 0xcd, 0xa0, 0x35, 0xc3, 0xe9, 0x1b, 0xc5, 0xbd,
+	0xe9, 0x4c, 0x81, 0xd8, 0x8f, 0x8e, 0xe0, 0x7e, 0x82, 0x7f, 0x5a, 0x6b, 0xb5, 0xf4, 0x33, 0x41,
+	0xce, 0x88, 0xf2, 0xe2, 0x4e, 0xf4, 0x03, 0x78, 0x2f, 0x66, 0xb6, 0x6b, 0xda, 0x27, 0xba, 0x41,
+	0x2b, 0xf1, 0xbc, 0x69, 0x9c, 0xeb, 0x2d, 0x23, 0xbf, 0x59, 0x3c, 0x9c, 0xce, 0x94, 0x3c, 0x07,
+	0x62, 0x3f, 0xfa, 0x29, 0x28, 0xaf, 0x6c, 0xab, 0x69, 0x9f, 0xb4, 0x2e, 0x7e, 0x71, 0xa6, 0x37,
+	0x9e, 0xe8, 0x6c, 0xef, 0x56, 0xf1, 0xc1, 0x74, 0xa6, 0xbc, 0xcb, 0xd1, 0x14, 0x88, 0x7e, 0xf2,
+	0x9a, 0x00, 0x58, 0xd7, 0xf4, 0x66, 0xdb, 0x30, 0x6b, 0xf5, 0x8e, 0xde, 0xd2, 0xf4, 0xfc, 0x76,
+	0xb1, 0x30, 0x9d, 0x29, 0x87, 0x1c, 0x15, 0xa0, 0xc0, 0xd0, 0x0f, 0xe1, 0x61, 0xbc, 0xbf, 0xa5,
+	0xff, 0xd2, 0x30, 0x3b, 0xfa, 0xa7, 0x97, 0x14, 0xa2, 0x61, 0x9e, 0xe6, 0x77, 0x78, 0xe2, 0x14,
+	0x59, 0x02, 0xd4, 0x8f, 0x14, 0xc8, 0xc7, 0xfb, 0x4e, 0xf5, 0x5a, 0x43, 0xc7, 0x79, 0x99, 0x9f,
+	0x0c, 0xb7, 0x90, 0x0a, 0x07, 0x89, 0xb3, 0x6f, 0x3f, 0xc1, 0xb5, 0x86, 0x9e, 0x07, 0xae, 0x9a,
+	0x30, 0x51, 0x09, 0xf6, 0x63, 0x4e, 0xbd, 0x66, 0x68, 0xa7, 0xf9, 0x6c, 0x51, 0x9e, 0xce, 0x94,
+	0x4d, 0x66, 0xf0, 0x97, 0x56, 0xbc, 0x29, 0x7f, 0x58, 0x07, 0xe0, 0xb3, 0x8c, 0x96, 0xd7, 0xab,
+	0xb3, 0x45, 0x7a, 0xdb, 0xd9, 0xb2, 0xfe, 0xf6, 0xb3, 0x65, 0xe3, 0x2d, 0x66, 0x4b, 0xe6, 0x8d,
+	0x66, 0xcb, 0xfb, 0x20, 0xb3, 0x3e, 0xc6, 0xb4, 0x78, 0x4d, 0x1b, 0x13, 0xb4, 0xbf, 0x48, 0x90,
+	0xbd, 0x1c, 0xf7, 0x7d, 0xcb, 0x26, 0x8c, 0xf9, 0x18, 0x72, 0xfc, 0xc6, 0xcd, 0xef, 0x92, 0x7c,
+	0x47, 0xfd, 0xfe, 0x62, 0x5e, 0x7e, 0x47, 0x74, 0xf2, 0x04, 0xaa, 0xe2, 0x6c, 0x2f, 0x71, 0x0d,
+	0xd7, 0x60, 0x3f, 0x75, 0x15, 0x65, 0x92, 0xe5, 0x92, 0x92, 0xa5, 0x08, 0x2a, 0xde, 0x5b, 0xbd,
+	0xa3, 0x8a, 0xb4, 0x7e, 0x0c, 0x32, 0x33, 0x97, 0xd9, 0x8f, 0xad, 0x70, 0xb0, 0xcc, 0x9e, 0xae,
+	0x69, 0x13, 0xbe, 0xb6, 0x86, 0x13, 0xf1, 0x04, 0xcc, 0x0d, 0xb1, 0xf9, 0xcf, 0xeb, 0x90, 0x3b,
+	0x77, 0x82, 0x2e, 0x19, 0x58, 0xd7, 0x8e, 0x37, 0xf1, 0xd1, 0x23, 0x90, 0x45, 0xda, 0x8e, 0xcd,
+	0xa2, 0xc8, 0xc9, 0xce, 0x15, 0x41, 0x2a, 0xde, 0xe1, 0xeb, 0xa6, 0x9d, 0x68, 0xb4, 0xeb, 0x5f,
+	0xd7, 0x68, 0xdb, 0x7c, 0xe0, 0xf2, 0x1b, 0xa6, 0xe7, 0x2e, 0xef, 0x3c, 0x07, 0x95, 0xf8, 0x92,
+	0xe9, 0xda, 0xf4, 0x4f, 0xa4, 0x67, 0x70, 0xb4, 0x43, 0xcc, 0x60, 0x66, 0x5f, 0xb8, 0x64, 0x35,
+	0x62, 0x78, 0xe3, 0x15, 0x32, 0x6f, 0x14, 0x31, 0xbc, 0xf1, 0x92, 0x11, 0x8d, 0x1b, 0x4f, 0x88,
+	0xf2, 0x7b, 0x09, 0xf2, 0xe9, 0x10, 0xab, 0xd7, 0x35, 0xe9, 0x1b, 0xaf, 0x6b, 0x3f, 0xe3, 0x97,
+	0x13, 0xb3, 0x4b, 0xa7, 0x80, 0x10, 0x06, 0xe2, 0xb9, 0x90, 0xac, 0xcd, 0x98, 0xa7, 0xf2, 0x08,
+	0x8c, 0xc1, 0x73, 0xa9, 0xff, 0x51, 0xfa, 0xed, 0x3f, 0x0a, 0xf7, 0x80, 0x7e, 0x9e, 0x84, 0xbe,
+	0xd5, 0x0b, 0x83, 0x6a, 0xcf, 0xf3, 0x49, 0x95, 0x4e, 0x8f, 0xe0, 0xab, 0x17, 0x25, 0xe9, 0xf9,
+	0x8b, 0x92, 0xf4, 0xef, 0x17, 0x25, 0xe9, 0xcb, 0x97, 0xa5, 0xb5, 0xe7, 0x2f, 0x4b, 0x6b, 0xff,
+	0x7a, 0x59, 0x5a, 0xfb, 0xec, 0x59, 0xdf, 0x09, 0x07, 0x93, 0x6e, 0xa5, 0xe7, 0x8d, 0xaa, 0x74,
+	0x90, 0xf4, 0x06, 0x96, 0xe3, 0x0e, 0xad, 0x2e, 0xfd, 0x4a, 0x3c, 0x4e, 0x7c, 0x8b, 0x1e, 0x8b,
+	0x2f, 0xc6, 0x91, 0x67, 0x4f, 0x86, 0x24, 0xe0, 0x9f, 0xb0, 0xc7, 0xcb, 0x6f, 0xd8, 0xdb, 0xdb,
+	0x24, 0x97, 0x3f, 0xb2, 0xbb, 0xc5, 0x3e, 0x2b, 0xbf, 0xff, 0xdf, 0x01, 0x00, 0x8c, 0xfd, 0x4a,
+	0x57, 0xf3, 0x0e, 0x00, 0x00,
 }
 
 func (m *ClientState) Marshal() (dAtA []byte, err error) {
@@ -478,6 +843,48 @@ func (m *ClientState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.BatchRoot) > 0 {
+		i -= len(m.BatchRoot)
+		copy(dAtA[i:], m.BatchRoot)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.BatchRoot)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.MaxSignatureAge != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.MaxSignatureAge))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.MaxClockDrift != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.MaxClockDrift))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.TrustingPeriod != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.TrustingPeriod))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ConsensusStateMaxAge != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.ConsensusStateMaxAge))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxConsensusStates != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.MaxConsensusStates))
+		i--
+		dAtA[i] = 0x18
+	}
 	{
 		size, err := m.FrozenHeight.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -521,6 +928,29 @@ func (m *ConsensusState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Powers) > 0 {
+		dAtA4 := make([]byte, len(m.Powers)*10)
+		var j3 int
+		for _, num := range m.Powers {
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintEthmultisig(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Threshold != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.Threshold))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.Timestamp != 0 {
 		i = encodeVarintEthmultisig(dAtA, i, uint64(m.Timestamp))
 		i--
@@ -565,6 +995,29 @@ func (m *Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.NewPowers) > 0 {
+		dAtA6 := make([]byte, len(m.NewPowers)*10)
+		var j5 int
+		for _, num := range m.NewPowers {
+			for num >= 1<<7 {
+				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j5++
+			}
+			dAtA6[j5] = uint8(num)
+			j5++
+		}
+		i -= j5
+		copy(dAtA[i:], dAtA6[:j5])
+		i = encodeVarintEthmultisig(dAtA, i, uint64(j5))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.NewThreshold != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.NewThreshold))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.NewDiversifier) > 0 {
 		i -= len(m.NewDiversifier)
 		copy(dAtA[i:], m.NewDiversifier)
@@ -631,6 +1084,37 @@ func (m *MultiSignature) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Batch != nil {
+		{
+			size, err := m.Batch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEthmultisig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.CompactSignatures) > 0 {
+		i -= len(m.CompactSignatures)
+		copy(dAtA[i:], m.CompactSignatures)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.CompactSignatures)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Version != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.SignerBitmap) > 0 {
+		i -= len(m.SignerBitmap)
+		copy(dAtA[i:], m.SignerBitmap)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.SignerBitmap)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Timestamp != 0 {
 		i = encodeVarintEthmultisig(dAtA, i, uint64(m.Timestamp))
 		i--
@@ -648,6 +1132,55 @@ func (m *MultiSignature) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *BatchProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Siblings) > 0 {
+		for iNdEx := len(m.Siblings) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Siblings[iNdEx])
+			copy(dAtA[i:], m.Siblings[iNdEx])
+			i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Siblings[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.NumLeaves != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.NumLeaves))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Index != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Root) > 0 {
+		i -= len(m.Root)
+		copy(dAtA[i:], m.Root)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Root)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SignBytes) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -725,6 +1258,29 @@ func (m *HeaderData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.NewPowers) > 0 {
+		dAtA12 := make([]byte, len(m.NewPowers)*10)
+		var j11 int
+		for _, num := range m.NewPowers {
+			for num >= 1<<7 {
+				dAtA12[j11] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j11++
+			}
+			dAtA12[j11] = uint8(num)
+			j11++
+		}
+		i -= j11
+		copy(dAtA[i:], dAtA12[:j11])
+		i = encodeVarintEthmultisig(dAtA, i, uint64(j11))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.NewThreshold != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.NewThreshold))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.NewDiversifier) > 0 {
 		i -= len(m.NewDiversifier)
 		copy(dAtA[i:], m.NewDiversifier)
@@ -744,7 +1300,7 @@ func (m *HeaderData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *StateData) Marshal() (dAtA []byte, err error) {
+func (m *BatchData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -754,44 +1310,222 @@ func (m *StateData) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StateData) MarshalTo(dAtA []byte) (int, error) {
+func (m *BatchData) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *StateData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BatchData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Path) > 0 {
-		i -= len(m.Path)
-		copy(dAtA[i:], m.Path)
-		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Path)))
+	if len(m.Root) > 0 {
+		i -= len(m.Root)
+		copy(dAtA[i:], m.Root)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Root)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintEthmultisig(dAtA []byte, offset int, v uint64) int {
-	offset -= sovEthmultisig(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
+func (m *UpgradeData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpgradeData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpgradeData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ConsensusState) > 0 {
+		i -= len(m.ConsensusState)
+		copy(dAtA[i:], m.ConsensusState)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.ConsensusState)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ClientState) > 0 {
+		i -= len(m.ClientState)
+		copy(dAtA[i:], m.ClientState)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.ClientState)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StateData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StateData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StateData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Misbehaviour) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Misbehaviour) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Misbehaviour) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SignatureTwo != nil {
+		{
+			size, err := m.SignatureTwo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEthmultisig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.SignatureOne != nil {
+		{
+			size, err := m.SignatureOne.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEthmultisig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	{
+		size, err := m.Height.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintEthmultisig(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.ClientId) > 0 {
+		i -= len(m.ClientId)
+		copy(dAtA[i:], m.ClientId)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.ClientId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignatureAndData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignatureAndData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignatureAndData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SignBytes != nil {
+		{
+			size, err := m.SignBytes.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEthmultisig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Signature != nil {
+		{
+			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEthmultisig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintEthmultisig(dAtA []byte, offset int, v uint64) int {
+	offset -= sovEthmultisig(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
 func (m *ClientState) Size() (n int) {
 	if m == nil {
 		return 0
@@ -802,6 +1536,31 @@ func (m *ClientState) Size() (n int) {
 	n += 1 + l + sovEthmultisig(uint64(l))
 	l = m.FrozenHeight.Size()
 	n += 1 + l + sovEthmultisig(uint64(l))
+	if m.MaxConsensusStates != 0 {
+		n += 1 + sovEthmultisig(uint64(m.MaxConsensusStates))
+	}
+	if m.ConsensusStateMaxAge != 0 {
+		n += 1 + sovEthmultisig(uint64(m.ConsensusStateMaxAge))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovEthmultisig(uint64(m.Sequence))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovEthmultisig(uint64(m.Timestamp))
+	}
+	if m.TrustingPeriod != 0 {
+		n += 1 + sovEthmultisig(uint64(m.TrustingPeriod))
+	}
+	if m.MaxClockDrift != 0 {
+		n += 1 + sovEthmultisig(uint64(m.MaxClockDrift))
+	}
+	if m.MaxSignatureAge != 0 {
+		n += 1 + sovEthmultisig(uint64(m.MaxSignatureAge))
+	}
+	l = len(m.BatchRoot)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
 	return n
 }
 
@@ -824,6 +1583,16 @@ func (m *ConsensusState) Size() (n int) {
 	if m.Timestamp != 0 {
 		n += 1 + sovEthmultisig(uint64(m.Timestamp))
 	}
+	if m.Threshold != 0 {
+		n += 1 + sovEthmultisig(uint64(m.Threshold))
+	}
+	if len(m.Powers) > 0 {
+		l = 0
+		for _, e := range m.Powers {
+			l += sovEthmultisig(uint64(e))
+		}
+		n += 1 + sovEthmultisig(uint64(l)) + l
+	}
 	return n
 }
 
@@ -852,6 +1621,16 @@ func (m *Header) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovEthmultisig(uint64(l))
 	}
+	if m.NewThreshold != 0 {
+		n += 1 + sovEthmultisig(uint64(m.NewThreshold))
+	}
+	if len(m.NewPowers) > 0 {
+		l = 0
+		for _, e := range m.NewPowers {
+			l += sovEthmultisig(uint64(e))
+		}
+		n += 1 + sovEthmultisig(uint64(l)) + l
+	}
 	return n
 }
 
@@ -870,6 +1649,46 @@ func (m *MultiSignature) Size() (n int) {
 	if m.Timestamp != 0 {
 		n += 1 + sovEthmultisig(uint64(m.Timestamp))
 	}
+	l = len(m.SignerBitmap)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovEthmultisig(uint64(m.Version))
+	}
+	l = len(m.CompactSignatures)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	if m.Batch != nil {
+		l = m.Batch.Size()
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	return n
+}
+
+func (m *BatchProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovEthmultisig(uint64(m.Index))
+	}
+	if m.NumLeaves != 0 {
+		n += 1 + sovEthmultisig(uint64(m.NumLeaves))
+	}
+	if len(m.Siblings) > 0 {
+		for _, b := range m.Siblings {
+			l = len(b)
+			n += 1 + l + sovEthmultisig(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -914,6 +1733,46 @@ func (m *HeaderData) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovEthmultisig(uint64(l))
 	}
+	if m.NewThreshold != 0 {
+		n += 1 + sovEthmultisig(uint64(m.NewThreshold))
+	}
+	if len(m.NewPowers) > 0 {
+		l = 0
+		for _, e := range m.NewPowers {
+			l += sovEthmultisig(uint64(e))
+		}
+		n += 1 + sovEthmultisig(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *BatchData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	return n
+}
+
+func (m *UpgradeData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClientState)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	l = len(m.ConsensusState)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
 	return n
 }
 
@@ -934,22 +1793,62 @@ func (m *StateData) Size() (n int) {
 	return n
 }
 
-func sovEthmultisig(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozEthmultisig(x uint64) (n int) {
-	return sovEthmultisig(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *ClientState) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowEthmultisig
-			}
+func (m *Misbehaviour) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClientId)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	l = m.Height.Size()
+	n += 1 + l + sovEthmultisig(uint64(l))
+	if m.SignatureOne != nil {
+		l = m.SignatureOne.Size()
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	if m.SignatureTwo != nil {
+		l = m.SignatureTwo.Size()
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	return n
+}
+
+func (m *SignatureAndData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Signature != nil {
+		l = m.Signature.Size()
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	if m.SignBytes != nil {
+		l = m.SignBytes.Size()
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	return n
+}
+
+func sovEthmultisig(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozEthmultisig(x uint64) (n int) {
+	return sovEthmultisig(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ClientState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEthmultisig
+			}
 			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
@@ -973,7 +1872,1092 @@ func (m *ClientState) Unmarshal(dAtA []byte) error {
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field LatestHeight", wireType)
 			}
-			var msglen int
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.LatestHeight.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FrozenHeight", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.FrozenHeight.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxConsensusStates", wireType)
+			}
+			m.MaxConsensusStates = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxConsensusStates |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusStateMaxAge", wireType)
+			}
+			m.ConsensusStateMaxAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConsensusStateMaxAge |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustingPeriod", wireType)
+			}
+			m.TrustingPeriod = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TrustingPeriod |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxClockDrift", wireType)
+			}
+			m.MaxClockDrift = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxClockDrift |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSignatureAge", wireType)
+			}
+			m.MaxSignatureAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSignatureAge |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchRoot", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BatchRoot = append(m.BatchRoot[:0], dAtA[iNdEx:postIndex]...)
+			if m.BatchRoot == nil {
+				m.BatchRoot = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEthmultisig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConsensusState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEthmultisig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConsensusState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConsensusState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addresses", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addresses = append(m.Addresses, make([]byte, postIndex-iNdEx))
+			copy(m.Addresses[len(m.Addresses)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Diversifier", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Diversifier = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Threshold", wireType)
+			}
+			m.Threshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Threshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEthmultisig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Powers = append(m.Powers, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEthmultisig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthEthmultisig
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthEthmultisig
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Powers) == 0 {
+					m.Powers = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEthmultisig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Powers = append(m.Powers, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Powers", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEthmultisig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Header) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEthmultisig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Header: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Header: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Height.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Signature == nil {
+				m.Signature = &MultiSignature{}
+			}
+			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewAddresses", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewAddresses = append(m.NewAddresses, make([]byte, postIndex-iNdEx))
+			copy(m.NewAddresses[len(m.NewAddresses)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewDiversifier", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewDiversifier = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewThreshold", wireType)
+			}
+			m.NewThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NewThreshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEthmultisig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.NewPowers = append(m.NewPowers, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEthmultisig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthEthmultisig
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthEthmultisig
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.NewPowers) == 0 {
+					m.NewPowers = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEthmultisig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NewPowers = append(m.NewPowers, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPowers", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEthmultisig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MultiSignature) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEthmultisig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MultiSignature: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MultiSignature: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signatures", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signatures = append(m.Signatures, make([]byte, postIndex-iNdEx))
+			copy(m.Signatures[len(m.Signatures)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignerBitmap", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignerBitmap = append(m.SignerBitmap[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignerBitmap == nil {
+				m.SignerBitmap = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= MultiSignature_Version(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactSignatures", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompactSignatures = append(m.CompactSignatures[:0], dAtA[iNdEx:postIndex]...)
+			if m.CompactSignatures == nil {
+				m.CompactSignatures = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Batch == nil {
+				m.Batch = &BatchProof{}
+			}
+			if err := m.Batch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEthmultisig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEthmultisig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Root = append(m.Root[:0], dAtA[iNdEx:postIndex]...)
+			if m.Root == nil {
+				m.Root = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumLeaves", wireType)
+			}
+			m.NumLeaves = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -983,30 +2967,16 @@ func (m *ClientState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.NumLeaves |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthEthmultisig
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthEthmultisig
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.LatestHeight.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FrozenHeight", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Siblings", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1016,24 +2986,23 @@ func (m *ClientState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.FrozenHeight.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Siblings = append(m.Siblings, make([]byte, postIndex-iNdEx))
+			copy(m.Siblings[len(m.Siblings)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1056,7 +3025,7 @@ func (m *ClientState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConsensusState) Unmarshal(dAtA []byte) error {
+func (m *SignBytes) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1079,17 +3048,17 @@ func (m *ConsensusState) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConsensusState: wiretype end group for non-group")
+			return fmt.Errorf("proto: SignBytes: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConsensusState: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SignBytes: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addresses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1099,25 +3068,45 @@ func (m *ConsensusState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addresses = append(m.Addresses, make([]byte, postIndex-iNdEx))
-			copy(m.Addresses[len(m.Addresses)-1], dAtA[iNdEx:postIndex])
+			if err := m.Height.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Diversifier", wireType)
 			}
@@ -1149,11 +3138,11 @@ func (m *ConsensusState) Unmarshal(dAtA []byte) error {
 			}
 			m.Diversifier = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DataType", wireType)
 			}
-			m.Timestamp = 0
+			m.DataType = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1163,11 +3152,45 @@ func (m *ConsensusState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= uint64(b&0x7F) << shift
+				m.DataType |= SignBytes_DataType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEthmultisig(dAtA[iNdEx:])
@@ -1189,7 +3212,7 @@ func (m *ConsensusState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Header) Unmarshal(dAtA []byte) error {
+func (m *HeaderData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1212,17 +3235,17 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Header: wiretype end group for non-group")
+			return fmt.Errorf("proto: HeaderData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Header: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HeaderData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewAddresses", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1232,49 +3255,29 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Height.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NewAddresses = append(m.NewAddresses, make([]byte, postIndex-iNdEx))
+			copy(m.NewAddresses[len(m.NewAddresses)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
-			}
-			m.Timestamp = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEthmultisig
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Timestamp |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewDiversifier", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1284,33 +3287,29 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Signature == nil {
-				m.Signature = &MultiSignature{}
-			}
-			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NewDiversifier = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewAddresses", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewThreshold", wireType)
 			}
-			var byteLen int
+			m.NewThreshold = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1320,29 +3319,142 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.NewThreshold |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthEthmultisig
+		case 4:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEthmultisig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.NewPowers = append(m.NewPowers, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEthmultisig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthEthmultisig
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthEthmultisig
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.NewPowers) == 0 {
+					m.NewPowers = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEthmultisig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NewPowers = append(m.NewPowers, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPowers", wireType)
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEthmultisig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NewAddresses = append(m.NewAddresses, make([]byte, postIndex-iNdEx))
-			copy(m.NewAddresses[len(m.NewAddresses)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEthmultisig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewDiversifier", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1352,23 +3464,25 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NewDiversifier = string(dAtA[iNdEx:postIndex])
+			m.Root = append(m.Root[:0], dAtA[iNdEx:postIndex]...)
+			if m.Root == nil {
+				m.Root = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1391,7 +3505,7 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MultiSignature) Unmarshal(dAtA []byte) error {
+func (m *UpgradeData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1414,15 +3528,15 @@ func (m *MultiSignature) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MultiSignature: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpgradeData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MultiSignature: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpgradeData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signatures", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientState", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1449,14 +3563,16 @@ func (m *MultiSignature) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Signatures = append(m.Signatures, make([]byte, postIndex-iNdEx))
-			copy(m.Signatures[len(m.Signatures)-1], dAtA[iNdEx:postIndex])
+			m.ClientState = append(m.ClientState[:0], dAtA[iNdEx:postIndex]...)
+			if m.ClientState == nil {
+				m.ClientState = []byte{}
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusState", wireType)
 			}
-			m.Timestamp = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1466,11 +3582,26 @@ func (m *MultiSignature) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConsensusState = append(m.ConsensusState[:0], dAtA[iNdEx:postIndex]...)
+			if m.ConsensusState == nil {
+				m.ConsensusState = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEthmultisig(dAtA[iNdEx:])
@@ -1492,7 +3623,7 @@ func (m *MultiSignature) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SignBytes) Unmarshal(dAtA []byte) error {
+func (m *StateData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1515,17 +3646,17 @@ func (m *SignBytes) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SignBytes: wiretype end group for non-group")
+			return fmt.Errorf("proto: StateData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SignBytes: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StateData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1535,98 +3666,29 @@ func (m *SignBytes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Height.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Path = append(m.Path[:0], dAtA[iNdEx:postIndex]...)
+			if m.Path == nil {
+				m.Path = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
-			}
-			m.Timestamp = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEthmultisig
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Timestamp |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Diversifier", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEthmultisig
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthEthmultisig
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthEthmultisig
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Diversifier = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DataType", wireType)
-			}
-			m.DataType = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowEthmultisig
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.DataType |= SignBytes_DataType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1653,9 +3715,9 @@ func (m *SignBytes) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -1679,7 +3741,7 @@ func (m *SignBytes) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HeaderData) Unmarshal(dAtA []byte) error {
+func (m *Misbehaviour) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1702,17 +3764,17 @@ func (m *HeaderData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HeaderData: wiretype end group for non-group")
+			return fmt.Errorf("proto: Misbehaviour: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HeaderData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Misbehaviour: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewAddresses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientId", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1722,29 +3784,29 @@ func (m *HeaderData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NewAddresses = append(m.NewAddresses, make([]byte, postIndex-iNdEx))
-			copy(m.NewAddresses[len(m.NewAddresses)-1], dAtA[iNdEx:postIndex])
+			m.ClientId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewDiversifier", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1754,23 +3816,96 @@ func (m *HeaderData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NewDiversifier = string(dAtA[iNdEx:postIndex])
+			if err := m.Height.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureOne", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SignatureOne == nil {
+				m.SignatureOne = &SignatureAndData{}
+			}
+			if err := m.SignatureOne.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureTwo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SignatureTwo == nil {
+				m.SignatureTwo = &SignatureAndData{}
+			}
+			if err := m.SignatureTwo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1793,7 +3928,7 @@ func (m *HeaderData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StateData) Unmarshal(dAtA []byte) error {
+func (m *SignatureAndData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1816,17 +3951,17 @@ func (m *StateData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StateData: wiretype end group for non-group")
+			return fmt.Errorf("proto: SignatureAndData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StateData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SignatureAndData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1836,31 +3971,33 @@ func (m *StateData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = append(m.Path[:0], dAtA[iNdEx:postIndex]...)
-			if m.Path == nil {
-				m.Path = []byte{}
+			if m.Signature == nil {
+				m.Signature = &MultiSignature{}
+			}
+			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SignBytes", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowEthmultisig
@@ -1870,24 +4007,26 @@ func (m *StateData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthEthmultisig
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			if m.SignBytes == nil {
+				m.SignBytes = &SignBytes{}
+			}
+			if err := m.SignBytes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
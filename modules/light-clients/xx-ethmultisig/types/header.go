@@ -1,6 +1,9 @@
 package types
 
 import (
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
 	"github.com/cosmos/ibc-go/modules/core/exported"
 )
@@ -15,6 +18,23 @@ func (h *Header) GetHeight() exported.Height {
 	return clienttypes.Height(h.Height)
 }
 
+// ValidateBasic ensures that the height, timestamp and signature are not empty
+// and the new signer set and diversifier are valid.
 func (h *Header) ValidateBasic() error {
+	if h.GetHeight().IsZero() {
+		return sdkerrors.Wrap(ErrInvalidHeight, "height cannot be zero")
+	}
+	if h.Timestamp == 0 {
+		return sdkerrors.Wrap(ErrInvalidTimestamp, "timestamp cannot be zero")
+	}
+	if h.Signature == nil || h.Signature.NumSignatures() == 0 {
+		return sdkerrors.Wrap(ErrInvalidSignature, "signature cannot be empty")
+	}
+	if err := validateSignerSet(h.NewAddresses, h.NewPowers, h.NewThreshold); err != nil {
+		return sdkerrors.Wrap(err, "invalid new signer set")
+	}
+	if strings.TrimSpace(h.NewDiversifier) == "" {
+		return sdkerrors.Wrap(ErrInvalidDiversifier, "new diversifier cannot be blank")
+	}
 	return nil
 }
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"bytes"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	leafPrefix = []byte{0}
+	nodePrefix = []byte{1}
+)
+
+func hashLeaf(leaf []byte) []byte {
+	return crypto.Keccak256(leafPrefix, leaf)
+}
+
+func hashNode(left, right []byte) []byte {
+	return crypto.Keccak256(nodePrefix, left, right)
+}
+
+// NewBatchProofs builds the Merkle tree over the leaves and returns the inclusion proof of each leaf.
+// The proofs share the root of the tree.
+func NewBatchProofs(leaves [][]byte) ([]*BatchProof, error) {
+	if len(leaves) == 0 {
+		return nil, sdkerrors.Wrap(ErrInvalidBatchProof, "leaves cannot be empty")
+	}
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = hashLeaf(leaf)
+	}
+	proofs := make([]*BatchProof, len(leaves))
+	indexes := make([]int, len(leaves))
+	for i := range leaves {
+		proofs[i] = &BatchProof{Index: uint64(i), NumLeaves: uint64(len(leaves))}
+		indexes[i] = i
+	}
+	for len(level) > 1 {
+		for i, proof := range proofs {
+			if sibling := indexes[i] ^ 1; sibling < len(level) {
+				proof.Siblings = append(proof.Siblings, level[sibling])
+			}
+			indexes[i] /= 2
+		}
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, hashNode(level[i], level[i+1]))
+			} else {
+				next = append(next, level[i])
+			}
+		}
+		level = next
+	}
+	for _, proof := range proofs {
+		proof.Root = level[0]
+	}
+	return proofs, nil
+}
+
+// Verify verifies that the leaf is included in the batch at the index of the proof.
+func (p *BatchProof) Verify(leaf []byte) error {
+	if p.NumLeaves == 0 || p.Index >= p.NumLeaves {
+		return sdkerrors.Wrapf(ErrInvalidBatchProof, "index %d out of range of %d leaves", p.Index, p.NumLeaves)
+	}
+	node := hashLeaf(leaf)
+	index, width := p.Index, p.NumLeaves
+	siblings := p.Siblings
+	for width > 1 {
+		if index^1 < width {
+			if len(siblings) == 0 {
+				return sdkerrors.Wrap(ErrInvalidBatchProof, "too few siblings")
+			}
+			sibling := siblings[0]
+			siblings = siblings[1:]
+			if len(sibling) != 32 {
+				return sdkerrors.Wrapf(ErrInvalidBatchProof, "sibling must be 32 bytes long: %d", len(sibling))
+			}
+			if index%2 == 0 {
+				node = hashNode(node, sibling)
+			} else {
+				node = hashNode(sibling, node)
+			}
+		}
+		index /= 2
+		width = (width + 1) / 2
+	}
+	if len(siblings) != 0 {
+		return sdkerrors.Wrap(ErrInvalidBatchProof, "too many siblings")
+	}
+	if !bytes.Equal(node, p.Root) {
+		return sdkerrors.Wrapf(ErrInvalidBatchProof, "root mismatch: %X != %X", node, p.Root)
+	}
+	return nil
+}
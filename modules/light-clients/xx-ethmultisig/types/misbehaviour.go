@@ -0,0 +1,115 @@
+package types
+
+import (
+	"bytes"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	host "github.com/cosmos/ibc-go/modules/core/24-host"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+)
+
+var _ exported.Misbehaviour = (*Misbehaviour)(nil)
+
+func (misbehaviour *Misbehaviour) ClientType() string {
+	return ClientType
+}
+
+// GetClientID returns the ID of the client that committed a misbehaviour.
+func (misbehaviour *Misbehaviour) GetClientID() string {
+	return misbehaviour.ClientId
+}
+
+// GetHeight returns the height at which the misbehaviour occurred.
+func (misbehaviour *Misbehaviour) GetHeight() exported.Height {
+	return clienttypes.Height(misbehaviour.Height)
+}
+
+// ValidateBasic ensures that both signatures are made at the misbehaviour height
+// and that they are signed over different messages of the same data type.
+// The messages of a state must be signed over the same path with different values,
+// as the honest signers sign the different states at different sequences.
+func (misbehaviour *Misbehaviour) ValidateBasic() error {
+	if err := host.ClientIdentifierValidator(misbehaviour.ClientId); err != nil {
+		return sdkerrors.Wrap(err, "invalid client identifier for multisig")
+	}
+
+	if misbehaviour.GetHeight().IsZero() {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "height cannot be zero")
+	}
+
+	if err := misbehaviour.SignatureOne.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "signature one failed basic validation")
+	}
+
+	if err := misbehaviour.SignatureTwo.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "signature two failed basic validation")
+	}
+
+	for _, sd := range []*SignatureAndData{misbehaviour.SignatureOne, misbehaviour.SignatureTwo} {
+		if !clienttypes.Height(sd.SignBytes.Height).EQ(misbehaviour.GetHeight()) {
+			return sdkerrors.Wrapf(
+				clienttypes.ErrInvalidMisbehaviour,
+				"sign bytes height must be equal to the misbehaviour height (%s != %s)", clienttypes.Height(sd.SignBytes.Height), misbehaviour.GetHeight(),
+			)
+		}
+	}
+
+	// message data signed cannot be identical
+	if bytes.Equal(misbehaviour.SignatureOne.SignBytes.Data, misbehaviour.SignatureTwo.SignBytes.Data) {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "misbehaviour signature data must be signed over different messages")
+	}
+
+	one, two := misbehaviour.SignatureOne.SignBytes, misbehaviour.SignatureTwo.SignBytes
+	if one.DataType != two.DataType {
+		return sdkerrors.Wrapf(
+			clienttypes.ErrInvalidMisbehaviour,
+			"misbehaviour signatures must be signed over the same data type (%s != %s)", one.DataType, two.DataType,
+		)
+	}
+	if isStateDataType(one.DataType) {
+		var stateOne, stateTwo StateData
+		if err := stateOne.Unmarshal(one.Data); err != nil {
+			return sdkerrors.Wrap(ErrInvalidSignatureAndData, "failed to unmarshal the state data of signature one")
+		}
+		if err := stateTwo.Unmarshal(two.Data); err != nil {
+			return sdkerrors.Wrap(ErrInvalidSignatureAndData, "failed to unmarshal the state data of signature two")
+		}
+		if !bytes.Equal(stateOne.Path, stateTwo.Path) {
+			return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "misbehaviour signatures must be signed over the same path")
+		}
+		if bytes.Equal(stateOne.Value, stateTwo.Value) {
+			return sdkerrors.Wrap(clienttypes.ErrInvalidMisbehaviour, "misbehaviour signatures must be signed over different values")
+		}
+	}
+
+	return nil
+}
+
+// isStateDataType returns true if the data of the data type is a StateData
+func isStateDataType(dataType SignBytes_DataType) bool {
+	return dataType >= CLIENT && dataType <= NEXTSEQUENCERECV
+}
+
+// ValidateBasic ensures that the signature and sign bytes fields are non-empty.
+func (sd *SignatureAndData) ValidateBasic() error {
+	if sd == nil {
+		return sdkerrors.Wrap(ErrInvalidSignatureAndData, "signature and data cannot be nil")
+	}
+	if sd.Signature == nil || sd.Signature.NumSignatures() == 0 {
+		return sdkerrors.Wrap(ErrInvalidSignatureAndData, "signature cannot be empty")
+	}
+	if sd.SignBytes == nil {
+		return sdkerrors.Wrap(ErrInvalidSignatureAndData, "sign bytes cannot be empty")
+	}
+	if len(sd.SignBytes.Data) == 0 {
+		return sdkerrors.Wrap(ErrInvalidSignatureAndData, "data for signature cannot be empty")
+	}
+	if sd.SignBytes.DataType == UNSPECIFIED {
+		return sdkerrors.Wrap(ErrInvalidSignatureAndData, "data type cannot be UNSPECIFIED")
+	}
+	if sd.SignBytes.Timestamp == 0 {
+		return sdkerrors.Wrap(ErrInvalidSignatureAndData, "timestamp cannot be 0")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+)
+
+// CheckMisbehaviourAndUpdateState determines whether or not the currently registered
+// signers signed over two different messages at the same height. If this is true
+// the client state is updated to a frozen status.
+// NOTE: Misbehaviour is not tracked for previous signer sets, the multisig may update to
+// a new signer set before the misbehaviour is processed. Therefore, misbehaviour is data
+// order processing dependent.
+func (cs ClientState) CheckMisbehaviourAndUpdateState(
+	ctx sdk.Context,
+	cdc codec.BinaryCodec,
+	clientStore sdk.KVStore,
+	misbehaviour exported.Misbehaviour,
+) (exported.ClientState, error) {
+	msMisbehaviour, ok := misbehaviour.(*Misbehaviour)
+	if !ok {
+		return nil, sdkerrors.Wrapf(
+			clienttypes.ErrInvalidClientType,
+			"misbehaviour type %T, expected %T", misbehaviour, &Misbehaviour{},
+		)
+	}
+
+	cons, err := getConsensusState(clientStore, cdc, cs.GetLatestHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: a check that the misbehaviour message data are not equal is done by
+	// misbehaviour.ValidateBasic which is called by the 02-client keeper.
+
+	// verify first signature
+	if err := verifySignatureAndData(cdc, cons, msMisbehaviour, msMisbehaviour.SignatureOne); err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to verify signature one")
+	}
+
+	// verify second signature
+	if err := verifySignatureAndData(cdc, cons, msMisbehaviour, msMisbehaviour.SignatureTwo); err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to verify signature two")
+	}
+
+	cs.FrozenHeight = msMisbehaviour.Height
+	return &cs, nil
+}
+
+// verifySignatureAndData verifies that the currently registered signers have signed
+// over the provided sign bytes at the misbehaviour height.
+func verifySignatureAndData(cdc codec.BinaryCodec, consensusState *ConsensusState, misbehaviour *Misbehaviour, sigAndData *SignatureAndData) error {
+	// do not check misbehaviour timestamp since we want to allow processing of past misbehaviour
+
+	signBytes := *sigAndData.SignBytes
+	signBytes.Height = misbehaviour.Height
+	signBytes.Diversifier = consensusState.Diversifier
+	signBz, err := cdc.Marshal(&signBytes)
+	if err != nil {
+		return err
+	}
+
+	return consensusState.VerifySignature(sigAndData.Signature, signBz)
+}
@@ -1,35 +1,176 @@
 package types
 
 import (
-	"fmt"
+	"math/big"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
 	"github.com/cosmos/ibc-go/modules/core/exported"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/ibc/client"
 )
 
+// VerifySignature verifies that every address signed over the sign bytes.
 func VerifySignature(addresses []common.Address, multiSig *MultiSignature, signBytes []byte) error {
-	if len(addresses) != len(multiSig.Signatures) {
-		return ErrInvalidSignatureCount
+	return VerifyThresholdSignature(addresses, uint64(len(addresses)), multiSig, signBytes)
+}
+
+// VerifyThresholdSignature verifies that at least threshold distinct addresses signed over the sign bytes.
+// The signers that took part are given by the signer bitmap of the multisig, which can be of either version.
+// The signatures are recovered by the SignerRecoverer set with SetSignerRecoverer.
+func VerifyThresholdSignature(addresses []common.Address, threshold uint64, multiSig *MultiSignature, signBytes []byte) error {
+	return VerifyWeightedSignature(addresses, nil, threshold, multiSig, signBytes)
+}
+
+// VerifyWeightedSignature verifies that distinct addresses whose voting powers add up to at least threshold
+// signed over the sign bytes, where powers[i] is the voting power of addresses[i].
+// If powers is empty, every address has a voting power of one.
+func VerifyWeightedSignature(addresses []common.Address, powers []uint64, threshold uint64, multiSig *MultiSignature, signBytes []byte) error {
+	powers, totalPower, err := normalizePowers(len(addresses), powers)
+	if err != nil {
+		return err
+	}
+	if threshold == 0 || threshold > totalPower {
+		return sdkerrors.Wrapf(ErrInvalidThreshold, "threshold must be between 1 and %d: %d", totalPower, threshold)
+	}
+	seen := make(map[common.Address]bool, len(addresses))
+	for _, addr := range addresses {
+		if seen[addr] {
+			return sdkerrors.Wrapf(ErrDuplicateSigner, "address %v", addr)
+		}
+		seen[addr] = true
+	}
+	sigs, err := multiSig.DecodeSignatures()
+	if err != nil {
+		return err
+	}
+	indexes, err := multiSig.SignerIndexes(len(addresses))
+	if err != nil {
+		return err
+	}
+	if len(indexes) != len(sigs) {
+		return sdkerrors.Wrapf(ErrInvalidSignatureCount, "expected %d signatures, got %d", len(indexes), len(sigs))
+	}
+	var signedPower uint64
+	for _, i := range indexes {
+		signedPower += powers[i]
+	}
+	if signedPower < threshold {
+		return sdkerrors.Wrapf(ErrInsufficientPower, "%d < %d", signedPower, threshold)
 	}
 	h := crypto.Keccak256(signBytes)
-	for i, sig := range multiSig.Signatures {
-		if len(sig) != crypto.SignatureLength {
-			return fmt.Errorf("signature must be 65 bytes long")
+	signers, err := getSignerRecoverer().RecoverSigners(h, sigs)
+	if err != nil {
+		return err
+	}
+	for i, signer := range signers {
+		if addr := addresses[indexes[i]]; addr != signer {
+			return sdkerrors.Wrapf(ErrInvalidSignature, "signature does not match signer: %v != %v (hash=%x)", addr, signer, h)
+		}
+	}
+	return nil
+}
+
+// normalizePowers returns the voting powers of numAddresses addresses and their total.
+// Empty powers give every address a voting power of one.
+func normalizePowers(numAddresses int, powers []uint64) ([]uint64, uint64, error) {
+	if len(powers) == 0 {
+		powers = make([]uint64, numAddresses)
+		for i := range powers {
+			powers[i] = 1
 		}
-		rpk, err := crypto.SigToPub(h, sig)
-		if err != nil {
-			return err
+	} else if len(powers) != numAddresses {
+		return nil, 0, sdkerrors.Wrapf(ErrInvalidSignerSet, "the number of powers must equal the number of addresses (%d != %d)", len(powers), numAddresses)
+	}
+	var total uint64
+	for i, power := range powers {
+		if power == 0 {
+			return nil, 0, sdkerrors.Wrapf(ErrInvalidSignerSet, "power cannot be zero: index=%d", i)
 		}
-		signer := crypto.PubkeyToAddress(*rpk)
-		if addresses[i] != signer {
-			return fmt.Errorf("signature does not match signer: %v != %v (hash=%v)", addresses[i], signer, h)
+		if total+power < total {
+			return nil, 0, sdkerrors.Wrap(ErrInvalidSignerSet, "total power overflows")
 		}
+		total += power
 	}
-	return nil
+	return powers, total, nil
+}
+
+// RecoverSigner recovers the address that signed the hash from a 65-byte [R || S || V] signature.
+// Only canonical signatures are accepted: s must be in the lower half of the curve order and
+// v must be 0 or 1, where 27 and 28 are normalised to 0 and 1. The zero address is never returned.
+// The same rules are applied by MultisigClient.recoverSigner of the Solidity client.
+func RecoverSigner(hash []byte, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, sdkerrors.Wrapf(ErrInvalidSignature, "signature must be %d bytes long: %d", crypto.SignatureLength, len(sig))
+	}
+	v := sig[crypto.RecoveryIDOffset]
+	if v >= 27 {
+		v -= 27
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if !crypto.ValidateSignatureValues(v, r, s, true) {
+		return common.Address{}, sdkerrors.Wrapf(ErrInvalidSignature, "non-canonical signature: v=%d r=%v s=%v", sig[crypto.RecoveryIDOffset], r, s)
+	}
+	normalized := make([]byte, crypto.SignatureLength)
+	copy(normalized, sig)
+	normalized[crypto.RecoveryIDOffset] = v
+	pub, err := crypto.SigToPub(hash, normalized)
+	if err != nil {
+		return common.Address{}, sdkerrors.Wrap(ErrInvalidSignature, err.Error())
+	}
+	signer := crypto.PubkeyToAddress(*pub)
+	if signer == (common.Address{}) {
+		return common.Address{}, sdkerrors.Wrap(ErrInvalidSignature, "recovered the zero address")
+	}
+	return signer, nil
+}
+
+// SignerIndexes returns the indexes of the addresses that took part in the multisig in ascending order.
+// If the signer bitmap is empty, every address must sign.
+func (ms *MultiSignature) SignerIndexes(numAddresses int) ([]int, error) {
+	var indexes []int
+	if len(ms.SignerBitmap) == 0 {
+		if ms.NumSignatures() != numAddresses {
+			return nil, ErrInvalidSignatureCount
+		}
+		for i := 0; i < numAddresses; i++ {
+			indexes = append(indexes, i)
+		}
+		return indexes, nil
+	}
+	if len(ms.SignerBitmap) != (numAddresses+7)/8 {
+		return nil, sdkerrors.Wrapf(ErrInvalidSignerBitmap, "bitmap length must be %d: %d", (numAddresses+7)/8, len(ms.SignerBitmap))
+	}
+	for i := 0; i < len(ms.SignerBitmap)*8; i++ {
+		if ms.SignerBitmap[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		if i >= numAddresses {
+			return nil, sdkerrors.Wrapf(ErrInvalidSignerBitmap, "signer index out of range: %d", i)
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes, nil
+}
+
+// NewSignerBitmap returns a signer bitmap for the given indexes of numAddresses addresses.
+func NewSignerBitmap(numAddresses int, indexes []int) ([]byte, error) {
+	bitmap := make([]byte, (numAddresses+7)/8)
+	for _, i := range indexes {
+		if i < 0 || i >= numAddresses {
+			return nil, sdkerrors.Wrapf(ErrInvalidSignerBitmap, "signer index out of range: %d", i)
+		}
+		if bitmap[i/8]&(1<<(i%8)) != 0 {
+			return nil, sdkerrors.Wrapf(ErrDuplicateSigner, "signer index %d", i)
+		}
+		bitmap[i/8] |= 1 << (i % 8)
+	}
+	return bitmap, nil
 }
 
 // ClientStateSignBytes returns the sign bytes for verification of the
@@ -93,3 +234,270 @@ func ConsensusStateSignBytes(
 	}
 	return cdc.Marshal(signBytes)
 }
+
+// ConnectionStateSignBytes returns the sign bytes for verification of the
+// connection state.
+func ConnectionStateSignBytes(
+	cdc codec.BinaryCodec,
+	height clienttypes.Height, timestamp uint64,
+	diversifier string,
+	path []byte,
+	connectionEnd exported.ConnectionI,
+) ([]byte, error) {
+	connection, ok := connectionEnd.(conntypes.ConnectionEnd)
+	if !ok {
+		return nil, sdkerrors.Wrapf(
+			conntypes.ErrInvalidConnection,
+			"expected type %T, got %T", conntypes.ConnectionEnd{}, connectionEnd,
+		)
+	}
+	connectionBz, err := cdc.Marshal(&connection)
+	if err != nil {
+		return nil, err
+	}
+	data := StateData{
+		Path:  path,
+		Value: connectionBz,
+	}
+	dataBz, err := cdc.Marshal(&data)
+	if err != nil {
+		return nil, err
+	}
+	signBytes := &SignBytes{
+		Height:      client.Height(height),
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		DataType:    CONNECTION,
+		Data:        dataBz,
+	}
+	return cdc.Marshal(signBytes)
+}
+
+// ChannelStateSignBytes returns the sign bytes for verification of the
+// channel state.
+func ChannelStateSignBytes(
+	cdc codec.BinaryCodec,
+	height clienttypes.Height, timestamp uint64,
+	diversifier string,
+	path []byte,
+	channelEnd exported.ChannelI,
+) ([]byte, error) {
+	channel, ok := channelEnd.(chantypes.Channel)
+	if !ok {
+		return nil, sdkerrors.Wrapf(
+			chantypes.ErrInvalidChannel,
+			"expected type %T, got %T", chantypes.Channel{}, channelEnd,
+		)
+	}
+	channelBz, err := cdc.Marshal(&channel)
+	if err != nil {
+		return nil, err
+	}
+	data := StateData{
+		Path:  path,
+		Value: channelBz,
+	}
+	dataBz, err := cdc.Marshal(&data)
+	if err != nil {
+		return nil, err
+	}
+	signBytes := &SignBytes{
+		Height:      client.Height(height),
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		DataType:    CHANNEL,
+		Data:        dataBz,
+	}
+	return cdc.Marshal(signBytes)
+}
+
+// PacketCommitmentSignBytes returns the sign bytes for verification of the
+// packet commitment.
+func PacketCommitmentSignBytes(
+	cdc codec.BinaryCodec,
+	height clienttypes.Height, timestamp uint64,
+	diversifier string,
+	path []byte,
+	commitmentBytes []byte,
+) ([]byte, error) {
+	data := StateData{
+		Path:  path,
+		Value: commitmentBytes,
+	}
+	dataBz, err := cdc.Marshal(&data)
+	if err != nil {
+		return nil, err
+	}
+	signBytes := &SignBytes{
+		Height:      client.Height(height),
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		DataType:    PACKETCOMMITMENT,
+		Data:        dataBz,
+	}
+	return cdc.Marshal(signBytes)
+}
+
+// PacketAcknowledgementSignBytes returns the sign bytes for verification of
+// the acknowledgement. The acknowledgement is committed with sha256 as
+// the solidity client does.
+func PacketAcknowledgementSignBytes(
+	cdc codec.BinaryCodec,
+	height clienttypes.Height, timestamp uint64,
+	diversifier string,
+	path []byte,
+	acknowledgement []byte,
+) ([]byte, error) {
+	data := StateData{
+		Path:  path,
+		Value: chantypes.CommitAcknowledgement(acknowledgement),
+	}
+	dataBz, err := cdc.Marshal(&data)
+	if err != nil {
+		return nil, err
+	}
+	signBytes := &SignBytes{
+		Height:      client.Height(height),
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		DataType:    PACKETACKNOWLEDGEMENT,
+		Data:        dataBz,
+	}
+	return cdc.Marshal(signBytes)
+}
+
+// PacketReceiptAbsenceSignBytes returns the sign bytes for verification of
+// the absence of a packet receipt. An empty value is signed over the path.
+func PacketReceiptAbsenceSignBytes(
+	cdc codec.BinaryCodec,
+	height clienttypes.Height, timestamp uint64,
+	diversifier string,
+	path []byte,
+) ([]byte, error) {
+	data := StateData{
+		Path: path,
+	}
+	dataBz, err := cdc.Marshal(&data)
+	if err != nil {
+		return nil, err
+	}
+	signBytes := &SignBytes{
+		Height:      client.Height(height),
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		DataType:    PACKETRECEIPTABSENCE,
+		Data:        dataBz,
+	}
+	return cdc.Marshal(signBytes)
+}
+
+// NextSequenceRecvSignBytes returns the sign bytes for verification of the
+// next sequence to be received. The sequence is encoded in big endian.
+func NextSequenceRecvSignBytes(
+	cdc codec.BinaryCodec,
+	height clienttypes.Height, timestamp uint64,
+	diversifier string,
+	path []byte,
+	nextSequenceRecv uint64,
+) ([]byte, error) {
+	data := StateData{
+		Path:  path,
+		Value: sdk.Uint64ToBigEndian(nextSequenceRecv),
+	}
+	dataBz, err := cdc.Marshal(&data)
+	if err != nil {
+		return nil, err
+	}
+	signBytes := &SignBytes{
+		Height:      client.Height(height),
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		DataType:    NEXTSEQUENCERECV,
+		Data:        dataBz,
+	}
+	return cdc.Marshal(signBytes)
+}
+
+// BatchSignBytes returns the sign bytes for verification of the root of a batch of states.
+func BatchSignBytes(
+	cdc codec.BinaryCodec,
+	height clienttypes.Height, timestamp uint64,
+	diversifier string,
+	root []byte,
+) ([]byte, error) {
+	dataBz, err := cdc.Marshal(&BatchData{Root: root})
+	if err != nil {
+		return nil, err
+	}
+	signBytes := &SignBytes{
+		Height:      client.Height(height),
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		DataType:    BATCH,
+		Data:        dataBz,
+	}
+	return cdc.Marshal(signBytes)
+}
+
+// UpgradeSignBytes returns the sign bytes for verification of the upgraded
+// client and consensus state. The client state is signed with its custom
+// fields zeroed.
+func UpgradeSignBytes(
+	cdc codec.BinaryCodec,
+	height clienttypes.Height, timestamp uint64,
+	diversifier string,
+	clientState exported.ClientState,
+	consensusState exported.ConsensusState,
+) ([]byte, error) {
+	clientStateBz, err := cdc.MarshalInterface(clientState.ZeroCustomFields())
+	if err != nil {
+		return nil, err
+	}
+	consensusStateBz, err := cdc.MarshalInterface(consensusState)
+	if err != nil {
+		return nil, err
+	}
+	data := UpgradeData{
+		ClientState:    clientStateBz,
+		ConsensusState: consensusStateBz,
+	}
+	dataBz, err := cdc.Marshal(&data)
+	if err != nil {
+		return nil, err
+	}
+	signBytes := &SignBytes{
+		Height:      client.Height(height),
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		DataType:    UPGRADE,
+		Data:        dataBz,
+	}
+	return cdc.Marshal(signBytes)
+}
+
+// HeaderSignBytes returns the sign bytes for verification of the header.
+// The timestamp of the header is used as the timestamp of the sign bytes.
+func HeaderSignBytes(
+	cdc codec.BinaryCodec,
+	header *Header,
+	diversifier string,
+) ([]byte, error) {
+	data := HeaderData{
+		NewAddresses:   header.NewAddresses,
+		NewDiversifier: header.NewDiversifier,
+		NewThreshold:   header.NewThreshold,
+		NewPowers:      header.NewPowers,
+	}
+	dataBz, err := cdc.Marshal(&data)
+	if err != nil {
+		return nil, err
+	}
+	signBytes := &SignBytes{
+		Height:      header.Height,
+		Timestamp:   header.Timestamp,
+		Diversifier: diversifier,
+		DataType:    HEADER,
+		Data:        dataBz,
+	}
+	return cdc.Marshal(signBytes)
+}
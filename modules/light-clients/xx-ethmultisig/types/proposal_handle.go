@@ -0,0 +1,123 @@
+package types
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/ibc/client"
+)
+
+// CheckSubstituteAndUpdateState will try to update the client with the state of the
+// substitute if and only if the proposal passes and the subject client is Frozen or Expired.
+//
+// The following must always be true:
+//   - The substitute client is the same type as the subject client
+//   - The subject and substitute client states match in all parameters (except frozen height, latest height and sequence)
+//   - The latest height of the substitute is greater than the latest height of the subject, so that no consensus state
+//     of the subject is left above the latest height
+//
+// The consensus state at the latest height of the substitute, which holds the signer set and
+// the diversifier, is copied to the subject, and the subject is unfrozen if it is Frozen.
+// The sequence of the subject is raised to at least the latest height of the substitute.
+func (cs ClientState) CheckSubstituteAndUpdateState(
+	ctx sdk.Context, cdc codec.BinaryCodec, subjectClientStore,
+	substituteClientStore sdk.KVStore, substituteClient exported.ClientState,
+) (exported.ClientState, error) {
+	substituteClientState, ok := substituteClient.(*ClientState)
+	if !ok {
+		return nil, sdkerrors.Wrapf(
+			clienttypes.ErrInvalidClient, "expected type %T, got %T", &ClientState{}, substituteClient,
+		)
+	}
+
+	if !IsMatchingClientState(cs, *substituteClientState) {
+		return nil, sdkerrors.Wrap(clienttypes.ErrInvalidSubstitute, "subject client state does not match substitute client state")
+	}
+
+	switch cs.Status(ctx, subjectClientStore, cdc) {
+	case exported.Frozen:
+		// unfreeze the client
+		cs.FrozenHeight = client.Height{}
+	case exported.Expired:
+	default:
+		return nil, sdkerrors.Wrap(clienttypes.ErrUpdateClientFailed, "client cannot be updated with proposal")
+	}
+
+	if !substituteClientState.GetLatestHeight().GT(cs.GetLatestHeight()) {
+		return nil, sdkerrors.Wrapf(
+			clienttypes.ErrInvalidSubstitute, "substitute client latest height must be greater than subject client latest height (%s <= %s)",
+			substituteClientState.GetLatestHeight(), cs.GetLatestHeight(),
+		)
+	}
+
+	// copy the latest consensus state from substitute to subject
+	height := substituteClientState.GetLatestHeight()
+
+	consensusState, err := getConsensusState(substituteClientStore, cdc, height)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "unable to retrieve latest consensus state for substitute client")
+	}
+
+	setConsensusState(subjectClientStore, cdc, consensusState, height)
+
+	// set metadata stored for the substitute consensus state
+	processedHeight, found := GetProcessedHeight(substituteClientStore, height)
+	if !found {
+		return nil, sdkerrors.Wrap(clienttypes.ErrUpdateClientFailed, "unable to retrieve processed height for substitute client latest height")
+	}
+
+	processedTime, found := GetProcessedTime(substituteClientStore, height)
+	if !found {
+		return nil, sdkerrors.Wrap(clienttypes.ErrUpdateClientFailed, "unable to retrieve processed time for substitute client latest height")
+	}
+
+	setConsensusMetadataWithValues(subjectClientStore, height, processedHeight, processedTime)
+
+	// keep the newer sequence so that the proofs verified by either client cannot be replayed
+	switch {
+	case substituteClientState.GetLatestHeight().GetRevisionNumber() != cs.GetLatestHeight().GetRevisionNumber(),
+		substituteClientState.Sequence > cs.Sequence:
+		cs.Sequence = substituteClientState.Sequence
+		cs.BatchRoot = substituteClientState.BatchRoot
+	case substituteClientState.Sequence == cs.Sequence:
+		// neither batch can be continued if the clients verified different batches at the sequence
+		if !bytes.Equal(cs.BatchRoot, substituteClientState.BatchRoot) {
+			cs.BatchRoot = nil
+		}
+	}
+	// the signers of the subject are retired by the proposal, so the sequence is raised to the latest height
+	// of the substitute and they cannot sign any more proofs that are verified with their consensus states
+	if height.GetRevisionHeight() > cs.Sequence {
+		cs.Sequence = height.GetRevisionHeight()
+		cs.BatchRoot = nil
+	}
+	if substituteClientState.Timestamp > cs.Timestamp {
+		cs.Timestamp = substituteClientState.Timestamp
+	}
+
+	cs.LatestHeight = substituteClientState.LatestHeight
+
+	// no validation is necessary since the substitute is verified to be Active
+	// in 02-client.
+
+	return &cs, nil
+}
+
+// IsMatchingClientState returns true if all the client state parameters match
+// except for frozen height, latest height and the sequence of the verified proofs.
+func IsMatchingClientState(subject, substitute ClientState) bool {
+	// zero out parameters which do not need to match
+	subject.LatestHeight = client.Height{}
+	subject.FrozenHeight = client.Height{}
+	substitute.LatestHeight = client.Height{}
+	substitute.FrozenHeight = client.Height{}
+	subject.Sequence, subject.Timestamp, subject.BatchRoot = 0, 0, nil
+	substitute.Sequence, substitute.Timestamp, substitute.BatchRoot = 0, 0, nil
+
+	return reflect.DeepEqual(subject, substitute)
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// SignerRecoverer recovers the signers of signatures over the same hash.
+// The i-th address of the result is the signer of the i-th signature.
+type SignerRecoverer interface {
+	RecoverSigners(hash []byte, sigs [][]byte) ([]common.Address, error)
+}
+
+// signerRecoverer is atomic so that a recoverer set after the verification has started is seen safely,
+// but it is meant to be set once at the initialization.
+var signerRecoverer atomic.Value
+
+func init() {
+	SetSignerRecoverer(nil)
+}
+
+// SetSignerRecoverer sets the SignerRecoverer that is used to verify multisigs.
+// If r is nil, the signatures are recovered sequentially, which is the default.
+// It must be called only at the initialization of the application, before any multisig is verified,
+// since it replaces the recoverer of every client of the process.
+func SetSignerRecoverer(r SignerRecoverer) {
+	if r == nil {
+		r = sequentialRecoverer{}
+	}
+	signerRecoverer.Store(&r)
+}
+
+func getSignerRecoverer() SignerRecoverer {
+	return *signerRecoverer.Load().(*SignerRecoverer)
+}
+
+type sequentialRecoverer struct{}
+
+var _ SignerRecoverer = sequentialRecoverer{}
+
+func (sequentialRecoverer) RecoverSigners(hash []byte, sigs [][]byte) ([]common.Address, error) {
+	signers := make([]common.Address, len(sigs))
+	for i, sig := range sigs {
+		signer, err := RecoverSigner(hash, sig)
+		if err != nil {
+			return nil, err
+		}
+		signers[i] = signer
+	}
+	return signers, nil
+}
+
+// ParallelRecoverer recovers signatures concurrently with a bounded number of workers.
+// If the cache is enabled, the signers already recovered for the same hash and signature are not recovered again.
+type ParallelRecoverer struct {
+	workers int
+	cache   *lru.Cache
+}
+
+var _ SignerRecoverer = (*ParallelRecoverer)(nil)
+
+// NewParallelRecoverer returns a ParallelRecoverer that runs at most workers recoveries at a time
+// and caches up to cacheSize recovered signers. A cacheSize of zero disables the cache.
+func NewParallelRecoverer(workers int, cacheSize int) (*ParallelRecoverer, error) {
+	if workers <= 0 {
+		return nil, fmt.Errorf("workers must be positive: %d", workers)
+	}
+	if cacheSize < 0 {
+		return nil, fmt.Errorf("cache size must not be negative: %d", cacheSize)
+	}
+	r := &ParallelRecoverer{workers: workers}
+	if cacheSize > 0 {
+		cache, err := lru.New(cacheSize)
+		if err != nil {
+			return nil, err
+		}
+		r.cache = cache
+	}
+	return r, nil
+}
+
+// RecoverSigners implements SignerRecoverer.
+// If several signatures are invalid, the error of the first one is returned.
+func (r *ParallelRecoverer) RecoverSigners(hash []byte, sigs [][]byte) ([]common.Address, error) {
+	signers := make([]common.Address, len(sigs))
+	errs := make([]error, len(sigs))
+	workers := r.workers
+	if workers > len(sigs) {
+		workers = len(sigs)
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				signers[i], errs[i] = r.recover(hash, sigs[i])
+			}
+		}()
+	}
+	for i := range sigs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return signers, nil
+}
+
+func (r *ParallelRecoverer) recover(hash []byte, sig []byte) (common.Address, error) {
+	if r.cache == nil {
+		return RecoverSigner(hash, sig)
+	}
+	key := string(hash) + string(sig)
+	if v, ok := r.cache.Get(key); ok {
+		return v.(common.Address), nil
+	}
+	signer, err := RecoverSigner(hash, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	r.cache.Add(key, signer)
+	return signer, nil
+}
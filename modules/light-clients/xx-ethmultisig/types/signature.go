@@ -0,0 +1,100 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CompactSignatureLength is the length of an EIP-2098 compact signature
+const CompactSignatureLength = 64
+
+// CompactSignature converts a 65-byte [R || S || V] signature into a 64-byte EIP-2098 [R || YParityAndS] signature.
+// The signature must be canonical, that is, s must be in the lower half of the curve order.
+func CompactSignature(sig []byte) ([]byte, error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, sdkerrors.Wrapf(ErrInvalidSignature, "signature must be %d bytes long: %d", crypto.SignatureLength, len(sig))
+	}
+	v := sig[crypto.RecoveryIDOffset]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 || sig[32]&0x80 != 0 {
+		return nil, sdkerrors.Wrap(ErrInvalidSignature, "non-canonical signature")
+	}
+	compact := make([]byte, CompactSignatureLength)
+	copy(compact, sig[:64])
+	compact[32] |= v << 7
+	return compact, nil
+}
+
+// ExpandCompactSignature converts a 64-byte EIP-2098 [R || YParityAndS] signature into a 65-byte [R || S || V] signature.
+func ExpandCompactSignature(compact []byte) ([]byte, error) {
+	if len(compact) != CompactSignatureLength {
+		return nil, sdkerrors.Wrapf(ErrInvalidSignature, "compact signature must be %d bytes long: %d", CompactSignatureLength, len(compact))
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, compact)
+	sig[crypto.RecoveryIDOffset] = compact[32] >> 7
+	sig[32] &= 0x7f
+	return sig, nil
+}
+
+// NumSignatures returns the number of signatures that the multisig carries in its version.
+func (ms *MultiSignature) NumSignatures() int {
+	switch ms.Version {
+	case COMPACT:
+		return len(ms.CompactSignatures) / CompactSignatureLength
+	default:
+		return len(ms.Signatures)
+	}
+}
+
+// DecodeSignatures returns the signatures of the multisig as 65-byte [R || S || V] signatures regardless of its version.
+func (ms *MultiSignature) DecodeSignatures() ([][]byte, error) {
+	switch ms.Version {
+	case LEGACY:
+		if len(ms.CompactSignatures) != 0 {
+			return nil, sdkerrors.Wrap(ErrInvalidSignature, "the legacy version cannot have compact signatures")
+		}
+		return ms.Signatures, nil
+	case COMPACT:
+		if len(ms.Signatures) != 0 {
+			return nil, sdkerrors.Wrap(ErrInvalidSignature, "the compact version cannot have legacy signatures")
+		}
+		if len(ms.CompactSignatures)%CompactSignatureLength != 0 {
+			return nil, sdkerrors.Wrapf(ErrInvalidSignature, "compact signatures length must be a multiple of %d: %d", CompactSignatureLength, len(ms.CompactSignatures))
+		}
+		var sigs [][]byte
+		for i := 0; i < len(ms.CompactSignatures); i += CompactSignatureLength {
+			sig, err := ExpandCompactSignature(ms.CompactSignatures[i : i+CompactSignatureLength])
+			if err != nil {
+				return nil, err
+			}
+			sigs = append(sigs, sig)
+		}
+		return sigs, nil
+	default:
+		return nil, sdkerrors.Wrapf(ErrInvalidProof, "unsupported multisig version: %v", ms.Version)
+	}
+}
+
+// ToCompact returns a copy of the multisig in the compact version.
+func (ms *MultiSignature) ToCompact() (*MultiSignature, error) {
+	sigs, err := ms.DecodeSignatures()
+	if err != nil {
+		return nil, err
+	}
+	compact := &MultiSignature{
+		Timestamp:    ms.Timestamp,
+		SignerBitmap: ms.SignerBitmap,
+		Version:      COMPACT,
+	}
+	for _, sig := range sigs {
+		bz, err := CompactSignature(sig)
+		if err != nil {
+			return nil, err
+		}
+		compact.CompactSignatures = append(compact.CompactSignatures, bz...)
+	}
+	return compact, nil
+}
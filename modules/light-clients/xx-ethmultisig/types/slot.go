@@ -24,6 +24,8 @@ const (
 	channelPrefix        = uint8(3)
 	packetPrefix         = uint8(4)
 	packetAckPrefix      = uint8(5)
+	packetReceiptPrefix  = uint8(6)
+	nextSeqRecvPrefix    = uint8(7)
 )
 
 // Commitment key generator
@@ -76,6 +78,22 @@ func PacketAcknowledgementCommitmentKey(prefix []byte, portId, channelId string,
 	return append(prefix, key...), nil
 }
 
+func PacketReceiptCommitmentKey(prefix []byte, portId, channelId string, sequence uint64) ([]byte, error) {
+	key, err := keccak256AbiEncodePacked(packetReceiptPrefix, portId, "/", channelId, "/", sequence)
+	if err != nil {
+		return nil, err
+	}
+	return append(prefix, key...), nil
+}
+
+func NextSequenceRecvCommitmentKey(prefix []byte, portId, channelId string) ([]byte, error) {
+	key, err := keccak256AbiEncodePacked(nextSeqRecvPrefix, portId, "/", channelId)
+	if err != nil {
+		return nil, err
+	}
+	return append(prefix, key...), nil
+}
+
 // keccak256AbiEncodePacked only covers some data types.
 func keccak256AbiEncodePacked(data ...interface{}) ([]byte, error) {
 	// abi.encodePacked
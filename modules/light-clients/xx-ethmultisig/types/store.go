@@ -1,6 +1,9 @@
 package types
 
 import (
+	"encoding/binary"
+	"strings"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -9,6 +12,18 @@ import (
 	"github.com/cosmos/ibc-go/modules/core/exported"
 )
 
+// KeyIterateConsensusStatePrefix is the prefix of the keys that map a big endian height
+// to the consensus state key, in the same way as the tendermint client does.
+// It provides the ordered iteration over the consensus states.
+const KeyIterateConsensusStatePrefix = "iterateConsensusStates"
+
+var (
+	// KeyProcessedTime is appended to consensus state key to store the processed time
+	KeyProcessedTime = []byte("/processedTime")
+	// KeyProcessedHeight is appended to consensus state key to store the processed height
+	KeyProcessedHeight = []byte("/processedHeight")
+)
+
 // sets the client state to the store
 func setClientState(store sdk.KVStore, cdc codec.BinaryCodec, clientState exported.ClientState) {
 	bz := clienttypes.MustMarshalClientState(cdc, clientState)
@@ -42,12 +57,215 @@ func getConsensusState(store sdk.KVStore, cdc codec.BinaryCodec, height exported
 	return consensusState, nil
 }
 
+// getConsensusStateForHeight returns the consensus state which is valid at the given height.
+// An error is returned if the consensus state does not exist or has been pruned.
+func getConsensusStateForHeight(store sdk.KVStore, cdc codec.BinaryCodec, latestHeight, height exported.Height) (*ConsensusState, error) {
+	consensusHeight, err := getConsensusHeightForHeight(store, latestHeight, height)
+	if err != nil {
+		return nil, err
+	}
+	return getConsensusState(store, cdc, consensusHeight)
+}
+
+// getConsensusHeightForHeight returns the height of the consensus state which is valid at the given height,
+// that is, the given height if a consensus state exists at the height, or the latest height of the client
+// if the given height is greater than it. A height between two consensus states has no valid consensus state,
+// as the Solidity client cannot look up the consensus state below such a height and the two clients must agree.
+func getConsensusHeightForHeight(store sdk.KVStore, latestHeight, height exported.Height) (exported.Height, error) {
+	if store.Has(host.ConsensusStateKey(height)) {
+		return height, nil
+	}
+	if height.GT(latestHeight) {
+		return latestHeight, nil
+	}
+	return nil, sdkerrors.Wrapf(
+		clienttypes.ErrConsensusStateNotFound,
+		"consensus state does not exist for height %s", height,
+	)
+}
+
 // SetConsensusState stores the consensus state at the given height.
 func setConsensusState(clientStore sdk.KVStore, cdc codec.BinaryCodec, consensusState *ConsensusState, height exported.Height) {
-	if height.GetRevisionHeight() != 1 {
-		panic("fatal error")
-	}
 	key := host.ConsensusStateKey(height)
 	val := clienttypes.MustMarshalConsensusState(cdc, consensusState)
 	clientStore.Set(key, val)
 }
+
+// deleteConsensusState deletes the consensus state and its metadata at the given height
+func deleteConsensusState(clientStore sdk.KVStore, height exported.Height) {
+	clientStore.Delete(host.ConsensusStateKey(height))
+	deleteConsensusMetadata(clientStore, height)
+}
+
+// pruneConsensusStates deletes the oldest consensus states so that the number of consensus states
+// does not exceed the maximum once a new consensus state is added, and deletes the consensus states
+// which are older than the max age relative to the timestamp of the new consensus state.
+func pruneConsensusStates(clientStore sdk.KVStore, cdc codec.BinaryCodec, clientState *ClientState, timestamp uint64) error {
+	if clientState.MaxConsensusStates == 0 && clientState.ConsensusStateMaxAge == 0 {
+		return nil
+	}
+
+	var heights []exported.Height
+	iterateConsensusStateAscending(clientStore, func(height exported.Height) bool {
+		heights = append(heights, height)
+		return false
+	})
+
+	for i, height := range heights {
+		// the number of consensus states including the new one if this one is kept
+		if count := uint64(len(heights) - i + 1); clientState.MaxConsensusStates != 0 && count > clientState.MaxConsensusStates {
+			deleteConsensusState(clientStore, height)
+			continue
+		}
+		if clientState.ConsensusStateMaxAge != 0 {
+			consensusState, err := getConsensusState(clientStore, cdc, height)
+			if err != nil {
+				return err
+			}
+			if timestamp > consensusState.Timestamp && timestamp-consensusState.Timestamp > clientState.ConsensusStateMaxAge {
+				deleteConsensusState(clientStore, height)
+				continue
+			}
+		}
+		// the later consensus states are newer than this one
+		break
+	}
+	return nil
+}
+
+// IterateConsensusMetadata iterates through the metadata of the consensus states and applies the callback.
+// If the cb returns true, then iterator will close and stop.
+func IterateConsensusMetadata(store sdk.KVStore, cb func(key, val []byte) bool) {
+	// iterate over processed time and processed height
+	iterator := sdk.KVStorePrefixIterator(store, []byte(host.KeyConsensusStatePrefix))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		// the metadata key has the format: "consensusStates/<height>/processedTime"
+		keySplit := strings.Split(string(iterator.Key()), "/")
+		if len(keySplit) != 3 || (keySplit[2] != "processedTime" && keySplit[2] != "processedHeight") {
+			continue
+		}
+		if cb(iterator.Key(), iterator.Value()) {
+			break
+		}
+	}
+
+	// iterate over iteration keys
+	iterator = sdk.KVStorePrefixIterator(store, []byte(KeyIterateConsensusStatePrefix))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(iterator.Key(), iterator.Value()) {
+			break
+		}
+	}
+}
+
+// ProcessedTimeKey returns the key under which the processed time will be stored in the client store.
+func ProcessedTimeKey(height exported.Height) []byte {
+	return append(host.ConsensusStateKey(height), KeyProcessedTime...)
+}
+
+// SetProcessedTime stores the time at which the consensus state was created on this chain.
+// It is used to check the delay period in the packet verification functions.
+func SetProcessedTime(clientStore sdk.KVStore, height exported.Height, timeNs uint64) {
+	clientStore.Set(ProcessedTimeKey(height), sdk.Uint64ToBigEndian(timeNs))
+}
+
+// GetProcessedTime gets the time (in nanoseconds) at which the consensus state was created on this chain.
+func GetProcessedTime(clientStore sdk.KVStore, height exported.Height) (uint64, bool) {
+	bz := clientStore.Get(ProcessedTimeKey(height))
+	if bz == nil {
+		return 0, false
+	}
+	return sdk.BigEndianToUint64(bz), true
+}
+
+// ProcessedHeightKey returns the key under which the processed height will be stored in the client store.
+func ProcessedHeightKey(height exported.Height) []byte {
+	return append(host.ConsensusStateKey(height), KeyProcessedHeight...)
+}
+
+// SetProcessedHeight stores the height at which the consensus state was created on this chain.
+// It is used to check the delay period in the packet verification functions.
+func SetProcessedHeight(clientStore sdk.KVStore, consHeight, processedHeight exported.Height) {
+	clientStore.Set(ProcessedHeightKey(consHeight), []byte(processedHeight.String()))
+}
+
+// GetProcessedHeight gets the height at which the consensus state was created on this chain.
+func GetProcessedHeight(clientStore sdk.KVStore, height exported.Height) (exported.Height, bool) {
+	bz := clientStore.Get(ProcessedHeightKey(height))
+	if bz == nil {
+		return nil, false
+	}
+	processedHeight, err := clienttypes.ParseHeight(string(bz))
+	if err != nil {
+		return nil, false
+	}
+	return processedHeight, true
+}
+
+// setConsensusMetadata sets the processed time, the processed height and the iteration key
+// of the consensus state at the given height from the current context.
+func setConsensusMetadata(ctx sdk.Context, clientStore sdk.KVStore, height exported.Height) {
+	setConsensusMetadataWithValues(clientStore, height, clienttypes.GetSelfHeight(ctx), uint64(ctx.BlockTime().UnixNano()))
+}
+
+// setConsensusMetadataWithValues sets the consensus metadata with the provided values
+func setConsensusMetadataWithValues(clientStore sdk.KVStore, height, processedHeight exported.Height, processedTime uint64) {
+	SetProcessedTime(clientStore, height, processedTime)
+	SetProcessedHeight(clientStore, height, processedHeight)
+	setIterationKey(clientStore, height)
+}
+
+// deleteConsensusMetadata deletes the metadata stored for the consensus state at the given height.
+func deleteConsensusMetadata(clientStore sdk.KVStore, height exported.Height) {
+	clientStore.Delete(ProcessedTimeKey(height))
+	clientStore.Delete(ProcessedHeightKey(height))
+	deleteIterationKey(clientStore, height)
+}
+
+// IterationKey returns the key under which the consensus state key will be stored.
+func IterationKey(height exported.Height) []byte {
+	return append([]byte(KeyIterateConsensusStatePrefix), bigEndianHeightBytes(height)...)
+}
+
+// setIterationKey stores the consensus state key under a key that is more efficient for ordered iteration
+func setIterationKey(clientStore sdk.KVStore, height exported.Height) {
+	clientStore.Set(IterationKey(height), host.ConsensusStateKey(height))
+}
+
+// deleteIterationKey deletes the iteration key for a given height
+func deleteIterationKey(clientStore sdk.KVStore, height exported.Height) {
+	clientStore.Delete(IterationKey(height))
+}
+
+// getHeightFromIterationKey takes an iteration key and returns the height that it references
+func getHeightFromIterationKey(iterKey []byte) exported.Height {
+	return heightFromBigEndianBytes(iterKey[len(KeyIterateConsensusStatePrefix):])
+}
+
+// iterateConsensusStateAscending iterates through the consensus states in ascending order. It calls the provided
+// callback on each height, until stop=true is returned.
+func iterateConsensusStateAscending(clientStore sdk.KVStore, cb func(height exported.Height) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(clientStore, []byte(KeyIterateConsensusStatePrefix))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(getHeightFromIterationKey(iterator.Key())) {
+			return
+		}
+	}
+}
+
+func bigEndianHeightBytes(height exported.Height) []byte {
+	heightBytes := make([]byte, 16)
+	binary.BigEndian.PutUint64(heightBytes, height.GetRevisionNumber())
+	binary.BigEndian.PutUint64(heightBytes[8:], height.GetRevisionHeight())
+	return heightBytes
+}
+
+func heightFromBigEndianBytes(bz []byte) exported.Height {
+	return clienttypes.NewHeight(binary.BigEndian.Uint64(bz[:8]), binary.BigEndian.Uint64(bz[8:]))
+}
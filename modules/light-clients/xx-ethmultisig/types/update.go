@@ -0,0 +1,113 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+)
+
+// CheckHeaderAndUpdateState checks if the provided header is valid and updates
+// the consensus state if appropriate. It returns an error if:
+// - the header provided is not parseable to a multisig header
+// - the client has expired
+// - the header height is not greater than the latest height of the client
+// - the header timestamp is less than the consensus state timestamp
+// - the header timestamp is out of the max clock drift or the max signature age of the block time
+// - the currently registered signers did not provide the update signature
+// The consensus states exceeding the max count or age of the client state are pruned.
+func (cs ClientState) CheckHeaderAndUpdateState(
+	ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore,
+	header exported.Header,
+) (exported.ClientState, exported.ConsensusState, error) {
+	msHeader, ok := header.(*Header)
+	if !ok {
+		return nil, nil, sdkerrors.Wrapf(
+			clienttypes.ErrInvalidHeader, "header type %T, expected %T", header, &Header{},
+		)
+	}
+
+	cons, err := getConsensusState(clientStore, cdc, cs.GetLatestHeight())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the signers cannot update the client once it has expired
+	if cs.IsExpired(cons.Timestamp, ctx.BlockTime()) {
+		return nil, nil, sdkerrors.Wrap(clienttypes.ErrInvalidHeader, "the client has expired")
+	}
+
+	if err := checkSignatureTimestamp(ctx, cs, msHeader.Timestamp); err != nil {
+		return nil, nil, sdkerrors.Wrap(clienttypes.ErrInvalidHeader, err.Error())
+	}
+
+	if err := checkHeader(cdc, &cs, cons, msHeader); err != nil {
+		return nil, nil, err
+	}
+
+	if err := pruneConsensusStates(clientStore, cdc, &cs, msHeader.Timestamp); err != nil {
+		return nil, nil, err
+	}
+
+	clientState, consensusState := update(ctx, clientStore, &cs, msHeader)
+	return clientState, consensusState, nil
+}
+
+// checkHeader checks if the multisig update signature is valid.
+func checkHeader(cdc codec.BinaryCodec, clientState *ClientState, consensusState *ConsensusState, header *Header) error {
+	if err := header.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidHeader, err.Error())
+	}
+
+	// assert update height is greater than the latest height
+	latestHeight := clientState.GetLatestHeight()
+	if header.GetHeight().GetRevisionNumber() != latestHeight.GetRevisionNumber() || header.GetHeight().LTE(latestHeight) {
+		return sdkerrors.Wrapf(
+			clienttypes.ErrInvalidHeader,
+			"header height must be greater than the latest height of the client (%s <= %s)", header.GetHeight(), latestHeight,
+		)
+	}
+
+	// assert update timestamp is not less than current consensus state timestamp
+	if header.Timestamp < consensusState.Timestamp {
+		return sdkerrors.Wrapf(
+			clienttypes.ErrInvalidHeader,
+			"header timestamp is less than to the consensus state timestamp (%d < %d)", header.Timestamp, consensusState.Timestamp,
+		)
+	}
+
+	// assert currently registered signers signed over the new addresses with the header height
+	signBz, err := HeaderSignBytes(cdc, header, consensusState.Diversifier)
+	if err != nil {
+		return err
+	}
+
+	if err := consensusState.VerifySignature(header.Signature, signBz); err != nil {
+		return sdkerrors.Wrap(clienttypes.ErrInvalidHeader, err.Error())
+	}
+
+	return nil
+}
+
+// update the consensus state to the new addresses and voting powers and advance the latest height.
+// The new consensus state is stored at the header height by the client keeper.
+// The sequence is raised to the header height and the batch root of the latest sequence is cleared,
+// so the rotated-out signers cannot sign any more proofs that are verified with their consensus state.
+func update(ctx sdk.Context, clientStore sdk.KVStore, clientState *ClientState, header *Header) (*ClientState, *ConsensusState) {
+	consensusState := &ConsensusState{
+		Addresses:   header.NewAddresses,
+		Diversifier: header.NewDiversifier,
+		Timestamp:   header.Timestamp,
+		Threshold:   header.NewThreshold,
+		Powers:      header.NewPowers,
+	}
+
+	setConsensusMetadata(ctx, clientStore, header.GetHeight())
+	clientState.LatestHeight = header.Height
+	if header.Height.RevisionHeight > clientState.Sequence {
+		clientState.Sequence = header.Height.RevisionHeight
+	}
+	clientState.BatchRoot = nil
+	return clientState, consensusState
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"bytes"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+)
+
+// VerifyUpgradeAndUpdateState checks if the upgraded client and consensus states are signed by the
+// currently registered signers and returns them if so. The upgrade is a single multisig over the sign
+// bytes of the UPGRADE data type at the latest height of the client, which covers both states so that
+// the states of different upgrades cannot be combined. The 02-client keeper requires both proofs,
+// so the consensus state proof must be the same as the client state proof. It returns an error if:
+// - the upgraded client or consensus state is not a multisig client or consensus state
+// - the upgraded latest height is not greater than the latest height of the client
+// - the upgraded consensus state timestamp is less than the current consensus state timestamp
+// - the proofs are not the same
+// - the signature timestamp is out of the max clock drift or the max signature age of the block time
+// - the currently registered signers did not sign the upgrade
+// The custom fields and the latest sequence of the current client state are carried over to the upgraded client state
+// of the same revision.
+func (cs ClientState) VerifyUpgradeAndUpdateState(
+	ctx sdk.Context, cdc codec.BinaryCodec, clientStore sdk.KVStore,
+	upgradedClient exported.ClientState, upgradedConsState exported.ConsensusState,
+	proofUpgradeClient, proofUpgradeConsState []byte,
+) (exported.ClientState, exported.ConsensusState, error) {
+	msUpgradedClient, ok := upgradedClient.(*ClientState)
+	if !ok {
+		return nil, nil, sdkerrors.Wrapf(
+			clienttypes.ErrInvalidClientType, "upgraded client must be a multisig client. expected: %T got: %T",
+			&ClientState{}, upgradedClient,
+		)
+	}
+	msUpgradedConsState, ok := upgradedConsState.(*ConsensusState)
+	if !ok {
+		return nil, nil, sdkerrors.Wrapf(
+			clienttypes.ErrInvalidConsensus, "upgraded consensus state must be a multisig consensus state. expected %T, got: %T",
+			&ConsensusState{}, upgradedConsState,
+		)
+	}
+
+	lastHeight := cs.GetLatestHeight()
+	if !msUpgradedClient.GetLatestHeight().GT(lastHeight) {
+		return nil, nil, sdkerrors.Wrapf(
+			sdkerrors.ErrInvalidHeight, "upgraded client height %s must be at greater than current client height %s",
+			msUpgradedClient.GetLatestHeight(), lastHeight,
+		)
+	}
+
+	cons, err := getConsensusState(clientStore, cdc, lastHeight)
+	if err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "could not retrieve consensus state for lastHeight")
+	}
+
+	if msUpgradedConsState.Timestamp < cons.Timestamp {
+		return nil, nil, sdkerrors.Wrapf(
+			clienttypes.ErrInvalidUpgradeClient,
+			"upgraded consensus state timestamp is less than the consensus state timestamp (%d < %d)", msUpgradedConsState.Timestamp, cons.Timestamp,
+		)
+	}
+
+	if !bytes.Equal(proofUpgradeClient, proofUpgradeConsState) {
+		return nil, nil, sdkerrors.Wrap(ErrInvalidProof, "consensus state proof must be the same as the client state proof")
+	}
+	if err := verifyUpgradeProof(ctx, cdc, cs, lastHeight, cons, proofUpgradeClient, msUpgradedClient, msUpgradedConsState); err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "upgrade proof failed")
+	}
+
+	newClientState := &ClientState{
+		LatestHeight:         msUpgradedClient.LatestHeight,
+		MaxConsensusStates:   cs.MaxConsensusStates,
+		ConsensusStateMaxAge: cs.ConsensusStateMaxAge,
+		TrustingPeriod:       cs.TrustingPeriod,
+		MaxClockDrift:        cs.MaxClockDrift,
+		MaxSignatureAge:      cs.MaxSignatureAge,
+		Timestamp:            cs.Timestamp,
+	}
+	// the sequence starts over with a new revision, since the proofs must be at the latest revision.
+	// Within the revision, it is raised to the upgraded height as on an update, so the current signers
+	// cannot sign any more proofs that are verified with their consensus state.
+	if newClientState.GetLatestHeight().GetRevisionNumber() == lastHeight.GetRevisionNumber() {
+		newClientState.Sequence = cs.Sequence
+		if upgradedHeight := newClientState.LatestHeight.RevisionHeight; upgradedHeight > newClientState.Sequence {
+			newClientState.Sequence = upgradedHeight
+		}
+	}
+
+	// the consensus state is stored at the upgraded latest height by the client keeper
+	setConsensusMetadata(ctx, clientStore, newClientState.GetLatestHeight())
+	return newClientState, msUpgradedConsState, nil
+}
+
+// verifyUpgradeProof verifies that the proof is a multisig of the consensus state over the upgrade.
+func verifyUpgradeProof(
+	ctx sdk.Context, cdc codec.BinaryCodec, cs ClientState, height exported.Height, consensusState *ConsensusState, proof []byte,
+	upgradedClient exported.ClientState, upgradedConsState exported.ConsensusState,
+) error {
+	var multiSig MultiSignature
+	if err := cdc.Unmarshal(proof, &multiSig); err != nil {
+		return err
+	}
+	if err := checkSignatureTimestamp(ctx, cs, multiSig.Timestamp); err != nil {
+		return err
+	}
+	if consensusState.GetTimestamp() > multiSig.Timestamp {
+		return sdkerrors.Wrapf(ErrInvalidProof, "the consensus state timestamp is greater than the signature timestamp (%d >= %d)", consensusState.GetTimestamp(), multiSig.Timestamp)
+	}
+	signBz, err := UpgradeSignBytes(cdc, height.(clienttypes.Height), multiSig.Timestamp, consensusState.Diversifier, upgradedClient, upgradedConsState)
+	if err != nil {
+		return err
+	}
+	return consensusState.VerifySignature(&multiSig, signBz)
+}
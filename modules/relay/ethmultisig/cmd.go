@@ -0,0 +1,134 @@
+package ethmultisig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig/signer"
+)
+
+const (
+	flagMnemonicFile = "mnemonic-file"
+	flagHDWPath      = "hdw-path"
+	flagListen       = "listen"
+	flagTLSCert      = "tls-cert"
+	flagTLSKey       = "tls-key"
+	flagClientCA     = "client-ca"
+	flagDiversifiers = "diversifiers"
+	flagInsecure     = "insecure"
+
+	// mnemonicEnv is the environment variable of the mnemonic if no mnemonic file is given
+	mnemonicEnv = "ETHMULTISIG_SIGNER_MNEMONIC"
+)
+
+// ethmultisigCmd returns the commands of the ethmultisig module
+func ethmultisigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ethmultisig",
+		Short: "manage ethmultisig signers",
+	}
+	cmd.AddCommand(signerCmd())
+	return cmd
+}
+
+func signerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signer",
+		Short: "manage a remote signer",
+	}
+	cmd.AddCommand(signerServeCmd())
+	return cmd
+}
+
+func signerServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "serve the gRPC signing API with a key of a signer of the multisig",
+		Long: fmt.Sprintf(`Serve the gRPC signing API with the key derived from a mnemonic.
+The mnemonic is read from --%v, or from the %v environment variable.
+The connections are encrypted with TLS unless --%v is given, and --%v requires
+the clients to present a certificate signed by the CA (mutual TLS).`, flagMnemonicFile, mnemonicEnv, flagInsecure, flagClientCA),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mnemonic, err := readMnemonic(cmd)
+			if err != nil {
+				return err
+			}
+			hdwPath, _ := cmd.Flags().GetString(flagHDWPath)
+			key, err := NewHDWalletSigner(mnemonic, hdwPath)
+			if err != nil {
+				return err
+			}
+			tlsConfig, err := serverTLSConfig(cmd)
+			if err != nil {
+				return err
+			}
+			diversifiers, _ := cmd.Flags().GetStringSlice(flagDiversifiers)
+			listen, _ := cmd.Flags().GetString(flagListen)
+			lis, err := net.Listen("tcp", listen)
+			if err != nil {
+				return err
+			}
+			server := signer.NewGRPCServer(signer.NewServer(key, diversifiers), tlsConfig)
+			go func() {
+				sigs := make(chan os.Signal, 1)
+				signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+				<-sigs
+				server.GracefulStop()
+			}()
+			log.Printf("signer %v is listening on %v", key.Address(), lis.Addr())
+			return server.Serve(lis)
+		},
+	}
+	cmd.Flags().String(flagMnemonicFile, "", "file of the mnemonic of the key")
+	cmd.Flags().String(flagHDWPath, "m/44'/60'/0'/0/0", "HD path of the key")
+	cmd.Flags().String(flagListen, "127.0.0.1:9090", "address to listen on")
+	cmd.Flags().String(flagTLSCert, "", "PEM file of the server certificate")
+	cmd.Flags().String(flagTLSKey, "", "PEM file of the key of the server certificate")
+	cmd.Flags().String(flagClientCA, "", "PEM file of the CA certificates that verify the client certificates")
+	cmd.Flags().StringSlice(flagDiversifiers, nil, "diversifiers that the signer signs for. if empty, any diversifier is allowed")
+	cmd.Flags().Bool(flagInsecure, false, "serve without TLS. it must be used only for a local relayer")
+	return cmd
+}
+
+func readMnemonic(cmd *cobra.Command) (string, error) {
+	file, _ := cmd.Flags().GetString(flagMnemonicFile)
+	if file == "" {
+		mnemonic := os.Getenv(mnemonicEnv)
+		if mnemonic == "" {
+			return "", fmt.Errorf("either --%v or %v is needed", flagMnemonicFile, mnemonicEnv)
+		}
+		return mnemonic, nil
+	}
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(bz)), nil
+}
+
+func serverTLSConfig(cmd *cobra.Command) (*tls.Config, error) {
+	insecure, _ := cmd.Flags().GetBool(flagInsecure)
+	certFile, _ := cmd.Flags().GetString(flagTLSCert)
+	keyFile, _ := cmd.Flags().GetString(flagTLSKey)
+	clientCAFile, _ := cmd.Flags().GetString(flagClientCA)
+	if insecure {
+		if certFile != "" || keyFile != "" || clientCAFile != "" {
+			return nil, fmt.Errorf("--%v cannot be used with the TLS flags", flagInsecure)
+		}
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("--%v and --%v are needed unless --%v is given", flagTLSCert, flagTLSKey, flagInsecure)
+	}
+	return signer.ServerTLSConfig(certFile, keyFile, clientCAFile)
+}
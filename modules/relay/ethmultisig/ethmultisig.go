@@ -1,18 +1,19 @@
 package ethmultisig
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
 	conntypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
 	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
 	"github.com/cosmos/ibc-go/modules/core/exported"
 	ibcexported "github.com/cosmos/ibc-go/modules/core/exported"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/ibc/client"
 
@@ -22,62 +23,176 @@ import (
 type ETHMultisig struct {
 	cdc         codec.ProtoCodecMarshaler
 	diversifier string
-	keys        []*ecdsa.PrivateKey
+	signers     []Signer
 	prefix      []byte
+	// signerSet is the whole signer set of the client if the signers are only a part of it
+	signerSet []common.Address
+	// powers are the voting powers of the signer set and threshold is the power that the signatures must reach.
+	// If threshold is zero, every signer must sign.
+	powers    []uint64
+	threshold uint64
+	// ctx is the context of the sign requests
+	ctx context.Context
+	// now returns the time used as the timestamp of the signatures
+	now func() time.Time
+	// version is the version of the multisig proofs
+	version ethmultisigtypes.MultiSignature_Version
 }
 
-func NewETHMultisig(cdc codec.ProtoCodecMarshaler, diversifier string, keys []*ecdsa.PrivateKey, prefix []byte) ETHMultisig {
-	return ETHMultisig{cdc: cdc, diversifier: diversifier, keys: keys, prefix: prefix}
+// NewETHMultisig returns a multisig whose proofs are signed by all the signers
+func NewETHMultisig(cdc codec.ProtoCodecMarshaler, diversifier string, signers []Signer, prefix []byte) ETHMultisig {
+	return ETHMultisig{cdc: cdc, diversifier: diversifier, signers: signers, prefix: prefix}
 }
 
+// Addresses returns the addresses of the signers
 func (m ETHMultisig) Addresses() []common.Address {
 	var addresses []common.Address
-	for _, key := range m.keys {
-		addr := crypto.PubkeyToAddress(key.PublicKey)
-		addresses = append(addresses, addr)
+	for _, signer := range m.signers {
+		addresses = append(addresses, signer.Address())
 	}
 	return addresses
 }
 
+// WithSignerSet returns a multisig that signs as a part of the given signer set.
+// Its proofs contain a signer bitmap, so a quorum of the set can sign without the other signers.
+func (m ETHMultisig) WithSignerSet(signers []common.Address) (ETHMultisig, error) {
+	for _, addr := range m.Addresses() {
+		if signerIndex(signers, addr) < 0 {
+			return ETHMultisig{}, fmt.Errorf("address %v is not contained in the signer set", addr)
+		}
+	}
+	m.signerSet = signers
+	m.powers, m.threshold = nil, 0
+	return m, nil
+}
+
+// WithThreshold returns a multisig that stops requesting the signers once the signers that have signed
+// reach the threshold voting power of the signer set, so the proofs do not wait for the slow or failed signers.
+// powers are the voting powers of the signer set, where empty powers give every signer a power of one,
+// and a zero threshold is the total power. The signer set must be given with WithSignerSet first.
+func (m ETHMultisig) WithThreshold(powers []uint64, threshold uint64) (ETHMultisig, error) {
+	if len(m.signerSet) == 0 {
+		return ETHMultisig{}, fmt.Errorf("a threshold needs the signer set")
+	}
+	if len(powers) == 0 {
+		powers = make([]uint64, len(m.signerSet))
+		for i := range powers {
+			powers[i] = 1
+		}
+	} else if len(powers) != len(m.signerSet) {
+		return ETHMultisig{}, fmt.Errorf("the number of powers must equal the number of signers: %v != %v", len(powers), len(m.signerSet))
+	}
+	var total uint64
+	for _, power := range powers {
+		total += power
+	}
+	if threshold == 0 {
+		threshold = total
+	} else if threshold > total {
+		return ETHMultisig{}, fmt.Errorf("threshold must not be greater than the total power: %v > %v", threshold, total)
+	}
+	m.powers, m.threshold = powers, threshold
+	return m, nil
+}
+
+// SignerAddresses returns the whole signer set of the client
+func (m ETHMultisig) SignerAddresses() []common.Address {
+	if len(m.signerSet) == 0 {
+		return m.Addresses()
+	}
+	return m.signerSet
+}
+
+// WithClock returns a multisig that uses the given clock for the timestamp of the signatures.
+func (m ETHMultisig) WithClock(now func() time.Time) ETHMultisig {
+	m.now = now
+	return m
+}
+
+// WithContext returns a multisig whose sign requests are canceled when the context is done.
+func (m ETHMultisig) WithContext(ctx context.Context) ETHMultisig {
+	m.ctx = ctx
+	return m
+}
+
+// WithProofVersion returns a multisig that signs proofs of the given version.
+func (m ETHMultisig) WithProofVersion(version ethmultisigtypes.MultiSignature_Version) (ETHMultisig, error) {
+	if _, ok := ethmultisigtypes.MultiSignature_Version_name[int32(version)]; !ok {
+		return ETHMultisig{}, fmt.Errorf("unsupported proof version: %v", version)
+	}
+	m.version = version
+	return m, nil
+}
+
 // GetCurrentTimestamp returns current time
 func (m ETHMultisig) GetCurrentTimestamp() uint64 {
+	if m.now != nil {
+		return uint64(m.now().UnixNano())
+	}
 	return uint64(time.Now().UnixNano())
 }
 
 func (m ETHMultisig) SignConsensusState(height clienttypes.Height, clientID string, dstClientConsHeight ibcexported.Height, consensusState exported.ConsensusState) (*ethmultisigtypes.MultiSignature, []byte, error) {
-	bz, err := m.cdc.MarshalInterface(consensusState)
+	data, err := m.ConsensusStateData(clientID, dstClientConsHeight, consensusState)
 	if err != nil {
 		return nil, nil, err
 	}
+	return m.SignState(height, ethmultisigtypes.CONSENSUS, data.Path, data.Value)
+}
+
+// ConsensusStateData returns the path and value of the consensus state that SignConsensusState signs
+func (m ETHMultisig) ConsensusStateData(clientID string, dstClientConsHeight ibcexported.Height, consensusState exported.ConsensusState) (ethmultisigtypes.StateData, error) {
+	bz, err := m.cdc.MarshalInterface(consensusState)
+	if err != nil {
+		return ethmultisigtypes.StateData{}, err
+	}
 	path, err := ethmultisigtypes.ConsensusCommitmentKey(m.prefix, clientID, dstClientConsHeight)
 	if err != nil {
-		return nil, nil, err
+		return ethmultisigtypes.StateData{}, err
 	}
-	return m.SignState(height, ethmultisigtypes.CONSENSUS, path, bz)
+	return ethmultisigtypes.StateData{Path: path, Value: bz}, nil
 }
 
 func (m ETHMultisig) SignClientState(height clienttypes.Height, clientID string, clientState exported.ClientState) (*ethmultisigtypes.MultiSignature, []byte, error) {
-	bz, err := m.cdc.MarshalInterface(clientState)
+	data, err := m.ClientStateData(clientID, clientState)
 	if err != nil {
 		return nil, nil, err
 	}
+	return m.SignState(height, ethmultisigtypes.CLIENT, data.Path, data.Value)
+}
+
+// ClientStateData returns the path and value of the client state that SignClientState signs
+func (m ETHMultisig) ClientStateData(clientID string, clientState exported.ClientState) (ethmultisigtypes.StateData, error) {
+	bz, err := m.cdc.MarshalInterface(clientState)
+	if err != nil {
+		return ethmultisigtypes.StateData{}, err
+	}
 	path, err := ethmultisigtypes.ClientCommitmentKey(m.prefix, clientID)
 	if err != nil {
-		return nil, nil, err
+		return ethmultisigtypes.StateData{}, err
 	}
-	return m.SignState(height, ethmultisigtypes.CLIENT, path, bz)
+	return ethmultisigtypes.StateData{Path: path, Value: bz}, nil
 }
 
 func (m ETHMultisig) SignConnectionState(height clienttypes.Height, connectionID string, connection conntypes.ConnectionEnd) (*ethmultisigtypes.MultiSignature, []byte, error) {
-	bz, err := m.cdc.Marshal(&connection)
+	data, err := m.ConnectionStateData(connectionID, connection)
 	if err != nil {
 		return nil, nil, err
 	}
+	return m.SignState(height, ethmultisigtypes.CONNECTION, data.Path, data.Value)
+}
+
+// ConnectionStateData returns the path and value of the connection state that SignConnectionState signs
+func (m ETHMultisig) ConnectionStateData(connectionID string, connection conntypes.ConnectionEnd) (ethmultisigtypes.StateData, error) {
+	bz, err := m.cdc.Marshal(&connection)
+	if err != nil {
+		return ethmultisigtypes.StateData{}, err
+	}
 	path, err := ethmultisigtypes.ConnectionCommitmentKey(m.prefix, connectionID)
 	if err != nil {
-		return nil, nil, err
+		return ethmultisigtypes.StateData{}, err
 	}
-	return m.SignState(height, ethmultisigtypes.CONNECTION, path, bz)
+	return ethmultisigtypes.StateData{Path: path, Value: bz}, nil
 }
 
 func (m ETHMultisig) SignChannelState(height clienttypes.Height, portID, channelID string, channel chantypes.Channel) (*ethmultisigtypes.MultiSignature, []byte, error) {
@@ -114,6 +229,22 @@ func (m ETHMultisig) SignPacketAcknowledgementState(height clienttypes.Height, p
 	return m.SignState(height, ethmultisigtypes.PACKETACKNOWLEDGEMENT, path, acknowledgementCommitment)
 }
 
+func (m ETHMultisig) SignPacketReceiptAbsence(height clienttypes.Height, portID, channelID string, sequence uint64) (*ethmultisigtypes.MultiSignature, []byte, error) {
+	path, err := ethmultisigtypes.PacketReceiptCommitmentKey(m.prefix, portID, channelID, sequence)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m.SignState(height, ethmultisigtypes.PACKETRECEIPTABSENCE, path, nil)
+}
+
+func (m ETHMultisig) SignNextSequenceRecv(height clienttypes.Height, portID, channelID string, nextSequenceRecv uint64) (*ethmultisigtypes.MultiSignature, []byte, error) {
+	path, err := ethmultisigtypes.NextSequenceRecvCommitmentKey(m.prefix, portID, channelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m.SignState(height, ethmultisigtypes.NEXTSEQUENCERECV, path, sdk.Uint64ToBigEndian(nextSequenceRecv))
+}
+
 func (m ETHMultisig) SignState(height clienttypes.Height, dtp ethmultisigtypes.SignBytes_DataType, path, value []byte) (*ethmultisigtypes.MultiSignature, []byte, error) {
 	data, err := m.cdc.Marshal(&ethmultisigtypes.StateData{
 		Path:  path,
@@ -133,14 +264,219 @@ func (m ETHMultisig) SignState(height clienttypes.Height, dtp ethmultisigtypes.S
 	if err != nil {
 		return nil, nil, err
 	}
-	signHash := gethcrypto.Keccak256(signBytes)
-	proof := ethmultisigtypes.MultiSignature{Timestamp: ts}
-	for _, key := range m.keys {
-		sig, err := gethcrypto.Sign(signHash, key)
+	proof, err := m.sign(signBytes, ts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, signBytes, nil
+}
+
+// SignStates signs the root of a batch of the states at once and returns a proof for each state in the same order,
+// which carries the root signature and the Merkle inclusion path of the state. The path and value of each state
+// are the same as the ones of SignState, and each proof can be verified with the data type of its state.
+func (m ETHMultisig) SignStates(height clienttypes.Height, states []ethmultisigtypes.StateData) ([]*ethmultisigtypes.MultiSignature, []byte, error) {
+	var leaves [][]byte
+	for i := range states {
+		leaf, err := m.cdc.Marshal(&states[i])
 		if err != nil {
 			return nil, nil, err
 		}
-		proof.Signatures = append(proof.Signatures, sig)
+		leaves = append(leaves, leaf)
+	}
+	batchProofs, err := ethmultisigtypes.NewBatchProofs(leaves)
+	if err != nil {
+		return nil, nil, err
+	}
+	ts := m.GetCurrentTimestamp()
+	signBytes, err := ethmultisigtypes.BatchSignBytes(m.cdc, height, ts, m.diversifier, batchProofs[0].Root)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := m.sign(signBytes, ts)
+	if err != nil {
+		return nil, nil, err
+	}
+	proofs := make([]*ethmultisigtypes.MultiSignature, len(batchProofs))
+	for i, batchProof := range batchProofs {
+		p := *proof
+		p.Batch = batchProof
+		proofs[i] = &p
+	}
+	return proofs, signBytes, nil
+}
+
+// SignHeader returns a header that rotates the signer set to the given addresses, threshold and diversifier.
+// Every new address has a voting power of one. The header is signed by the current signers of the multisig.
+func (m ETHMultisig) SignHeader(height clienttypes.Height, newAddresses []common.Address, newThreshold uint64, newDiversifier string) (*ethmultisigtypes.Header, []byte, error) {
+	return m.SignWeightedHeader(height, newAddresses, nil, newThreshold, newDiversifier)
+}
+
+// SignWeightedHeader returns a header that rotates the signer set to the given addresses, voting powers, threshold and diversifier.
+// The header is signed by the current signers of the multisig.
+func (m ETHMultisig) SignWeightedHeader(height clienttypes.Height, newAddresses []common.Address, newPowers []uint64, newThreshold uint64, newDiversifier string) (*ethmultisigtypes.Header, []byte, error) {
+	var addresses [][]byte
+	for _, addr := range newAddresses {
+		addresses = append(addresses, addr.Bytes())
+	}
+	header := &ethmultisigtypes.Header{
+		Height:         client.Height{RevisionNumber: height.RevisionNumber, RevisionHeight: height.RevisionHeight},
+		Timestamp:      m.GetCurrentTimestamp(),
+		NewAddresses:   addresses,
+		NewDiversifier: newDiversifier,
+		NewThreshold:   newThreshold,
+		NewPowers:      newPowers,
+	}
+	signBytes, err := ethmultisigtypes.HeaderSignBytes(m.cdc, header, m.diversifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	header.Signature, err = m.sign(signBytes, header.Timestamp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, signBytes, nil
+}
+
+// SignUpgrade returns a multisig over the upgraded client and consensus state at the given height,
+// which is the latest height of the client to be upgraded. The multisig is both the client state proof
+// and the consensus state proof of the upgrade.
+func (m ETHMultisig) SignUpgrade(height clienttypes.Height, upgradedClient exported.ClientState, upgradedConsState exported.ConsensusState) (*ethmultisigtypes.MultiSignature, []byte, error) {
+	ts := m.GetCurrentTimestamp()
+	signBytes, err := ethmultisigtypes.UpgradeSignBytes(m.cdc, height, ts, m.diversifier, upgradedClient, upgradedConsState)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := m.sign(signBytes, ts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, signBytes, nil
+}
+
+func (m ETHMultisig) sign(signBytes []byte, timestamp uint64) (*ethmultisigtypes.MultiSignature, error) {
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	signHash := gethcrypto.Keccak256(signBytes)
+	proof := ethmultisigtypes.MultiSignature{Timestamp: timestamp}
+	if len(m.signerSet) == 0 {
+		sigs, err := signAll(ctx, m.signers, nil, 0, signBytes, signHash)
+		if err != nil {
+			return nil, err
+		}
+		proof.Signatures = sigs
+	} else {
+		// signatures must be ordered by the signer index
+		signers := make([]Signer, len(m.signers))
+		copy(signers, m.signers)
+		sort.Slice(signers, func(i, j int) bool {
+			return signerIndex(m.signerSet, signers[i].Address()) < signerIndex(m.signerSet, signers[j].Address())
+		})
+		var powers []uint64
+		if m.threshold != 0 {
+			for _, signer := range signers {
+				powers = append(powers, m.powers[signerIndex(m.signerSet, signer.Address())])
+			}
+		}
+		sigs, err := signAll(ctx, signers, powers, m.threshold, signBytes, signHash)
+		if err != nil {
+			return nil, err
+		}
+		// the bitmap has only the signers that have signed
+		var indexes []int
+		for i, sig := range sigs {
+			if sig != nil {
+				indexes = append(indexes, signerIndex(m.signerSet, signers[i].Address()))
+				proof.Signatures = append(proof.Signatures, sig)
+			}
+		}
+		proof.SignerBitmap, err = ethmultisigtypes.NewSignerBitmap(len(m.signerSet), indexes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if m.version == ethmultisigtypes.COMPACT {
+		return proof.ToCompact()
+	}
+	return &proof, nil
+}
+
+// signAll requests all the signers to sign at the same time and returns the signatures in the order of the signers.
+// If threshold is zero, every signer must sign and the error of the first signer in the order that fails is returned.
+// Otherwise, the requests are canceled once the signers that have signed reach the threshold, where powers[i] is
+// the voting power of signers[i], and the signatures of the other signers are nil.
+func signAll(ctx context.Context, signers []Signer, powers []uint64, threshold uint64, signBytes, signHash []byte) ([][]byte, error) {
+	if threshold == 0 {
+		powers = make([]uint64, len(signers))
+		for i := range powers {
+			powers[i] = 1
+		}
+		threshold = uint64(len(signers))
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		index int
+		sig   []byte
+		err   error
+	}
+	// the channel is buffered so that the requests that are canceled do not block
+	results := make(chan result, len(signers))
+	for i, signer := range signers {
+		go func(i int, signer Signer) {
+			sig, err := signOne(ctx, signer, signBytes, signHash)
+			results <- result{index: i, sig: sig, err: err}
+		}(i, signer)
+	}
+	sigs := make([][]byte, len(signers))
+	errs := make([]error, len(signers))
+	var signedPower uint64
+	for range signers {
+		res := <-results
+		if res.err != nil {
+			errs[res.index] = res.err
+			continue
+		}
+		sigs[res.index] = res.sig
+		if signedPower += powers[res.index]; signedPower >= threshold {
+			return sigs, nil
+		}
+	}
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("signer %v failed to sign: %w", signers[i].Address(), err)
+		}
+	}
+	return nil, fmt.Errorf("the signers signed with %v power below the threshold %v", signedPower, threshold)
+}
+
+// signOne requests the signer to sign and checks the signature,
+// so a signature that the client rejects is caught here rather than on the counterparty chain.
+func signOne(ctx context.Context, signer Signer, signBytes, signHash []byte) ([]byte, error) {
+	var sig []byte
+	var err error
+	if s, ok := signer.(BytesSigner); ok {
+		sig, err = s.SignBytes(ctx, signBytes)
+	} else {
+		sig, err = signer.SignHash(ctx, signHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if addr, err := ethmultisigtypes.RecoverSigner(signHash, sig); err != nil {
+		return nil, fmt.Errorf("returned an invalid signature: %w", err)
+	} else if addr != signer.Address() {
+		return nil, fmt.Errorf("returned a signature of %v", addr)
+	}
+	return sig, nil
+}
+
+func signerIndex(signers []common.Address, addr common.Address) int {
+	for i, signer := range signers {
+		if signer == addr {
+			return i
+		}
 	}
-	return &proof, signBytes, nil
+	return -1
 }
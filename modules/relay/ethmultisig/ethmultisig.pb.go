@@ -28,6 +28,25 @@ type ProverConfig struct {
 	Diversifier string      `protobuf:"bytes,1,opt,name=diversifier,proto3" json:"diversifier,omitempty"`
 	Wallets     []*HDWallet `protobuf:"bytes,2,rep,name=wallets,proto3" json:"wallets,omitempty"`
 	Prefix      string      `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// minimum total voting power of the signers required by the client. zero means the total power.
+	Threshold uint64 `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// hex addresses of the whole signer set. if empty, the addresses of the wallets are used.
+	Addresses []string `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// voting power of each address of the signer set. if empty, every signer has a voting power of one.
+	Powers []uint64 `protobuf:"varint,6,rep,packed,name=powers,proto3" json:"powers,omitempty"`
+	// version of the MultiSignature proofs: 0 for the legacy format and 1 for the compact format.
+	ProofVersion int32 `protobuf:"varint,7,opt,name=proof_version,json=proofVersion,proto3" json:"proof_version,omitempty"`
+	// type of the store of the signing sequence: "file" or "leveldb". if empty, "file" is used.
+	SequenceStore string `protobuf:"bytes,8,opt,name=sequence_store,json=sequenceStore,proto3" json:"sequence_store,omitempty"`
+	// directory of the sequence store. if empty, "$HOME/.urelayer/ethmultisig" is used.
+	SequenceDir string `protobuf:"bytes,9,opt,name=sequence_dir,json=sequenceDir,proto3" json:"sequence_dir,omitempty"`
+	// wallets of the signer set that the client on the counterparty chain currently has.
+	// they sign the header that rotates the client to the signer set of this config.
+	PreviousWallets []*HDWallet `protobuf:"bytes,10,rep,name=previous_wallets,json=previousWallets,proto3" json:"previous_wallets,omitempty"`
+	// remote signers that sign as the signers of the signer set in addition to the wallets.
+	RemoteSigners []*RemoteSigner `protobuf:"bytes,11,rep,name=remote_signers,json=remoteSigners,proto3" json:"remote_signers,omitempty"`
+	// remote signers of the signer set that the client on the counterparty chain currently has.
+	PreviousRemoteSigners []*RemoteSigner `protobuf:"bytes,12,rep,name=previous_remote_signers,json=previousRemoteSigners,proto3" json:"previous_remote_signers,omitempty"`
 }
 
 func (m *ProverConfig) Reset()         { *m = ProverConfig{} }
@@ -84,6 +103,69 @@ func (m *ProverConfig) GetPrefix() string {
 	return ""
 }
 
+func (m *ProverConfig) GetThreshold() uint64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *ProverConfig) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *ProverConfig) GetPowers() []uint64 {
+	if m != nil {
+		return m.Powers
+	}
+	return nil
+}
+
+func (m *ProverConfig) GetProofVersion() int32 {
+	if m != nil {
+		return m.ProofVersion
+	}
+	return 0
+}
+
+func (m *ProverConfig) GetSequenceStore() string {
+	if m != nil {
+		return m.SequenceStore
+	}
+	return ""
+}
+
+func (m *ProverConfig) GetSequenceDir() string {
+	if m != nil {
+		return m.SequenceDir
+	}
+	return ""
+}
+
+func (m *ProverConfig) GetPreviousWallets() []*HDWallet {
+	if m != nil {
+		return m.PreviousWallets
+	}
+	return nil
+}
+
+func (m *ProverConfig) GetRemoteSigners() []*RemoteSigner {
+	if m != nil {
+		return m.RemoteSigners
+	}
+	return nil
+}
+
+func (m *ProverConfig) GetPreviousRemoteSigners() []*RemoteSigner {
+	if m != nil {
+		return m.PreviousRemoteSigners
+	}
+	return nil
+}
+
 type HDWallet struct {
 	Mnemonic string `protobuf:"bytes,1,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
 	HdwPath  string `protobuf:"bytes,2,opt,name=hdw_path,json=hdwPath,proto3" json:"hdw_path,omitempty"`
@@ -136,9 +218,128 @@ func (m *HDWallet) GetHdwPath() string {
 	return ""
 }
 
+// RemoteSigner is the config of a connection to a signer daemon.
+type RemoteSigner struct {
+	// address of the gRPC endpoint of the signer, such as "signer.example.com:9090"
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// hex address of the key of the signer. the signatures of other keys are rejected.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// PEM file of the CA certificates that verify the server certificate.
+	// if empty, the system CA certificates are used.
+	CaFile string `protobuf:"bytes,3,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+	// PEM files of the client certificate and its key for the mutual TLS.
+	CertFile string `protobuf:"bytes,4,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	KeyFile  string `protobuf:"bytes,5,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	// server name to verify the server certificate. if empty, the host of the endpoint is used.
+	ServerName string `protobuf:"bytes,6,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	// if true, the connection is not encrypted. it must be used only for a local signer.
+	Insecure bool `protobuf:"varint,7,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	// timeout of each request (in nanoseconds). zero means the default of 10 seconds.
+	Timeout uint64 `protobuf:"varint,8,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// number of the retries after a request fails.
+	MaxRetries uint32 `protobuf:"varint,9,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+}
+
+func (m *RemoteSigner) Reset()         { *m = RemoteSigner{} }
+func (m *RemoteSigner) String() string { return proto.CompactTextString(m) }
+func (*RemoteSigner) ProtoMessage()    {}
+func (*RemoteSigner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2476e5d20aae6674, []int{2}
+}
+func (m *RemoteSigner) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RemoteSigner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoteSigner.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RemoteSigner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoteSigner.Merge(m, src)
+}
+func (m *RemoteSigner) XXX_Size() int {
+	return m.Size()
+}
+func (m *RemoteSigner) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoteSigner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoteSigner proto.InternalMessageInfo
+
+func (m *RemoteSigner) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *RemoteSigner) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RemoteSigner) GetCaFile() string {
+	if m != nil {
+		return m.CaFile
+	}
+	return ""
+}
+
+func (m *RemoteSigner) GetCertFile() string {
+	if m != nil {
+		return m.CertFile
+	}
+	return ""
+}
+
+func (m *RemoteSigner) GetKeyFile() string {
+	if m != nil {
+		return m.KeyFile
+	}
+	return ""
+}
+
+func (m *RemoteSigner) GetServerName() string {
+	if m != nil {
+		return m.ServerName
+	}
+	return ""
+}
+
+func (m *RemoteSigner) GetInsecure() bool {
+	if m != nil {
+		return m.Insecure
+	}
+	return false
+}
+
+func (m *RemoteSigner) GetTimeout() uint64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *RemoteSigner) GetMaxRetries() uint32 {
+	if m != nil {
+		return m.MaxRetries
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ProverConfig)(nil), "ibc.relay.ethmultisig.ProverConfig")
 	proto.RegisterType((*HDWallet)(nil), "ibc.relay.ethmultisig.HDWallet")
+	proto.RegisterType((*RemoteSigner)(nil), "ibc.relay.ethmultisig.RemoteSigner")
 }
 
 func init() {
@@ -146,26 +347,46 @@ func init() {
 }
 
 var fileDescriptor_2476e5d20aae6674 = []byte{
-	// 304 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x3f, 0x4f, 0x02, 0x31,
-	0x18, 0xc6, 0x39, 0x48, 0x00, 0x8b, 0xd3, 0x45, 0xcd, 0xc1, 0x70, 0x12, 0x16, 0x59, 0x68, 0x13,
-	0x9d, 0x1c, 0xfd, 0x33, 0x30, 0x12, 0x16, 0x13, 0x17, 0xd3, 0xf6, 0x7a, 0xed, 0x9b, 0xf4, 0x5a,
-	0xd2, 0xeb, 0x81, 0xcc, 0x7e, 0x01, 0x3f, 0x96, 0x23, 0xa3, 0xa3, 0x81, 0x2f, 0x62, 0x2c, 0x60,
-	0x2e, 0xd1, 0xed, 0x79, 0xfa, 0xfc, 0xd2, 0xf7, 0xc9, 0xfb, 0xa2, 0x2b, 0x60, 0x9c, 0x38, 0xa1,
-	0xe9, 0x9a, 0x08, 0xaf, 0x8a, 0x4a, 0x7b, 0x28, 0x41, 0xd6, 0x35, 0x5e, 0x38, 0xeb, 0x6d, 0x7c,
-	0x0e, 0x8c, 0xe3, 0x00, 0xe2, 0x5a, 0x38, 0x38, 0x93, 0x56, 0xda, 0x40, 0x90, 0x1f, 0xb5, 0x87,
-	0x07, 0x7d, 0x69, 0xad, 0xd4, 0x82, 0x04, 0xc7, 0xaa, 0x9c, 0x50, 0xb3, 0xde, 0x47, 0xa3, 0xb7,
-	0x08, 0x9d, 0xce, 0x9c, 0x5d, 0x0a, 0xf7, 0x60, 0x4d, 0x0e, 0x32, 0x1e, 0xa2, 0x5e, 0x06, 0x4b,
-	0xe1, 0x4a, 0xc8, 0x41, 0xb8, 0x24, 0x1a, 0x46, 0xe3, 0x93, 0x79, 0xfd, 0x29, 0xbe, 0x45, 0x9d,
-	0x15, 0xd5, 0x5a, 0xf8, 0x32, 0x69, 0x0e, 0x5b, 0xe3, 0xde, 0xf5, 0x25, 0xfe, 0xb7, 0x0c, 0x9e,
-	0x3e, 0x3e, 0x05, 0x6e, 0x7e, 0xe4, 0xe3, 0x0b, 0xd4, 0x5e, 0x38, 0x91, 0xc3, 0x6b, 0xd2, 0x0a,
-	0xff, 0x1e, 0xdc, 0xe8, 0x0e, 0x75, 0x8f, 0x70, 0x3c, 0x40, 0xdd, 0xc2, 0x88, 0xc2, 0x1a, 0xe0,
-	0x87, 0xe9, 0xbf, 0x3e, 0xee, 0xa3, 0xae, 0xca, 0x56, 0x2f, 0x0b, 0xea, 0x55, 0xd2, 0x0c, 0x59,
-	0x47, 0x65, 0xab, 0x19, 0xf5, 0xea, 0x9e, 0x7d, 0x6c, 0xd3, 0x68, 0xb3, 0x4d, 0xa3, 0xaf, 0x6d,
-	0x1a, 0xbd, 0xef, 0xd2, 0xc6, 0x66, 0x97, 0x36, 0x3e, 0x77, 0x69, 0xe3, 0x79, 0x2a, 0xc1, 0xab,
-	0x8a, 0x61, 0x6e, 0x0b, 0x92, 0x51, 0x4f, 0xb9, 0xa2, 0x60, 0x34, 0x65, 0x04, 0x18, 0x9f, 0xd4,
-	0xfa, 0x4e, 0xb8, 0x06, 0x61, 0x3c, 0x29, 0x6c, 0x56, 0x69, 0x51, 0xfe, 0x3d, 0x03, 0x6b, 0x87,
-	0x9d, 0xdd, 0x7c, 0x07, 0x00, 0x00, 0xff, 0xff, 0x73, 0xb9, 0xcf, 0x8e, 0xa6, 0x01, 0x00, 0x00,
+	// 611 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0x86, 0xeb, 0x26, 0xcd, 0x65, 0x92, 0x14, 0x34, 0xa2, 0xd4, 0x2d, 0x28, 0x35, 0xad, 0x10,
+	0xd9, 0x34, 0x96, 0x60, 0xc5, 0x12, 0xa8, 0x50, 0xd5, 0x05, 0xaa, 0x5c, 0x89, 0x4a, 0xb0, 0xb0,
+	0xc6, 0xf6, 0x89, 0x7d, 0x54, 0x7b, 0x26, 0xcc, 0x8c, 0x93, 0xe6, 0x21, 0x90, 0x78, 0x1f, 0x5e,
+	0x80, 0x65, 0x97, 0x2c, 0x51, 0xf3, 0x22, 0xc8, 0xe3, 0x38, 0xb5, 0xb8, 0x48, 0xdd, 0xf9, 0xbf,
+	0xe4, 0xd3, 0xf1, 0xcc, 0x89, 0xc9, 0x0b, 0x0c, 0x42, 0x57, 0x42, 0xca, 0x16, 0x2e, 0xe8, 0x24,
+	0xcb, 0x53, 0x8d, 0x0a, 0xe3, 0xfa, 0xf3, 0x78, 0x2a, 0x85, 0x16, 0x74, 0x07, 0x83, 0x70, 0x6c,
+	0x8a, 0xe3, 0x5a, 0xb8, 0xff, 0x28, 0x16, 0xb1, 0x30, 0x0d, 0xb7, 0x78, 0x2a, 0xcb, 0xfb, 0x7b,
+	0xb1, 0x10, 0x71, 0x0a, 0xae, 0x51, 0x41, 0x3e, 0x71, 0x19, 0x5f, 0x94, 0xd1, 0xe1, 0xf7, 0x26,
+	0xe9, 0x9f, 0x4b, 0x31, 0x03, 0xf9, 0x4e, 0xf0, 0x09, 0xc6, 0xd4, 0x21, 0xbd, 0x08, 0x67, 0x20,
+	0x15, 0x4e, 0x10, 0xa4, 0x6d, 0x39, 0xd6, 0xa8, 0xeb, 0xd5, 0x2d, 0xfa, 0x9a, 0xb4, 0xe7, 0x2c,
+	0x4d, 0x41, 0x2b, 0x7b, 0xd3, 0x69, 0x8c, 0x7a, 0x2f, 0x0f, 0xc6, 0xff, 0x1c, 0x66, 0x7c, 0x7a,
+	0x72, 0x69, 0x7a, 0x5e, 0xd5, 0xa7, 0x8f, 0x49, 0x6b, 0x2a, 0x61, 0x82, 0xd7, 0x76, 0xc3, 0x70,
+	0x57, 0x8a, 0x3e, 0x25, 0x5d, 0x9d, 0x48, 0x50, 0x89, 0x48, 0x23, 0xbb, 0xe9, 0x58, 0xa3, 0xa6,
+	0x77, 0x67, 0x14, 0x29, 0x8b, 0x22, 0x09, 0x4a, 0x81, 0xb2, 0xb7, 0x9c, 0xc6, 0xa8, 0xeb, 0xdd,
+	0x19, 0x86, 0x29, 0xe6, 0x20, 0x95, 0xdd, 0x72, 0x1a, 0xa3, 0xa6, 0xb7, 0x52, 0xf4, 0x88, 0x0c,
+	0xa6, 0x52, 0x88, 0x89, 0x6f, 0x26, 0x17, 0xdc, 0x6e, 0x3b, 0xd6, 0x68, 0xcb, 0xeb, 0x1b, 0xf3,
+	0x63, 0xe9, 0xd1, 0xe7, 0x64, 0x5b, 0xc1, 0x97, 0x1c, 0x78, 0x08, 0xbe, 0xd2, 0x42, 0x82, 0xdd,
+	0x31, 0x83, 0x0d, 0x2a, 0xf7, 0xa2, 0x30, 0xe9, 0x33, 0xd2, 0x5f, 0xd7, 0x22, 0x94, 0x76, 0xb7,
+	0x3c, 0x95, 0xca, 0x3b, 0x41, 0x49, 0xcf, 0xc8, 0xc3, 0xa9, 0x84, 0x19, 0x8a, 0x5c, 0xf9, 0xd5,
+	0xf1, 0x90, 0xfb, 0x1d, 0xcf, 0x83, 0xea, 0x87, 0x97, 0xab, 0x63, 0x3a, 0x23, 0xdb, 0x12, 0x32,
+	0xa1, 0xc1, 0x57, 0x18, 0xf3, 0xe2, 0xd5, 0x7a, 0x86, 0x74, 0xf4, 0x1f, 0x92, 0x67, 0xca, 0x17,
+	0xa6, 0xeb, 0x0d, 0x64, 0x4d, 0x29, 0xfa, 0x99, 0xec, 0xae, 0xe7, 0xfa, 0x03, 0xda, 0xbf, 0x3f,
+	0x74, 0xa7, 0x62, 0xd4, 0x5d, 0x75, 0xf8, 0x86, 0x74, 0xaa, 0xb7, 0xa0, 0xfb, 0xa4, 0x93, 0x71,
+	0xc8, 0x04, 0xc7, 0x70, 0xb5, 0x35, 0x6b, 0x4d, 0xf7, 0x48, 0x27, 0x89, 0xe6, 0xfe, 0x94, 0xe9,
+	0xc4, 0xde, 0x34, 0x59, 0x3b, 0x89, 0xe6, 0xe7, 0x4c, 0x27, 0x87, 0x5f, 0x37, 0x49, 0xbf, 0x0e,
+	0x2d, 0x38, 0xc0, 0xa3, 0xa9, 0x40, 0xae, 0x2b, 0x4e, 0xa5, 0xa9, 0x4d, 0xda, 0xab, 0x8b, 0xaf,
+	0x30, 0x2b, 0x49, 0x77, 0x49, 0x3b, 0x64, 0xfe, 0x04, 0x53, 0xa8, 0x56, 0x2b, 0x64, 0xef, 0x31,
+	0x05, 0xfa, 0x84, 0x74, 0x43, 0x90, 0xba, 0x8c, 0x9a, 0x25, 0xaf, 0x30, 0x4c, 0xb8, 0x47, 0x3a,
+	0x57, 0xb0, 0x28, 0xb3, 0xad, 0x12, 0x78, 0x05, 0x0b, 0x13, 0x1d, 0x90, 0x9e, 0x02, 0x39, 0x03,
+	0xe9, 0x73, 0x96, 0x81, 0xdd, 0x32, 0x29, 0x29, 0xad, 0x0f, 0x2c, 0x83, 0x62, 0x4e, 0xe4, 0x0a,
+	0xc2, 0x5c, 0x82, 0x59, 0xad, 0x8e, 0xb7, 0xd6, 0xc5, 0x9c, 0x1a, 0x33, 0x10, 0xb9, 0x36, 0xfb,
+	0xd4, 0xf4, 0x2a, 0x59, 0x60, 0x33, 0x76, 0xed, 0x4b, 0xd0, 0x12, 0x41, 0x99, 0x45, 0x1a, 0x78,
+	0x24, 0x63, 0xd7, 0x5e, 0xe9, 0xbc, 0x0d, 0x7e, 0xdc, 0x0e, 0xad, 0x9b, 0xdb, 0xa1, 0xf5, 0xeb,
+	0x76, 0x68, 0x7d, 0x5b, 0x0e, 0x37, 0x6e, 0x96, 0xc3, 0x8d, 0x9f, 0xcb, 0xe1, 0xc6, 0xa7, 0xd3,
+	0x18, 0x75, 0x92, 0x07, 0xe3, 0x50, 0x64, 0x6e, 0xc4, 0x34, 0x0b, 0x13, 0x86, 0x3c, 0x65, 0x81,
+	0x8b, 0x41, 0x78, 0x5c, 0xbb, 0xb9, 0xe3, 0x30, 0x45, 0xe0, 0xda, 0xcd, 0x44, 0x94, 0xa7, 0xa0,
+	0xfe, 0xfe, 0x9c, 0x04, 0x2d, 0xf3, 0xdf, 0x7f, 0xf5, 0x7b, 0x00, 0x2a, 0x7d, 0xf8, 0x8b, 0x6e,
+	0x04, 0x00, 0x00,
 }
 
 func (m *ProverConfig) Marshal() (dAtA []byte, err error) {
@@ -188,6 +409,99 @@ func (m *ProverConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.PreviousRemoteSigners) > 0 {
+		for iNdEx := len(m.PreviousRemoteSigners) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PreviousRemoteSigners[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintEthmultisig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if len(m.RemoteSigners) > 0 {
+		for iNdEx := len(m.RemoteSigners) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RemoteSigners[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintEthmultisig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.PreviousWallets) > 0 {
+		for iNdEx := len(m.PreviousWallets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PreviousWallets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintEthmultisig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.SequenceDir) > 0 {
+		i -= len(m.SequenceDir)
+		copy(dAtA[i:], m.SequenceDir)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.SequenceDir)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.SequenceStore) > 0 {
+		i -= len(m.SequenceStore)
+		copy(dAtA[i:], m.SequenceStore)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.SequenceStore)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.ProofVersion != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.ProofVersion))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.Powers) > 0 {
+		dAtA2 := make([]byte, len(m.Powers)*10)
+		var j1 int
+		for _, num := range m.Powers {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintEthmultisig(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Addresses) > 0 {
+		for iNdEx := len(m.Addresses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addresses[iNdEx])
+			copy(dAtA[i:], m.Addresses[iNdEx])
+			i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Addresses[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Threshold != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.Threshold))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Prefix) > 0 {
 		i -= len(m.Prefix)
 		copy(dAtA[i:], m.Prefix)
@@ -256,6 +570,91 @@ func (m *HDWallet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RemoteSigner) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoteSigner) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoteSigner) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxRetries != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.MaxRetries))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Timeout != 0 {
+		i = encodeVarintEthmultisig(dAtA, i, uint64(m.Timeout))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Insecure {
+		i--
+		if m.Insecure {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.ServerName) > 0 {
+		i -= len(m.ServerName)
+		copy(dAtA[i:], m.ServerName)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.ServerName)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.KeyFile) > 0 {
+		i -= len(m.KeyFile)
+		copy(dAtA[i:], m.KeyFile)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.KeyFile)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.CertFile) > 0 {
+		i -= len(m.CertFile)
+		copy(dAtA[i:], m.CertFile)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.CertFile)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.CaFile) > 0 {
+		i -= len(m.CaFile)
+		copy(dAtA[i:], m.CaFile)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.CaFile)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Endpoint) > 0 {
+		i -= len(m.Endpoint)
+		copy(dAtA[i:], m.Endpoint)
+		i = encodeVarintEthmultisig(dAtA, i, uint64(len(m.Endpoint)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintEthmultisig(dAtA []byte, offset int, v uint64) int {
 	offset -= sovEthmultisig(v)
 	base := offset
@@ -287,6 +686,51 @@ func (m *ProverConfig) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovEthmultisig(uint64(l))
 	}
+	if m.Threshold != 0 {
+		n += 1 + sovEthmultisig(uint64(m.Threshold))
+	}
+	if len(m.Addresses) > 0 {
+		for _, s := range m.Addresses {
+			l = len(s)
+			n += 1 + l + sovEthmultisig(uint64(l))
+		}
+	}
+	if len(m.Powers) > 0 {
+		l = 0
+		for _, e := range m.Powers {
+			l += sovEthmultisig(uint64(e))
+		}
+		n += 1 + sovEthmultisig(uint64(l)) + l
+	}
+	if m.ProofVersion != 0 {
+		n += 1 + sovEthmultisig(uint64(m.ProofVersion))
+	}
+	l = len(m.SequenceStore)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	l = len(m.SequenceDir)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	if len(m.PreviousWallets) > 0 {
+		for _, e := range m.PreviousWallets {
+			l = e.Size()
+			n += 1 + l + sovEthmultisig(uint64(l))
+		}
+	}
+	if len(m.RemoteSigners) > 0 {
+		for _, e := range m.RemoteSigners {
+			l = e.Size()
+			n += 1 + l + sovEthmultisig(uint64(l))
+		}
+	}
+	if len(m.PreviousRemoteSigners) > 0 {
+		for _, e := range m.PreviousRemoteSigners {
+			l = e.Size()
+			n += 1 + l + sovEthmultisig(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -307,6 +751,48 @@ func (m *HDWallet) Size() (n int) {
 	return n
 }
 
+func (m *RemoteSigner) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	l = len(m.CaFile)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	l = len(m.CertFile)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	l = len(m.KeyFile)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	l = len(m.ServerName)
+	if l > 0 {
+		n += 1 + l + sovEthmultisig(uint64(l))
+	}
+	if m.Insecure {
+		n += 2
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovEthmultisig(uint64(m.Timeout))
+	}
+	if m.MaxRetries != 0 {
+		n += 1 + sovEthmultisig(uint64(m.MaxRetries))
+	}
+	return n
+}
+
 func sovEthmultisig(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -440,14 +926,326 @@ func (m *ProverConfig) Unmarshal(dAtA []byte) error {
 			}
 			m.Prefix = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEthmultisig(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Threshold", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthEthmultisig
+			m.Threshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Threshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addresses = append(m.Addresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEthmultisig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Powers = append(m.Powers, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEthmultisig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthEthmultisig
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthEthmultisig
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Powers) == 0 {
+					m.Powers = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEthmultisig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Powers = append(m.Powers, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Powers", wireType)
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofVersion", wireType)
+			}
+			m.ProofVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProofVersion |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceStore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SequenceStore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceDir", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SequenceDir = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousWallets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousWallets = append(m.PreviousWallets, &HDWallet{})
+			if err := m.PreviousWallets[len(m.PreviousWallets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoteSigners", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemoteSigners = append(m.RemoteSigners, &RemoteSigner{})
+			if err := m.RemoteSigners[len(m.RemoteSigners)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousRemoteSigners", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousRemoteSigners = append(m.PreviousRemoteSigners, &RemoteSigner{})
+			if err := m.PreviousRemoteSigners[len(m.PreviousRemoteSigners)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEthmultisig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEthmultisig
 			}
 			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
@@ -575,6 +1373,306 @@ func (m *HDWallet) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RemoteSigner) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEthmultisig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoteSigner: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoteSigner: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CaFile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CaFile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CertFile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CertFile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyFile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyFile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Insecure", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Insecure = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			m.Timeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timeout |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRetries", wireType)
+			}
+			m.MaxRetries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEthmultisig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxRetries |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEthmultisig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEthmultisig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipEthmultisig(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
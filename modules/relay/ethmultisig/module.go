@@ -22,5 +22,5 @@ func (m Module) RegisterInterfaces(registry codectypes.InterfaceRegistry) {
 
 // GetCmd returns the command
 func (m Module) GetCmd(ctx *config.Context) *cobra.Command {
-	return nil
+	return ethmultisigCmd()
 }
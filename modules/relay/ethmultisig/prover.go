@@ -1,8 +1,12 @@
 package ethmultisig
 
 import (
-	"crypto/ecdsa"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -11,17 +15,21 @@ import (
 	conntypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
 	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
 	ibcexported "github.com/cosmos/ibc-go/modules/core/exported"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/ibc/client"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/hyperledger-labs/yui-relayer/core"
 
 	ethmultisigclient "github.com/datachainlab/ibc-ethmultisig-client/modules/light-clients/xx-ethmultisig/types"
-	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig/wallet"
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig/sequence"
 )
 
 var _ core.ProverConfigI = (*ProverConfig)(nil)
 
+// ErrHeightNotAvailable is returned if the state of the chain at the requested height cannot be queried
+var ErrHeightNotAvailable = errors.New("height is not available")
+
 func (pr ProverConfig) Build(chain core.ChainI) (core.ProverI, error) {
 	return NewProver(pr, chain)
 }
@@ -30,25 +38,148 @@ type Prover struct {
 	chain core.ChainI
 
 	diversifier string
+	threshold   uint64
+	powers      []uint64
 	multisig    ETHMultisig
+	// headerSigners are the signers and the previous signers, which sign the headers
+	headerSigners []Signer
+
+	// sequences keeps the sequence of the latest signed proof
+	sequences sequence.Store
+	// sequenceStore returns the sequence store of the signatures with the diversifier
+	sequenceStore func(diversifier string) (sequence.Store, error)
+
+	mu sync.Mutex
+	// handshake keeps the proofs of the latest handshake batch that have not been returned yet
+	handshake handshakeProofs
+}
+
+// handshakeProofs are the proofs of the client state and the consensus state that have been signed
+// in a batch with the connection state at a height. A connection handshake message carries the proofs
+// of the three states at one proof height, and the relayer queries the client state and the consensus state
+// after the connection at the same height, so they are returned from the batch instead of being signed again.
+type handshakeProofs struct {
+	height          int64
+	clientState     *clienttypes.QueryClientStateResponse
+	consensusHeight ibcexported.Height
+	consensusState  *clienttypes.QueryConsensusStateResponse
 }
 
 var _ core.ProverI = (*Prover)(nil)
 
+// NewProver returns a prover whose signers are the HD wallets and the remote signers of the config
 func NewProver(pr ProverConfig, chain core.ChainI) (*Prover, error) {
-	if len(pr.Wallets) == 0 {
-		return nil, fmt.Errorf("at least one wallet is needed")
+	if len(pr.Wallets) == 0 && len(pr.RemoteSigners) == 0 {
+		return nil, fmt.Errorf("at least one wallet or remote signer is needed")
+	}
+	signers, err := loadSigners(pr.Wallets, pr.RemoteSigners)
+	if err != nil {
+		return nil, err
+	}
+	previousSigners, err := loadSigners(pr.PreviousWallets, pr.PreviousRemoteSigners)
+	if err != nil {
+		return nil, err
+	}
+	return NewProverWithSigners(pr, chain, signers, previousSigners)
+}
+
+// NewProverWithSigners returns a prover with the given signers instead of the wallets of the config.
+// The previous signers sign the headers that rotate the client on the counterparty chain as the previous wallets do.
+func NewProverWithSigners(pr ProverConfig, chain core.ChainI, signers []Signer, previousSigners []Signer) (*Prover, error) {
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("at least one signer is needed")
+	}
+	multisig := NewETHMultisig(chain.Codec(), pr.Diversifier, signers, []byte(pr.Prefix))
+	var err error
+	if len(pr.Addresses) > 0 {
+		var signers []common.Address
+		for _, addr := range pr.Addresses {
+			if !common.IsHexAddress(addr) {
+				return nil, fmt.Errorf("invalid address: %v", addr)
+			}
+			signers = append(signers, common.HexToAddress(addr))
+		}
+		multisig, err = multisig.WithSignerSet(signers)
+		if err != nil {
+			return nil, err
+		}
+		multisig, err = multisig.WithThreshold(pr.Powers, pr.Threshold)
+		if err != nil {
+			return nil, err
+		}
+	}
+	multisig, err = multisig.WithProofVersion(ethmultisigclient.MultiSignature_Version(pr.ProofVersion))
+	if err != nil {
+		return nil, err
+	}
+	if len(pr.Powers) > 0 && len(pr.Powers) != len(multisig.SignerAddresses()) {
+		return nil, fmt.Errorf("the number of powers must equal the number of signers: %v != %v", len(pr.Powers), len(multisig.SignerAddresses()))
 	}
-	var keys []*ecdsa.PrivateKey
-	for _, w := range pr.Wallets {
-		prv, err := wallet.GetPrvKeyFromMnemonicAndHDWPath(w.Mnemonic, w.HdwPath)
+	sequenceStore := func(diversifier string) (sequence.Store, error) {
+		return newSequenceStore(pr, chain.ChainID(), diversifier)
+	}
+	sequences, err := sequenceStore(pr.Diversifier)
+	if err != nil {
+		return nil, err
+	}
+	return &Prover{
+		chain:         chain,
+		diversifier:   pr.Diversifier,
+		threshold:     pr.Threshold,
+		powers:        pr.Powers,
+		multisig:      multisig,
+		headerSigners: uniqueSigners(signers, previousSigners),
+		sequences:     sequences,
+		sequenceStore: sequenceStore,
+	}, nil
+}
+
+func loadSigners(wallets []*HDWallet, remoteSigners []*RemoteSigner) ([]Signer, error) {
+	var signers []Signer
+	for _, w := range wallets {
+		signer, err := NewHDWalletSigner(w.Mnemonic, w.HdwPath)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, signer)
+	}
+	for _, rs := range remoteSigners {
+		signer, err := rs.Dial()
 		if err != nil {
 			return nil, err
 		}
-		keys = append(keys, prv)
+		signers = append(signers, signer)
 	}
-	multisig := NewETHMultisig(chain.Codec(), pr.Diversifier, keys, []byte(pr.Prefix))
-	return &Prover{chain: chain, diversifier: pr.Diversifier, multisig: multisig}, nil
+	return signers, nil
+}
+
+// uniqueSigners concatenates the lists of signers and removes the signers of the same address but the first one
+func uniqueSigners(lists ...[]Signer) []Signer {
+	var unique []Signer
+	seen := make(map[common.Address]bool)
+	for _, signers := range lists {
+		for _, signer := range signers {
+			if addr := signer.Address(); !seen[addr] {
+				seen[addr] = true
+				unique = append(unique, signer)
+			}
+		}
+	}
+	return unique
+}
+
+// newSequenceStore returns the sequence store of the signatures with the diversifier.
+// The provers of the same chain and diversifier share the sequence, so they never sign a proof with the same sequence.
+func newSequenceStore(pr ProverConfig, chainID string, diversifier string) (sequence.Store, error) {
+	dir := pr.SequenceDir
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(home, ".urelayer", "ethmultisig")
+	}
+	return sequence.NewStore(pr.SequenceStore, dir, chainID+"/"+diversifier)
 }
 
 // GetChainID returns the chain ID
@@ -56,20 +187,40 @@ func (pr *Prover) GetChainID() string {
 	return pr.chain.ChainID()
 }
 
-// QueryLatestHeader returns the latest header from the chain
+// QueryLatestHeader returns an unsigned header at the latest sequence that asserts the configured signer set.
+// The headers that update the client are signed only by SetupHeader, since a signed header uses up its sequence.
 func (pr *Prover) QueryLatestHeader() (out core.HeaderI, err error) {
-	return &ethmultisigclient.Header{Height: client.Height{RevisionNumber: 0, RevisionHeight: 1}}, nil
+	height, err := pr.GetHeight()
+	if err != nil {
+		return nil, err
+	}
+	var addresses [][]byte
+	for _, addr := range pr.multisig.SignerAddresses() {
+		addresses = append(addresses, addr.Bytes())
+	}
+	return &ethmultisigclient.Header{
+		Height:         client.Height{RevisionNumber: height.RevisionNumber, RevisionHeight: height.RevisionHeight},
+		Timestamp:      pr.multisig.GetCurrentTimestamp(),
+		NewAddresses:   addresses,
+		NewDiversifier: pr.diversifier,
+		NewThreshold:   pr.threshold,
+		NewPowers:      pr.powers,
+	}, nil
 }
 
-// GetLatestLightHeight returns the latest height on the light client
+// GetLatestLightHeight returns the latest sequence, which is the height of the latest header
 func (pr *Prover) GetLatestLightHeight() (int64, error) {
-	return 1, nil
+	seq, err := pr.GetSequeunce()
+	if err != nil {
+		return 0, err
+	}
+	return int64(seq), nil
 }
 
 // CreateMsgCreateClient creates a CreateClientMsg to this chain
 func (pr *Prover) CreateMsgCreateClient(clientID string, dstHeader core.HeaderI, signer sdk.AccAddress) (*clienttypes.MsgCreateClient, error) {
 	var addresses [][]byte
-	for _, addr := range pr.multisig.Addresses() {
+	for _, addr := range pr.multisig.SignerAddresses() {
 		addresses = append(addresses, addr.Bytes())
 	}
 	clientState := &ethmultisigclient.ClientState{
@@ -79,104 +230,337 @@ func (pr *Prover) CreateMsgCreateClient(clientID string, dstHeader core.HeaderI,
 		Addresses:   addresses,
 		Diversifier: pr.diversifier,
 		Timestamp:   uint64(time.Now().UnixNano()),
+		Threshold:   pr.threshold,
+		Powers:      pr.powers,
+	}
+	if err := clientState.Validate(); err != nil {
+		return nil, err
+	}
+	if err := clientState.ValidateInitial(); err != nil {
+		return nil, err
+	}
+	if err := consensusState.ValidateBasic(); err != nil {
+		return nil, err
 	}
 	return clienttypes.NewMsgCreateClient(clientState, consensusState, signer.String())
 }
 
-// SetupHeader creates a new header based on a given header
+// SetupHeader returns a header that updates the client on the counterparty chain to the configured signer set.
+// The header is signed by the signers of the prover that are in the signer set of the latest consensus state of the client
+// with its diversifier, so the client is rotated to the configured signer set and diversifier once the config changes.
+// The header height is a new sequence, so the proofs signed after the header are verified with the configured signer set.
+// It returns nil if the client already has the configured signer set and does not expire soon, so no update is needed.
 func (pr *Prover) SetupHeader(dst core.LightClientIBCQueryierI, baseSrcHeader core.HeaderI) (core.HeaderI, error) {
-	return nil, nil
+	clientState, consensusState, err := pr.queryCounterpartyClient(dst)
+	if err != nil {
+		return nil, err
+	}
+	if !pr.signerSetChanged(consensusState) && !expiresSoon(clientState, consensusState, time.Now()) {
+		return nil, nil
+	}
+	signer, err := pr.headerSigner(consensusState)
+	if err != nil {
+		return nil, err
+	}
+	height, err := pr.nextHeaderHeight(clientState, consensusState.Diversifier)
+	if err != nil {
+		return nil, err
+	}
+	header, signBytes, err := signer.SignWeightedHeader(height, pr.multisig.SignerAddresses(), pr.powers, pr.threshold, pr.diversifier)
+	if err != nil {
+		return nil, err
+	}
+	if err := consensusState.VerifySignature(header.Signature, signBytes); err != nil {
+		return nil, fmt.Errorf("the signers of the prover cannot sign a header for the signer set of the client: %w", err)
+	}
+	return header, nil
 }
 
-// UpdateLightWithHeader updates a header on the light client and returns the header and height corresponding to the chain
+// UpdateLightWithHeader returns the latest header and the latest height of the chain as the provable and queryable height.
+// The prover signs the state of the chain at any height that the chain can query, so both heights are the latest one.
 func (pr *Prover) UpdateLightWithHeader() (header core.HeaderI, provableHeight int64, queryableHeight int64, err error) {
 	h, err := pr.QueryLatestHeader()
 	if err != nil {
 		return nil, 0, 0, err
 	}
-	return h, 0, 0, nil
+	height, err := pr.chain.GetLatestHeight()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return h, height, height, nil
+}
+
+// signerSetChanged returns true if the signer set, the voting powers, the threshold or the diversifier of the config
+// differ from the ones of the consensus state
+func (pr *Prover) signerSetChanged(consensusState *ethmultisigclient.ConsensusState) bool {
+	var addresses [][]byte
+	for _, addr := range pr.multisig.SignerAddresses() {
+		addresses = append(addresses, addr.Bytes())
+	}
+	configured := &ethmultisigclient.ConsensusState{Addresses: addresses, Threshold: pr.threshold, Powers: pr.powers}
+	return consensusState.Diversifier != pr.diversifier ||
+		!reflect.DeepEqual(consensusState.GetAddresses(), configured.GetAddresses()) ||
+		!reflect.DeepEqual(consensusState.GetPowers(), configured.GetPowers()) ||
+		consensusState.GetThreshold() != configured.GetThreshold()
 }
 
-/* Query functions: Prover queries the latest state of the chain and signs it */
+// expiresSoon returns true if half of the trusting period of the client has passed since the latest consensus state,
+// so the client needs a new consensus state to keep it from expiring even if the signer set is unchanged
+func expiresSoon(clientState ibcexported.ClientState, consensusState *ethmultisigclient.ConsensusState, now time.Time) bool {
+	cs, ok := clientState.(*ethmultisigclient.ClientState)
+	if !ok || cs.TrustingPeriod == 0 {
+		return false
+	}
+	halfway := time.Unix(0, int64(consensusState.Timestamp)).Add(time.Duration(cs.TrustingPeriod / 2))
+	return !now.Before(halfway)
+}
 
-// QueryClientConsensusState returns the ClientConsensusState and its proof
-func (pr *Prover) QueryClientConsensusStateWithProof(_ int64, dstClientConsHeight ibcexported.Height) (*clienttypes.QueryConsensusStateResponse, error) {
-	h, err := pr.chain.GetLatestHeight()
+// queryCounterpartyClient returns the client state and the latest consensus state of the client on the counterparty chain
+func (pr *Prover) queryCounterpartyClient(dst core.LightClientIBCQueryierI) (ibcexported.ClientState, *ethmultisigclient.ConsensusState, error) {
+	dstHeight, err := dst.GetLatestLightHeight()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	res, err := pr.chain.QueryClientConsensusState(h, dstClientConsHeight)
+	csRes, err := dst.QueryClientState(dstHeight)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return pr.SignConsensusStateResponse(res, pr.chain.Path().ClientID, dstClientConsHeight)
+	clientState, err := clienttypes.UnpackClientState(csRes.ClientState)
+	if err != nil {
+		return nil, nil, err
+	}
+	consRes, err := dst.QueryClientConsensusState(dstHeight, clientState.GetLatestHeight())
+	if err != nil {
+		return nil, nil, err
+	}
+	consensusState, err := clienttypes.UnpackConsensusState(consRes.ConsensusState)
+	if err != nil {
+		return nil, nil, err
+	}
+	cons, ok := consensusState.(*ethmultisigclient.ConsensusState)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected consensus state type: %T", consensusState)
+	}
+	return clientState, cons, nil
+}
+
+// headerSigner returns a multisig of the signers of the prover that are in the signer set of the consensus state
+func (pr *Prover) headerSigner(consensusState *ethmultisigclient.ConsensusState) (ETHMultisig, error) {
+	signerSet := consensusState.GetAddresses()
+	var signers []Signer
+	for _, signer := range pr.headerSigners {
+		if signerIndex(signerSet, signer.Address()) >= 0 {
+			signers = append(signers, signer)
+		}
+	}
+	if len(signers) == 0 {
+		return ETHMultisig{}, fmt.Errorf("the prover has no signer of the signer set of the client: %v", signerSet)
+	}
+	m := pr.multisig
+	m.cdc = pr.chain.Codec()
+	m.diversifier = consensusState.Diversifier
+	m.signers = signers
+	m, err := m.WithSignerSet(signerSet)
+	if err != nil {
+		return ETHMultisig{}, err
+	}
+	return m.WithThreshold(consensusState.Powers, consensusState.GetThreshold())
 }
 
-// QueryClientStateWithProof returns the ClientState and its proof
-func (pr *Prover) QueryClientStateWithProof(_ int64) (*clienttypes.QueryClientStateResponse, error) {
-	h, err := pr.chain.GetLatestHeight()
+// nextHeaderHeight returns a new sequence for a header, which is greater than the latest height
+// and the sequence of the latest verified proof of the client.
+// The header is signed with the diversifier of the client, so the sequence is taken from the sequence store
+// of the diversifier as well as the one of the config, and no other proof is signed with the sequence.
+func (pr *Prover) nextHeaderHeight(clientState ibcexported.ClientState, diversifier string) (clienttypes.Height, error) {
+	min := clientState.GetLatestHeight().GetRevisionHeight() + 1
+	if cs, ok := clientState.(*ethmultisigclient.ClientState); ok && cs.Sequence >= min {
+		min = cs.Sequence + 1
+	}
+	next := func(state sequence.State) (sequence.State, error) {
+		state.Sequence++
+		if state.Sequence < min {
+			state.Sequence = min
+		}
+		return state, nil
+	}
+	state, err := pr.sequences.Update(next)
 	if err != nil {
+		return clienttypes.Height{}, err
+	}
+	if diversifier != pr.diversifier {
+		store, err := pr.sequenceStore(diversifier)
+		if err != nil {
+			return clienttypes.Height{}, err
+		}
+		min = state.Sequence
+		if state, err = store.Update(next); err != nil {
+			return clienttypes.Height{}, err
+		}
+		// the proofs of the config are signed with the sequences after the header
+		header := state.Sequence
+		if _, err := pr.sequences.Update(func(state sequence.State) (sequence.State, error) {
+			if state.Sequence < header {
+				state.Sequence = header
+			}
+			return state, nil
+		}); err != nil {
+			return clienttypes.Height{}, err
+		}
+	}
+	return clienttypes.NewHeight(0, state.Sequence), nil
+}
+
+/* Query functions: Prover queries the state of the chain at the requested height and signs it */
+
+// QueryClientConsensusState returns the ClientConsensusState at the height and its proof.
+// The proof signed in a batch with the connection at the height is returned if it has not been returned yet.
+func (pr *Prover) QueryClientConsensusStateWithProof(height int64, dstClientConsHeight ibcexported.Height) (*clienttypes.QueryConsensusStateResponse, error) {
+	if err := pr.checkQueryHeight(height); err != nil {
 		return nil, err
 	}
-	res, err := pr.chain.QueryClientState(h)
+	if res := pr.takeHandshakeConsensusState(height, dstClientConsHeight); res != nil {
+		return res, nil
+	}
+	res, err := pr.chain.QueryClientConsensusState(height, dstClientConsHeight)
 	if err != nil {
+		return nil, fmt.Errorf("failed to query the client consensus state at height %v: %w", height, err)
+	}
+	return pr.SignConsensusStateResponse(res, pr.chain.Path().ClientID, dstClientConsHeight)
+}
+
+// QueryClientStateWithProof returns the ClientState at the height and its proof.
+// The proof signed in a batch with the connection at the height is returned if it has not been returned yet.
+func (pr *Prover) QueryClientStateWithProof(height int64) (*clienttypes.QueryClientStateResponse, error) {
+	if err := pr.checkQueryHeight(height); err != nil {
 		return nil, err
 	}
+	if res := pr.takeHandshakeClientState(height); res != nil {
+		return res, nil
+	}
+	res, err := pr.chain.QueryClientState(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the client state at height %v: %w", height, err)
+	}
 	return pr.SignClientStateResponse(res, pr.chain.Path().ClientID)
 }
 
-// QueryConnectionWithProof returns the Connection and its proof
-func (pr *Prover) QueryConnectionWithProof(_ int64) (*conntypes.QueryConnectionResponse, error) {
-	h, err := pr.chain.GetLatestHeight()
+// QueryConnectionWithProof returns the Connection at the height and its proof.
+// The connection is signed in a batch with the client state and its latest consensus state at the height,
+// whose proofs are returned by the next queries of them at the height, so that the proofs of a connection
+// handshake message share the proof height without sharing the sequence with other signed data.
+func (pr *Prover) QueryConnectionWithProof(height int64) (*conntypes.QueryConnectionResponse, error) {
+	if err := pr.checkQueryHeight(height); err != nil {
+		return nil, err
+	}
+	res, err := pr.chain.QueryConnection(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the connection at height %v: %w", height, err)
+	}
+	csRes, err := pr.chain.QueryClientState(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the client state at height %v: %w", height, err)
+	}
+	clientState, err := clienttypes.UnpackClientState(csRes.ClientState)
 	if err != nil {
 		return nil, err
 	}
-	res, err := pr.chain.QueryConnection(h)
+	consRes, err := pr.chain.QueryClientConsensusState(height, clientState.GetLatestHeight())
 	if err != nil {
+		return nil, fmt.Errorf("failed to query the client consensus state at height %v: %w", height, err)
+	}
+	if err := pr.SignHandshakeResponses(res, csRes, consRes, pr.chain.Path().ConnectionID, pr.chain.Path().ClientID); err != nil {
 		return nil, err
 	}
-	return pr.SignConnectionStateResponse(res, pr.chain.Path().ConnectionID)
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.handshake = handshakeProofs{
+		height:          height,
+		clientState:     csRes,
+		consensusHeight: clientState.GetLatestHeight(),
+		consensusState:  consRes,
+	}
+	return res, nil
 }
 
-// QueryChannelWithProof returns the Channel and its proof
-func (pr *Prover) QueryChannelWithProof(_ int64) (*chantypes.QueryChannelResponse, error) {
-	h, err := pr.chain.GetLatestHeight()
-	if err != nil {
+// takeHandshakeClientState returns the client state proof of the handshake batch at the height only once
+func (pr *Prover) takeHandshakeClientState(height int64) *clienttypes.QueryClientStateResponse {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if pr.handshake.height != height {
+		return nil
+	}
+	res := pr.handshake.clientState
+	pr.handshake.clientState = nil
+	return res
+}
+
+// takeHandshakeConsensusState returns the consensus state proof of the handshake batch at the height only once
+func (pr *Prover) takeHandshakeConsensusState(height int64, consensusHeight ibcexported.Height) *clienttypes.QueryConsensusStateResponse {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if pr.handshake.height != height || pr.handshake.consensusState == nil ||
+		pr.handshake.consensusHeight.GetRevisionNumber() != consensusHeight.GetRevisionNumber() ||
+		pr.handshake.consensusHeight.GetRevisionHeight() != consensusHeight.GetRevisionHeight() {
+		return nil
+	}
+	res := pr.handshake.consensusState
+	pr.handshake.consensusState = nil
+	return res
+}
+
+// QueryChannelWithProof returns the Channel at the height and its proof
+func (pr *Prover) QueryChannelWithProof(height int64) (*chantypes.QueryChannelResponse, error) {
+	if err := pr.checkQueryHeight(height); err != nil {
 		return nil, err
 	}
-	res, err := pr.chain.QueryChannel(h)
+	res, err := pr.chain.QueryChannel(height)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to query the channel at height %v: %w", height, err)
 	}
 	return pr.SignChannelStateResponse(res, pr.chain.Path().PortID, pr.chain.Path().ChannelID)
 }
 
-// QueryPacketCommitmentWithProof returns the packet commitment and its proof
-func (pr *Prover) QueryPacketCommitmentWithProof(_ int64, seq uint64) (comRes *chantypes.QueryPacketCommitmentResponse, err error) {
-	h, err := pr.chain.GetLatestHeight()
-	if err != nil {
+// QueryPacketCommitmentWithProof returns the packet commitment at the height and its proof
+func (pr *Prover) QueryPacketCommitmentWithProof(height int64, seq uint64) (comRes *chantypes.QueryPacketCommitmentResponse, err error) {
+	if err := pr.checkQueryHeight(height); err != nil {
 		return nil, err
 	}
-	res, err := pr.chain.QueryPacketCommitment(h, seq)
+	res, err := pr.chain.QueryPacketCommitment(height, seq)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to query the packet commitment at height %v: %w", height, err)
 	}
 	return pr.SignPacketStateResponse(res, pr.chain.Path().PortID, pr.chain.Path().ChannelID, seq)
 }
 
-// QueryPacketAcknowledgementCommitmentWithProof returns the packet acknowledgement commitment and its proof
-func (pr *Prover) QueryPacketAcknowledgementCommitmentWithProof(_ int64, seq uint64) (*chantypes.QueryPacketAcknowledgementResponse, error) {
-	h, err := pr.chain.GetLatestHeight()
-	if err != nil {
+// QueryPacketAcknowledgementCommitmentWithProof returns the packet acknowledgement commitment at the height and its proof
+func (pr *Prover) QueryPacketAcknowledgementCommitmentWithProof(height int64, seq uint64) (*chantypes.QueryPacketAcknowledgementResponse, error) {
+	if err := pr.checkQueryHeight(height); err != nil {
 		return nil, err
 	}
-	res, err := pr.chain.QueryPacketAcknowledgementCommitment(h, seq)
+	res, err := pr.chain.QueryPacketAcknowledgementCommitment(height, seq)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to query the packet acknowledgement commitment at height %v: %w", height, err)
 	}
 	return pr.SignAcknowledgementStateResponse(res, pr.chain.Path().PortID, pr.chain.Path().ChannelID, seq)
 }
 
+// checkQueryHeight returns an error if the state of the chain at the height cannot be queried,
+// so that the prover never signs the state at another height than the requested one.
+func (pr *Prover) checkQueryHeight(height int64) error {
+	if height <= 0 {
+		return fmt.Errorf("%w: height must be positive: %v", ErrHeightNotAvailable, height)
+	}
+	latest, err := pr.chain.GetLatestHeight()
+	if err != nil {
+		return err
+	}
+	if height > latest {
+		return fmt.Errorf("%w: height %v is greater than the latest height %v of the chain %v", ErrHeightNotAvailable, height, latest, pr.chain.ChainID())
+	}
+	return nil
+}
+
+// GetHeight returns the proof height of the latest sequence
 func (pr *Prover) GetHeight() (clienttypes.Height, error) {
 	seq, err := pr.GetSequeunce()
 	if err != nil {
@@ -185,9 +569,72 @@ func (pr *Prover) GetHeight() (clienttypes.Height, error) {
 	return clienttypes.NewHeight(0, seq), nil
 }
 
-// TODO load a sequence value from the persisted storage
+// GetSequeunce returns the latest sequence
 func (pr *Prover) GetSequeunce() (uint64, error) {
-	return 1, nil
+	state, err := pr.sequences.Load()
+	if err != nil {
+		return 0, err
+	}
+	return state.Sequence, nil
+}
+
+// nextProofHeight returns the proof height of a new sequence for a proof.
+// The client accepts a sequence only once, so every proof is signed with its own sequence
+// and the proofs that must share a proof height are signed in a batch.
+// The sequence is persisted before the proof is signed, so it is never reused even if the prover crashes.
+func (pr *Prover) nextProofHeight() (clienttypes.Height, error) {
+	state, err := pr.sequences.Update(func(state sequence.State) (sequence.State, error) {
+		state.Sequence++
+		return state, nil
+	})
+	if err != nil {
+		return clienttypes.Height{}, err
+	}
+	return clienttypes.NewHeight(0, state.Sequence), nil
+}
+
+// SignHandshakeResponses signs the connection, the client state and its latest consensus state in a batch,
+// and sets the proofs of the batch to the responses with the same proof height.
+func (pr *Prover) SignHandshakeResponses(connRes *conntypes.QueryConnectionResponse, csRes *clienttypes.QueryClientStateResponse, consRes *clienttypes.QueryConsensusStateResponse, connectionID, clientID string) error {
+	clientState, err := clienttypes.UnpackClientState(csRes.ClientState)
+	if err != nil {
+		return err
+	}
+	consensusState, err := clienttypes.UnpackConsensusState(consRes.ConsensusState)
+	if err != nil {
+		return err
+	}
+	pr.xxxInit(pr.chain.Codec())
+	connData, err := pr.multisig.ConnectionStateData(connectionID, *connRes.Connection)
+	if err != nil {
+		return err
+	}
+	csData, err := pr.multisig.ClientStateData(clientID, clientState)
+	if err != nil {
+		return err
+	}
+	consData, err := pr.multisig.ConsensusStateData(clientID, clientState.GetLatestHeight(), consensusState)
+	if err != nil {
+		return err
+	}
+	proofHeight, err := pr.nextProofHeight()
+	if err != nil {
+		return err
+	}
+	proofs, _, err := pr.multisig.SignStates(proofHeight, []ethmultisigclient.StateData{connData, csData, consData})
+	if err != nil {
+		return err
+	}
+	var bzs [3][]byte
+	for i, proof := range proofs {
+		if bzs[i], err = proto.Marshal(proof); err != nil {
+			return err
+		}
+	}
+	connRes.Proof, connRes.ProofHeight = bzs[0], proofHeight
+	csRes.Proof, csRes.ProofHeight = bzs[1], proofHeight
+	consRes.Proof, consRes.ProofHeight = bzs[2], proofHeight
+	return nil
 }
 
 func (pr *Prover) SignClientStateResponse(res *clienttypes.QueryClientStateResponse, clientID string) (*clienttypes.QueryClientStateResponse, error) {
@@ -195,7 +642,7 @@ func (pr *Prover) SignClientStateResponse(res *clienttypes.QueryClientStateRespo
 	if err != nil {
 		return nil, err
 	}
-	res.ProofHeight, err = pr.GetHeight()
+	res.ProofHeight, err = pr.nextProofHeight()
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +659,7 @@ func (pr *Prover) SignConsensusStateResponse(res *clienttypes.QueryConsensusStat
 	if err != nil {
 		return nil, err
 	}
-	res.ProofHeight, err = pr.GetHeight()
+	res.ProofHeight, err = pr.nextProofHeight()
 	if err != nil {
 		return nil, err
 	}
@@ -226,7 +673,7 @@ func (pr *Prover) SignConsensusStateResponse(res *clienttypes.QueryConsensusStat
 
 func (pr *Prover) SignConnectionStateResponse(res *conntypes.QueryConnectionResponse, connectionID string) (*conntypes.QueryConnectionResponse, error) {
 	var err error
-	res.ProofHeight, err = pr.GetHeight()
+	res.ProofHeight, err = pr.nextProofHeight()
 	if err != nil {
 		return nil, err
 	}
@@ -240,7 +687,7 @@ func (pr *Prover) SignConnectionStateResponse(res *conntypes.QueryConnectionResp
 
 func (pr *Prover) SignChannelStateResponse(res *chantypes.QueryChannelResponse, portID, channelID string) (*chantypes.QueryChannelResponse, error) {
 	var err error
-	res.ProofHeight, err = pr.GetHeight()
+	res.ProofHeight, err = pr.nextProofHeight()
 	if err != nil {
 		return nil, err
 	}
@@ -254,7 +701,7 @@ func (pr *Prover) SignChannelStateResponse(res *chantypes.QueryChannelResponse,
 
 func (pr *Prover) SignPacketStateResponse(res *chantypes.QueryPacketCommitmentResponse, portID, channelID string, seq uint64) (*chantypes.QueryPacketCommitmentResponse, error) {
 	var err error
-	res.ProofHeight, err = pr.GetHeight()
+	res.ProofHeight, err = pr.nextProofHeight()
 	if err != nil {
 		return nil, err
 	}
@@ -268,7 +715,7 @@ func (pr *Prover) SignPacketStateResponse(res *chantypes.QueryPacketCommitmentRe
 
 func (pr *Prover) SignAcknowledgementStateResponse(res *chantypes.QueryPacketAcknowledgementResponse, portID, channelID string, seq uint64) (*chantypes.QueryPacketAcknowledgementResponse, error) {
 	var err error
-	res.ProofHeight, err = pr.GetHeight()
+	res.ProofHeight, err = pr.nextProofHeight()
 	if err != nil {
 		return nil, err
 	}
@@ -280,6 +727,39 @@ func (pr *Prover) SignAcknowledgementStateResponse(res *chantypes.QueryPacketAck
 	return res, nil
 }
 
+// SignPacketReceiptAbsenceResponse signs the absence of the packet receipt for the given sequence.
+// It returns an error if the packet has already been received.
+func (pr *Prover) SignPacketReceiptAbsenceResponse(res *chantypes.QueryPacketReceiptResponse, portID, channelID string, seq uint64) (*chantypes.QueryPacketReceiptResponse, error) {
+	if res.Received {
+		return nil, fmt.Errorf("packet receipt exists: portID=%v channelID=%v sequence=%v", portID, channelID, seq)
+	}
+	var err error
+	res.ProofHeight, err = pr.nextProofHeight()
+	if err != nil {
+		return nil, err
+	}
+	pr.xxxInit(pr.chain.Codec())
+	res.Proof, err = marshalProofIfNoError(pr.multisig.SignPacketReceiptAbsence(res.ProofHeight, portID, channelID, seq))
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (pr *Prover) SignNextSequenceRecvResponse(res *chantypes.QueryNextSequenceReceiveResponse, portID, channelID string) (*chantypes.QueryNextSequenceReceiveResponse, error) {
+	var err error
+	res.ProofHeight, err = pr.nextProofHeight()
+	if err != nil {
+		return nil, err
+	}
+	pr.xxxInit(pr.chain.Codec())
+	res.Proof, err = marshalProofIfNoError(pr.multisig.SignNextSequenceRecv(res.ProofHeight, portID, channelID, res.NextSequenceReceive))
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // xxxInit initializes the codec of ethmultisig
 // TODO: This method should be removed after the problem with the prover not giving a codec is fixed
 func (pr *Prover) xxxInit(cdc codec.ProtoCodecMarshaler) {
@@ -0,0 +1,114 @@
+package sequence
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore is a Store that keeps the state in a JSON file.
+// The file is replaced atomically with a fsynced temporary file on every update,
+// so the state is either the old one or the new one after a crash.
+// Updates are serialized across processes with an exclusive lock of a lock file next to the state file.
+type FileStore struct {
+	mu       sync.Mutex
+	dir      string
+	path     string
+	lockPath string
+}
+
+var _ Store = (*FileStore)(nil)
+
+// NewFileStore returns a FileStore that keeps the state of key in the directory.
+// The directory is created if it does not exist.
+func NewFileStore(dir string, key string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, fileName(key)+".json")
+	return &FileStore{dir: dir, path: path, lockPath: path + ".lock"}, nil
+}
+
+// Load implements Store.Load
+func (s *FileStore) Load() (State, error) {
+	var state State
+	err := s.withLock(func() error {
+		var err error
+		state, err = s.read()
+		return err
+	})
+	return state, err
+}
+
+// Update implements Store.Update
+func (s *FileStore) Update(fn func(State) (State, error)) (State, error) {
+	var next State
+	err := s.withLock(func() error {
+		state, err := s.read()
+		if err != nil {
+			return err
+		}
+		next, err = apply(state, fn)
+		if err != nil {
+			return err
+		}
+		if next == state {
+			return nil
+		}
+		return s.write(next)
+	})
+	return next, err
+}
+
+func (s *FileStore) withLock(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock, err := lockFile(s.lockPath)
+	if err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		unlock()
+		return err
+	}
+	return unlock()
+}
+
+func (s *FileStore) read() (State, error) {
+	bz, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return InitialState, nil
+	} else if err != nil {
+		return State{}, err
+	}
+	return unmarshalState(bz)
+}
+
+func (s *FileStore) write(state State) error {
+	bz, err := marshalState(state)
+	if err != nil {
+		return err
+	}
+	// a temporary file left by a crash is overwritten as the lock is held
+	tmpPath := s.path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(bz); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return err
+	}
+	return syncDir(s.dir)
+}
@@ -0,0 +1,37 @@
+//go:build windows
+// +build windows
+
+package sequence
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile blocks until it acquires the exclusive lock of the file at the path.
+// The lock is held by the open file, so it is released even if the process crashes.
+func lockFile(path string) (unlock func() error, err error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	ol := new(windows.Overlapped)
+	if err := windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, ol); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() error {
+		if err := windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, ol); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}, nil
+}
+
+// syncDir is a no-op because Windows cannot flush a directory.
+// The rename of a file is flushed with the file system journal.
+func syncDir(dir string) error {
+	return nil
+}
@@ -0,0 +1,108 @@
+package sequence
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+const levelDBName = "sequence"
+
+// LevelDBStore is a Store that keeps the state in an embedded LevelDB database.
+// The states of several keys can share a database.
+// LevelDB allows only one process to open a database at a time, so the database is opened
+// for each operation while an exclusive lock of a lock file next to the database is held.
+// The state is written with a synced write.
+type LevelDBStore struct {
+	mu       sync.Mutex
+	dir      string
+	key      []byte
+	lockPath string
+}
+
+var _ Store = (*LevelDBStore)(nil)
+
+// NewLevelDBStore returns a LevelDBStore that keeps the state of key in the database in the directory.
+// The directory is created if it does not exist.
+func NewLevelDBStore(dir string, key string) (*LevelDBStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &LevelDBStore{
+		dir:      dir,
+		key:      []byte("sequence/" + key),
+		lockPath: filepath.Join(dir, levelDBName+".db.lock"),
+	}, nil
+}
+
+// Load implements Store.Load
+func (s *LevelDBStore) Load() (State, error) {
+	var state State
+	err := s.withDB(func(db *dbm.GoLevelDB) error {
+		var err error
+		state, err = s.read(db)
+		return err
+	})
+	return state, err
+}
+
+// Update implements Store.Update
+func (s *LevelDBStore) Update(fn func(State) (State, error)) (State, error) {
+	var next State
+	err := s.withDB(func(db *dbm.GoLevelDB) error {
+		state, err := s.read(db)
+		if err != nil {
+			return err
+		}
+		next, err = apply(state, fn)
+		if err != nil {
+			return err
+		}
+		if next == state {
+			return nil
+		}
+		bz, err := marshalState(next)
+		if err != nil {
+			return err
+		}
+		return db.SetSync(s.key, bz)
+	})
+	return next, err
+}
+
+func (s *LevelDBStore) withDB(fn func(db *dbm.GoLevelDB) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock, err := lockFile(s.lockPath)
+	if err != nil {
+		return err
+	}
+	db, err := dbm.NewGoLevelDB(levelDBName, s.dir)
+	if err != nil {
+		unlock()
+		return err
+	}
+	if err := fn(db); err != nil {
+		db.Close()
+		unlock()
+		return err
+	}
+	if err := db.Close(); err != nil {
+		unlock()
+		return err
+	}
+	return unlock()
+}
+
+func (s *LevelDBStore) read(db *dbm.GoLevelDB) (State, error) {
+	bz, err := db.Get(s.key)
+	if err != nil {
+		return State{}, err
+	}
+	if bz == nil {
+		return InitialState, nil
+	}
+	return unmarshalState(bz)
+}
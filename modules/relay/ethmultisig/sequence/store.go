@@ -0,0 +1,81 @@
+package sequence
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// State is the signing state of a prover.
+// Sequence is the sequence of the latest signed proof.
+type State struct {
+	Sequence uint64 `json:"sequence"`
+}
+
+// InitialState is the state of a store that has never been updated.
+// The sequence of a new client starts at one.
+var InitialState = State{Sequence: 1}
+
+// Store is a durable store of the signing state of a prover.
+// A store must be safe to share between goroutines and processes.
+type Store interface {
+	// Load returns the persisted state.
+	Load() (State, error)
+	// Update applies fn to the persisted state and persists its result before returning it.
+	// Concurrent updates of the same state, even from other processes, are serialized,
+	// so every state returned by Update is returned only once.
+	// fn must not decrease the sequence.
+	Update(fn func(State) (State, error)) (State, error)
+}
+
+const (
+	// StoreTypeFile is a store that keeps the state in a file
+	StoreTypeFile = "file"
+	// StoreTypeLevelDB is a store that keeps the state in a LevelDB database
+	StoreTypeLevelDB = "leveldb"
+)
+
+// NewStore returns the store of the given type that keeps the state of key in the directory.
+// An empty type is the file store.
+func NewStore(storeType string, dir string, key string) (Store, error) {
+	switch storeType {
+	case "", StoreTypeFile:
+		return NewFileStore(dir, key)
+	case StoreTypeLevelDB:
+		return NewLevelDBStore(dir, key)
+	default:
+		return nil, fmt.Errorf("unknown sequence store type: %v", storeType)
+	}
+}
+
+// fileName returns a file name that is unique to the key
+func fileName(key string) string {
+	return url.PathEscape(key)
+}
+
+func marshalState(state State) ([]byte, error) {
+	return json.Marshal(state)
+}
+
+func unmarshalState(bz []byte) (State, error) {
+	var state State
+	if err := json.Unmarshal(bz, &state); err != nil {
+		return State{}, fmt.Errorf("corrupted sequence state: %w", err)
+	}
+	if state.Sequence == 0 {
+		return State{}, fmt.Errorf("corrupted sequence state: sequence cannot be zero")
+	}
+	return state, nil
+}
+
+// apply applies fn to the state and checks that the sequence does not go backwards
+func apply(state State, fn func(State) (State, error)) (State, error) {
+	next, err := fn(state)
+	if err != nil {
+		return State{}, err
+	}
+	if next.Sequence < state.Sequence {
+		return State{}, fmt.Errorf("sequence cannot decrease: %v < %v", next.Sequence, state.Sequence)
+	}
+	return next, nil
+}
@@ -0,0 +1,99 @@
+package ethmultisig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig/signer"
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig/wallet"
+)
+
+// Signer signs the hashes of the sign bytes as a signer of the multisig.
+// Implementations can keep the key anywhere, such as in memory, in a keystore, in a remote signer or in a KMS.
+type Signer interface {
+	// Address returns the address of the signer
+	Address() common.Address
+	// SignHash signs the 32-byte hash and returns a 65-byte [R || S || V] signature.
+	// The signature must be canonical, that is, s must be in the lower half of the curve order.
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// BytesSigner is a Signer that signs the sign bytes themselves rather than their hash,
+// such as a remote signer that checks what it signs before signing.
+// The multisig passes the sign bytes to a BytesSigner instead of the hash.
+type BytesSigner interface {
+	Signer
+	// SignBytes signs the keccak256 hash of the sign bytes and returns a 65-byte [R || S || V] signature.
+	SignBytes(ctx context.Context, signBytes []byte) ([]byte, error)
+}
+
+var _ BytesSigner = (*signer.RemoteSigner)(nil)
+
+// KeySigner is a Signer with a private key in memory
+type KeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+var _ Signer = (*KeySigner)(nil)
+
+// NewKeySigner returns a KeySigner with the private key
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{key: key}
+}
+
+// NewKeySigners returns a KeySigner for each private key
+func NewKeySigners(keys ...*ecdsa.PrivateKey) []Signer {
+	var signers []Signer
+	for _, key := range keys {
+		signers = append(signers, NewKeySigner(key))
+	}
+	return signers
+}
+
+// NewHDWalletSigner returns a KeySigner with the private key derived from the mnemonic with the HD path
+func NewHDWalletSigner(mnemonic string, hdwPath string) (*KeySigner, error) {
+	key, err := wallet.GetPrvKeyFromMnemonicAndHDWPath(mnemonic, hdwPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(key), nil
+}
+
+// Address implements Signer.Address
+func (s *KeySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+// SignHash implements Signer.SignHash
+func (s *KeySigner) SignHash(_ context.Context, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+// Dial returns a signer that requests the signer daemon of the config to sign
+func (rs RemoteSigner) Dial() (*signer.RemoteSigner, error) {
+	if rs.Endpoint == "" {
+		return nil, fmt.Errorf("the endpoint of a remote signer is empty")
+	}
+	if !common.IsHexAddress(rs.Address) {
+		return nil, fmt.Errorf("invalid address of the remote signer %v: %v", rs.Endpoint, rs.Address)
+	}
+	var tlsConfig *tls.Config
+	if !rs.Insecure {
+		var err error
+		tlsConfig, err = signer.ClientTLSConfig(rs.CaFile, rs.CertFile, rs.KeyFile, rs.ServerName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS config of the remote signer %v: %w", rs.Endpoint, err)
+		}
+	}
+	return signer.Dial(rs.Endpoint, common.HexToAddress(rs.Address), signer.Options{
+		TLSConfig:  tlsConfig,
+		Timeout:    time.Duration(rs.Timeout),
+		MaxRetries: uint(rs.MaxRetries),
+	})
+}
@@ -0,0 +1,142 @@
+package signer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultTimeout is the timeout of each request if no timeout is given
+	DefaultTimeout = 10 * time.Second
+
+	retryDelay    = 100 * time.Millisecond
+	retryMaxDelay = 2 * time.Second
+)
+
+// Options are the options of a connection to a signer server
+type Options struct {
+	// TLSConfig is the TLS config of the connection. If nil, the connection is not encrypted.
+	TLSConfig *tls.Config
+	// Timeout is the timeout of each request. If zero, DefaultTimeout is used.
+	Timeout time.Duration
+	// MaxRetries is the number of the retries after a request fails
+	MaxRetries uint
+}
+
+// RemoteSigner signs the sign bytes with the key of a signer server.
+// The server returns a signature of its own key, so the caller must check that the signature is of the address.
+type RemoteSigner struct {
+	endpoint string
+	address  common.Address
+	opts     Options
+	conn     *grpc.ClientConn
+	client   SignerClient
+}
+
+// Dial returns a RemoteSigner of the key of the address that the server at the endpoint holds.
+// The connection is established in the background, so Dial succeeds even if the server is not running yet.
+func Dial(endpoint string, address common.Address, opts Options) (*RemoteSigner, error) {
+	creds := grpc.WithInsecure()
+	if opts.TLSConfig != nil {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(opts.TLSConfig))
+	}
+	conn, err := grpc.Dial(endpoint, creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial the signer %v: %w", endpoint, err)
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	return &RemoteSigner{
+		endpoint: endpoint,
+		address:  address,
+		opts:     opts,
+		conn:     conn,
+		client:   NewSignerClient(conn),
+	}, nil
+}
+
+// Address returns the address of the key of the signer
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// RemoteAddress queries the address of the key that the server holds
+func (s *RemoteSigner) RemoteAddress(ctx context.Context) (common.Address, error) {
+	var res *AddressResponse
+	err := s.do(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = s.client.Address(ctx, &AddressRequest{})
+		return err
+	})
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(res.Address) != common.AddressLength {
+		return common.Address{}, fmt.Errorf("signer %v returned an invalid address: %x", s.endpoint, res.Address)
+	}
+	return common.BytesToAddress(res.Address), nil
+}
+
+// SignBytes requests the server to sign the sign bytes and returns a 65-byte [R || S || V] signature.
+// Each request times out after the timeout of the options, and the failed requests are retried
+// unless the server rejects the sign bytes.
+func (s *RemoteSigner) SignBytes(ctx context.Context, signBytes []byte) ([]byte, error) {
+	var res *SignResponse
+	err := s.do(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = s.client.Sign(ctx, &SignRequest{SignBytes: signBytes})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// SignHash always fails because the server signs only the sign bytes that it can check.
+// The multisig requests a RemoteSigner to sign with SignBytes instead.
+func (s *RemoteSigner) SignHash(_ context.Context, _ []byte) ([]byte, error) {
+	return nil, fmt.Errorf("signer %v signs only sign bytes", s.endpoint)
+}
+
+// Close closes the connection to the server
+func (s *RemoteSigner) Close() error {
+	return s.conn.Close()
+}
+
+// do calls fn with a timeout and retries it. The returned error is the gRPC status error of the last call.
+func (s *RemoteSigner) do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return retry.Do(
+		func() error {
+			ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+			defer cancel()
+			return fn(ctx)
+		},
+		retry.Context(ctx),
+		retry.Attempts(s.opts.MaxRetries+1),
+		retry.Delay(retryDelay),
+		retry.MaxDelay(retryMaxDelay),
+		retry.LastErrorOnly(true),
+		retry.RetryIf(isRetryable),
+	)
+}
+
+// isRetryable returns false if the request fails the same way however many times it is sent
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.PermissionDenied, codes.Unimplemented, codes.Unauthenticated, codes.Canceled:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,90 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	ethmultisigtypes "github.com/datachainlab/ibc-ethmultisig-client/modules/light-clients/xx-ethmultisig/types"
+)
+
+// HashSigner is a key that the server signs with
+type HashSigner interface {
+	// Address returns the address of the key
+	Address() common.Address
+	// SignHash signs the 32-byte hash and returns a 65-byte [R || S || V] signature
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// Server is a SignerServer that signs the sign bytes of the light client with a key.
+// It signs only the sign bytes that are canonically encoded and whose diversifier is allowed,
+// so a relayer cannot make it sign arbitrary data.
+type Server struct {
+	signer       HashSigner
+	diversifiers map[string]bool
+}
+
+var _ SignerServer = (*Server)(nil)
+
+// NewServer returns a Server that signs with the signer.
+// If diversifiers are given, the sign bytes of the other diversifiers are rejected.
+func NewServer(signer HashSigner, diversifiers []string) *Server {
+	s := &Server{signer: signer}
+	if len(diversifiers) > 0 {
+		s.diversifiers = make(map[string]bool)
+		for _, d := range diversifiers {
+			s.diversifiers[d] = true
+		}
+	}
+	return s
+}
+
+// Address implements SignerServer.Address
+func (s *Server) Address(_ context.Context, _ *AddressRequest) (*AddressResponse, error) {
+	return &AddressResponse{Address: s.signer.Address().Bytes()}, nil
+}
+
+// Sign implements SignerServer.Sign
+func (s *Server) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	var signBytes ethmultisigtypes.SignBytes
+	if err := signBytes.Unmarshal(req.SignBytes); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode the sign bytes: %v", err)
+	}
+	// the bytes that are signed must be the ones that have been checked
+	bz, err := signBytes.Marshal()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to encode the sign bytes: %v", err)
+	} else if !bytes.Equal(bz, req.SignBytes) {
+		return nil, status.Error(codes.InvalidArgument, "sign bytes are not canonically encoded")
+	}
+	if _, ok := ethmultisigtypes.SignBytes_DataType_name[int32(signBytes.DataType)]; !ok || signBytes.DataType == ethmultisigtypes.UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown data type: %v", signBytes.DataType)
+	}
+	if s.diversifiers != nil && !s.diversifiers[signBytes.Diversifier] {
+		return nil, status.Errorf(codes.PermissionDenied, "diversifier %q is not allowed", signBytes.Diversifier)
+	}
+	sig, err := s.signer.SignHash(ctx, crypto.Keccak256(req.SignBytes))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign: %v", err)
+	}
+	return &SignResponse{Signature: sig}, nil
+}
+
+// NewGRPCServer returns a gRPC server that serves the signer server, such as a Server.
+// If tlsConfig is nil, the connections are not encrypted.
+func NewGRPCServer(s SignerServer, tlsConfig *tls.Config) *grpc.Server {
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	gs := grpc.NewServer(opts...)
+	RegisterSignerServer(gs, s)
+	return gs
+}
@@ -26,10 +26,26 @@ var (
 	_ = event.NewSubscription
 )
 
+// BatchProofData is an auto generated low-level Go binding around an user-defined struct.
+type BatchProofData struct {
+	Root      []byte
+	Index     uint64
+	NumLeaves uint64
+	Siblings  [][]byte
+}
+
 // ClientStateData is an auto generated low-level Go binding around an user-defined struct.
 type ClientStateData struct {
-	LatestHeight HeightData
-	FrozenHeight HeightData
+	LatestHeight         HeightData
+	FrozenHeight         HeightData
+	MaxConsensusStates   uint64
+	ConsensusStateMaxAge uint64
+	Sequence             uint64
+	Timestamp            uint64
+	TrustingPeriod       uint64
+	MaxClockDrift        uint64
+	MaxSignatureAge      uint64
+	BatchRoot            []byte
 }
 
 // ConsensusStateData is an auto generated low-level Go binding around an user-defined struct.
@@ -37,6 +53,8 @@ type ConsensusStateData struct {
 	Addresses   [][]byte
 	Diversifier string
 	Timestamp   uint64
+	Threshold   uint64
+	Powers      []uint64
 }
 
 // HeightData is an auto generated low-level Go binding around an user-defined struct.
@@ -47,12 +65,16 @@ type HeightData struct {
 
 // MultiSignatureData is an auto generated low-level Go binding around an user-defined struct.
 type MultiSignatureData struct {
-	Signatures [][]byte
-	Timestamp  uint64
+	Signatures        [][]byte
+	Timestamp         uint64
+	SignerBitmap      []byte
+	Version           uint8
+	CompactSignatures []byte
+	Batch             BatchProofData
 }
 
 // MultisigclientABI is the input ABI used to generate the binding from.
-const MultisigclientABI = "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"}],\"name\":\"getTimestampAtHeight\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"}],\"name\":\"getLatestHeight\",\"outputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"\",\"type\":\"tuple\"},{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"name\":\"checkHeaderAndUpdateState\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes[]\",\"name\":\"addresses\",\"type\":\"bytes[]\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"}],\"internalType\":\"structConsensusState.Data\",\"name\":\"consensusState\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"bytes[]\",\"name\":\"signatures\",\"type\":\"bytes[]\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"}],\"internalType\":\"structMultiSignature.Data\",\"name\":\"multisig\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"signBytes\",\"type\":\"bytes\"}],\"name\":\"verifySignature\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"pure\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"counterpartyClientIdentifier\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"clientStateBytes\",\"type\":\"bytes\"}],\"name\":\"verifyClientState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"string\",\"name\":\"counterpartyClientIdentifier\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"consensusHeight\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"consensusStateBytes\",\"type\":\"bytes\"}],\"name\":\"verifyClientConsensusState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"connectionId\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"connectionBytes\",\"type\":\"bytes\"}],\"name\":\"verifyConnectionState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"portId\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"channelId\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"channelBytes\",\"type\":\"bytes\"}],\"name\":\"verifyChannelState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"portId\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"channelId\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"bytes32\",\"name\":\"commitmentBytes\",\"type\":\"bytes32\"}],\"name\":\"verifyPacketCommitment\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"portId\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"channelId\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"acknowledgement\",\"type\":\"bytes\"}],\"name\":\"verifyPacketAcknowledgement\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"}],\"name\":\"getClientState\",\"outputs\":[{\"components\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"latest_height\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"frozen_height\",\"type\":\"tuple\"}],\"internalType\":\"structClientState.Data\",\"name\":\"clientState\",\"type\":\"tuple\"},{\"internalType\":\"bool\",\"name\":\"found\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"}],\"name\":\"getConsensusState\",\"outputs\":[{\"components\":[{\"internalType\":\"bytes[]\",\"name\":\"addresses\",\"type\":\"bytes[]\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"}],\"internalType\":\"structConsensusState.Data\",\"name\":\"consensusState\",\"type\":\"tuple\"},{\"internalType\":\"bool\",\"name\":\"found\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"clientID\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"clientState\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makeClientStateSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"clientID\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"consensusHeight\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"consensusState\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makeConsensusStateSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"connectionID\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"connection\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makeConnectionStateSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"portID\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"channelID\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"channel\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makeChannelStateSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"bytes32\",\"name\":\"path\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"packetCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makePacketSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\",\"constant\":true},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"bytes32\",\"name\":\"path\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"packetAcknowledgement\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makePacketAcknowledgementSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\",\"constant\":true}]"
+const MultisigclientABI = "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"name\":\"checkHeaderAndUpdateState\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"}],\"name\":\"getClientState\",\"outputs\":[{\"components\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"latest_height\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"frozen_height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"max_consensus_states\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"consensus_state_max_age\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"trusting_period\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"max_clock_drift\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"max_signature_age\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"batch_root\",\"type\":\"bytes\"}],\"internalType\":\"structClientState.Data\",\"name\":\"clientState\",\"type\":\"tuple\"},{\"internalType\":\"bool\",\"name\":\"found\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"}],\"name\":\"getConsensusState\",\"outputs\":[{\"components\":[{\"internalType\":\"bytes[]\",\"name\":\"addresses\",\"type\":\"bytes[]\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"threshold\",\"type\":\"uint64\"},{\"internalType\":\"uint64[]\",\"name\":\"powers\",\"type\":\"uint64[]\"}],\"internalType\":\"structConsensusState.Data\",\"name\":\"consensusState\",\"type\":\"tuple\"},{\"internalType\":\"bool\",\"name\":\"found\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"}],\"name\":\"getLatestHeight\",\"outputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"\",\"type\":\"tuple\"},{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"}],\"name\":\"getTimestampAtHeight\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"portID\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"channelID\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"channel\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makeChannelStateSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"clientID\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"clientState\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makeClientStateSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"connectionID\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"connection\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makeConnectionStateSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"clientID\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"consensusHeight\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"consensusState\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makeConsensusStateSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"bytes32\",\"name\":\"path\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"packetAcknowledgement\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makePacketAcknowledgementSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"bytes32\",\"name\":\"path\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"packetCommitment\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"}],\"name\":\"makePacketSignBytes\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"hash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"compactSigs\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"recoverCompactSigner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"hash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"sig\",\"type\":\"bytes\"}],\"name\":\"recoverSigner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"portId\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"channelId\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"channelBytes\",\"type\":\"bytes\"}],\"name\":\"verifyChannelState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"string\",\"name\":\"counterpartyClientIdentifier\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"consensusHeight\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"consensusStateBytes\",\"type\":\"bytes\"}],\"name\":\"verifyClientConsensusState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"counterpartyClientIdentifier\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"clientStateBytes\",\"type\":\"bytes\"}],\"name\":\"verifyClientState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"connectionId\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"connectionBytes\",\"type\":\"bytes\"}],\"name\":\"verifyConnectionState\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"delayPeriodTime\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"delayPeriodBlocks\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"portId\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"channelId\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"acknowledgement\",\"type\":\"bytes\"}],\"name\":\"verifyPacketAcknowledgement\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"contractIBCHost\",\"name\":\"host\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"clientId\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"revision_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"revision_height\",\"type\":\"uint64\"}],\"internalType\":\"structHeight.Data\",\"name\":\"height\",\"type\":\"tuple\"},{\"internalType\":\"uint64\",\"name\":\"delayPeriodTime\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"delayPeriodBlocks\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"prefix\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proof\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"portId\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"channelId\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"bytes32\",\"name\":\"commitmentBytes\",\"type\":\"bytes32\"}],\"name\":\"verifyPacketCommitment\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes[]\",\"name\":\"addresses\",\"type\":\"bytes[]\"},{\"internalType\":\"string\",\"name\":\"diversifier\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"threshold\",\"type\":\"uint64\"},{\"internalType\":\"uint64[]\",\"name\":\"powers\",\"type\":\"uint64[]\"}],\"internalType\":\"structConsensusState.Data\",\"name\":\"consensusState\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"bytes[]\",\"name\":\"signatures\",\"type\":\"bytes[]\"},{\"internalType\":\"uint64\",\"name\":\"timestamp\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"signer_bitmap\",\"type\":\"bytes\"},{\"internalType\":\"enumMultiSignature.Version\",\"name\":\"version\",\"type\":\"uint8\"},{\"internalType\":\"bytes\",\"name\":\"compact_signatures\",\"type\":\"bytes\"},{\"components\":[{\"internalType\":\"bytes\",\"name\":\"root\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"index\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"num_leaves\",\"type\":\"uint64\"},{\"internalType\":\"bytes[]\",\"name\":\"siblings\",\"type\":\"bytes[]\"}],\"internalType\":\"structBatchProof.Data\",\"name\":\"batch\",\"type\":\"tuple\"}],\"internalType\":\"structMultiSignature.Data\",\"name\":\"multisig\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"signBytes\",\"type\":\"bytes\"}],\"name\":\"verifySignature\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"pure\",\"type\":\"function\"}]"
 
 // Multisigclient is an auto generated Go binding around an Ethereum contract.
 type Multisigclient struct {
@@ -231,7 +253,7 @@ func (_Multisigclient *MultisigclientCallerSession) CheckHeaderAndUpdateState(ar
 
 // GetClientState is a free data retrieval call binding the contract method 0xff33b3e6.
 //
-// Solidity: function getClientState(address host, string clientId) view returns(((uint64,uint64),(uint64,uint64)) clientState, bool found)
+// Solidity: function getClientState(address host, string clientId) view returns(((uint64,uint64),(uint64,uint64),uint64,uint64,uint64,uint64,uint64,uint64,uint64,bytes) clientState, bool found)
 func (_Multisigclient *MultisigclientCaller) GetClientState(opts *bind.CallOpts, host common.Address, clientId string) (struct {
 	ClientState ClientStateData
 	Found       bool
@@ -253,7 +275,7 @@ func (_Multisigclient *MultisigclientCaller) GetClientState(opts *bind.CallOpts,
 
 // GetClientState is a free data retrieval call binding the contract method 0xff33b3e6.
 //
-// Solidity: function getClientState(address host, string clientId) view returns(((uint64,uint64),(uint64,uint64)) clientState, bool found)
+// Solidity: function getClientState(address host, string clientId) view returns(((uint64,uint64),(uint64,uint64),uint64,uint64,uint64,uint64,uint64,uint64,uint64,bytes) clientState, bool found)
 func (_Multisigclient *MultisigclientSession) GetClientState(host common.Address, clientId string) (struct {
 	ClientState ClientStateData
 	Found       bool
@@ -263,7 +285,7 @@ func (_Multisigclient *MultisigclientSession) GetClientState(host common.Address
 
 // GetClientState is a free data retrieval call binding the contract method 0xff33b3e6.
 //
-// Solidity: function getClientState(address host, string clientId) view returns(((uint64,uint64),(uint64,uint64)) clientState, bool found)
+// Solidity: function getClientState(address host, string clientId) view returns(((uint64,uint64),(uint64,uint64),uint64,uint64,uint64,uint64,uint64,uint64,uint64,bytes) clientState, bool found)
 func (_Multisigclient *MultisigclientCallerSession) GetClientState(host common.Address, clientId string) (struct {
 	ClientState ClientStateData
 	Found       bool
@@ -273,7 +295,7 @@ func (_Multisigclient *MultisigclientCallerSession) GetClientState(host common.A
 
 // GetConsensusState is a free data retrieval call binding the contract method 0xdd8dd65f.
 //
-// Solidity: function getConsensusState(address host, string clientId, (uint64,uint64) height) view returns((bytes[],string,uint64) consensusState, bool found)
+// Solidity: function getConsensusState(address host, string clientId, (uint64,uint64) height) view returns((bytes[],string,uint64,uint64,uint64[]) consensusState, bool found)
 func (_Multisigclient *MultisigclientCaller) GetConsensusState(opts *bind.CallOpts, host common.Address, clientId string, height HeightData) (struct {
 	ConsensusState ConsensusStateData
 	Found          bool
@@ -295,7 +317,7 @@ func (_Multisigclient *MultisigclientCaller) GetConsensusState(opts *bind.CallOp
 
 // GetConsensusState is a free data retrieval call binding the contract method 0xdd8dd65f.
 //
-// Solidity: function getConsensusState(address host, string clientId, (uint64,uint64) height) view returns((bytes[],string,uint64) consensusState, bool found)
+// Solidity: function getConsensusState(address host, string clientId, (uint64,uint64) height) view returns((bytes[],string,uint64,uint64,uint64[]) consensusState, bool found)
 func (_Multisigclient *MultisigclientSession) GetConsensusState(host common.Address, clientId string, height HeightData) (struct {
 	ConsensusState ConsensusStateData
 	Found          bool
@@ -305,7 +327,7 @@ func (_Multisigclient *MultisigclientSession) GetConsensusState(host common.Addr
 
 // GetConsensusState is a free data retrieval call binding the contract method 0xdd8dd65f.
 //
-// Solidity: function getConsensusState(address host, string clientId, (uint64,uint64) height) view returns((bytes[],string,uint64) consensusState, bool found)
+// Solidity: function getConsensusState(address host, string clientId, (uint64,uint64) height) view returns((bytes[],string,uint64,uint64,uint64[]) consensusState, bool found)
 func (_Multisigclient *MultisigclientCallerSession) GetConsensusState(host common.Address, clientId string, height HeightData) (struct {
 	ConsensusState ConsensusStateData
 	Found          bool
@@ -563,6 +585,68 @@ func (_Multisigclient *MultisigclientCallerSession) MakePacketSignBytes(height H
 	return _Multisigclient.Contract.MakePacketSignBytes(&_Multisigclient.CallOpts, height, timestamp, diversifier, path, packetCommitment, prefix)
 }
 
+// RecoverCompactSigner is a free data retrieval call binding the contract method 0x92c18a2f.
+//
+// Solidity: function recoverCompactSigner(bytes32 hash, bytes compactSigs, uint256 index) pure returns(address)
+func (_Multisigclient *MultisigclientCaller) RecoverCompactSigner(opts *bind.CallOpts, hash [32]byte, compactSigs []byte, index *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _Multisigclient.contract.Call(opts, &out, "recoverCompactSigner", hash, compactSigs, index)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// RecoverCompactSigner is a free data retrieval call binding the contract method 0x92c18a2f.
+//
+// Solidity: function recoverCompactSigner(bytes32 hash, bytes compactSigs, uint256 index) pure returns(address)
+func (_Multisigclient *MultisigclientSession) RecoverCompactSigner(hash [32]byte, compactSigs []byte, index *big.Int) (common.Address, error) {
+	return _Multisigclient.Contract.RecoverCompactSigner(&_Multisigclient.CallOpts, hash, compactSigs, index)
+}
+
+// RecoverCompactSigner is a free data retrieval call binding the contract method 0x92c18a2f.
+//
+// Solidity: function recoverCompactSigner(bytes32 hash, bytes compactSigs, uint256 index) pure returns(address)
+func (_Multisigclient *MultisigclientCallerSession) RecoverCompactSigner(hash [32]byte, compactSigs []byte, index *big.Int) (common.Address, error) {
+	return _Multisigclient.Contract.RecoverCompactSigner(&_Multisigclient.CallOpts, hash, compactSigs, index)
+}
+
+// RecoverSigner is a free data retrieval call binding the contract method 0x97aba7f9.
+//
+// Solidity: function recoverSigner(bytes32 hash, bytes sig) pure returns(address)
+func (_Multisigclient *MultisigclientCaller) RecoverSigner(opts *bind.CallOpts, hash [32]byte, sig []byte) (common.Address, error) {
+	var out []interface{}
+	err := _Multisigclient.contract.Call(opts, &out, "recoverSigner", hash, sig)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// RecoverSigner is a free data retrieval call binding the contract method 0x97aba7f9.
+//
+// Solidity: function recoverSigner(bytes32 hash, bytes sig) pure returns(address)
+func (_Multisigclient *MultisigclientSession) RecoverSigner(hash [32]byte, sig []byte) (common.Address, error) {
+	return _Multisigclient.Contract.RecoverSigner(&_Multisigclient.CallOpts, hash, sig)
+}
+
+// RecoverSigner is a free data retrieval call binding the contract method 0x97aba7f9.
+//
+// Solidity: function recoverSigner(bytes32 hash, bytes sig) pure returns(address)
+func (_Multisigclient *MultisigclientCallerSession) RecoverSigner(hash [32]byte, sig []byte) (common.Address, error) {
+	return _Multisigclient.Contract.RecoverSigner(&_Multisigclient.CallOpts, hash, sig)
+}
+
 // VerifyChannelState is a free data retrieval call binding the contract method 0xc9f13d26.
 //
 // Solidity: function verifyChannelState(address host, string clientId, (uint64,uint64) height, bytes prefix, bytes proof, string portId, string channelId, bytes channelBytes) view returns(bool)
@@ -689,10 +773,10 @@ func (_Multisigclient *MultisigclientCallerSession) VerifyConnectionState(host c
 
 // VerifyPacketAcknowledgement is a free data retrieval call binding the contract method 0x54596a36.
 //
-// Solidity: function verifyPacketAcknowledgement(address host, string clientId, (uint64,uint64) height, uint64 , uint64 , bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes acknowledgement) view returns(bool)
-func (_Multisigclient *MultisigclientCaller) VerifyPacketAcknowledgement(opts *bind.CallOpts, host common.Address, clientId string, height HeightData, arg3 uint64, arg4 uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, acknowledgement []byte) (bool, error) {
+// Solidity: function verifyPacketAcknowledgement(address host, string clientId, (uint64,uint64) height, uint64 delayPeriodTime, uint64 delayPeriodBlocks, bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes acknowledgement) view returns(bool)
+func (_Multisigclient *MultisigclientCaller) VerifyPacketAcknowledgement(opts *bind.CallOpts, host common.Address, clientId string, height HeightData, delayPeriodTime uint64, delayPeriodBlocks uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, acknowledgement []byte) (bool, error) {
 	var out []interface{}
-	err := _Multisigclient.contract.Call(opts, &out, "verifyPacketAcknowledgement", host, clientId, height, arg3, arg4, prefix, proof, portId, channelId, sequence, acknowledgement)
+	err := _Multisigclient.contract.Call(opts, &out, "verifyPacketAcknowledgement", host, clientId, height, delayPeriodTime, delayPeriodBlocks, prefix, proof, portId, channelId, sequence, acknowledgement)
 
 	if err != nil {
 		return *new(bool), err
@@ -706,24 +790,24 @@ func (_Multisigclient *MultisigclientCaller) VerifyPacketAcknowledgement(opts *b
 
 // VerifyPacketAcknowledgement is a free data retrieval call binding the contract method 0x54596a36.
 //
-// Solidity: function verifyPacketAcknowledgement(address host, string clientId, (uint64,uint64) height, uint64 , uint64 , bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes acknowledgement) view returns(bool)
-func (_Multisigclient *MultisigclientSession) VerifyPacketAcknowledgement(host common.Address, clientId string, height HeightData, arg3 uint64, arg4 uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, acknowledgement []byte) (bool, error) {
-	return _Multisigclient.Contract.VerifyPacketAcknowledgement(&_Multisigclient.CallOpts, host, clientId, height, arg3, arg4, prefix, proof, portId, channelId, sequence, acknowledgement)
+// Solidity: function verifyPacketAcknowledgement(address host, string clientId, (uint64,uint64) height, uint64 delayPeriodTime, uint64 delayPeriodBlocks, bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes acknowledgement) view returns(bool)
+func (_Multisigclient *MultisigclientSession) VerifyPacketAcknowledgement(host common.Address, clientId string, height HeightData, delayPeriodTime uint64, delayPeriodBlocks uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, acknowledgement []byte) (bool, error) {
+	return _Multisigclient.Contract.VerifyPacketAcknowledgement(&_Multisigclient.CallOpts, host, clientId, height, delayPeriodTime, delayPeriodBlocks, prefix, proof, portId, channelId, sequence, acknowledgement)
 }
 
 // VerifyPacketAcknowledgement is a free data retrieval call binding the contract method 0x54596a36.
 //
-// Solidity: function verifyPacketAcknowledgement(address host, string clientId, (uint64,uint64) height, uint64 , uint64 , bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes acknowledgement) view returns(bool)
-func (_Multisigclient *MultisigclientCallerSession) VerifyPacketAcknowledgement(host common.Address, clientId string, height HeightData, arg3 uint64, arg4 uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, acknowledgement []byte) (bool, error) {
-	return _Multisigclient.Contract.VerifyPacketAcknowledgement(&_Multisigclient.CallOpts, host, clientId, height, arg3, arg4, prefix, proof, portId, channelId, sequence, acknowledgement)
+// Solidity: function verifyPacketAcknowledgement(address host, string clientId, (uint64,uint64) height, uint64 delayPeriodTime, uint64 delayPeriodBlocks, bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes acknowledgement) view returns(bool)
+func (_Multisigclient *MultisigclientCallerSession) VerifyPacketAcknowledgement(host common.Address, clientId string, height HeightData, delayPeriodTime uint64, delayPeriodBlocks uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, acknowledgement []byte) (bool, error) {
+	return _Multisigclient.Contract.VerifyPacketAcknowledgement(&_Multisigclient.CallOpts, host, clientId, height, delayPeriodTime, delayPeriodBlocks, prefix, proof, portId, channelId, sequence, acknowledgement)
 }
 
 // VerifyPacketCommitment is a free data retrieval call binding the contract method 0xa0aaade7.
 //
-// Solidity: function verifyPacketCommitment(address host, string clientId, (uint64,uint64) height, uint64 , uint64 , bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes32 commitmentBytes) view returns(bool)
-func (_Multisigclient *MultisigclientCaller) VerifyPacketCommitment(opts *bind.CallOpts, host common.Address, clientId string, height HeightData, arg3 uint64, arg4 uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, commitmentBytes [32]byte) (bool, error) {
+// Solidity: function verifyPacketCommitment(address host, string clientId, (uint64,uint64) height, uint64 delayPeriodTime, uint64 delayPeriodBlocks, bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes32 commitmentBytes) view returns(bool)
+func (_Multisigclient *MultisigclientCaller) VerifyPacketCommitment(opts *bind.CallOpts, host common.Address, clientId string, height HeightData, delayPeriodTime uint64, delayPeriodBlocks uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, commitmentBytes [32]byte) (bool, error) {
 	var out []interface{}
-	err := _Multisigclient.contract.Call(opts, &out, "verifyPacketCommitment", host, clientId, height, arg3, arg4, prefix, proof, portId, channelId, sequence, commitmentBytes)
+	err := _Multisigclient.contract.Call(opts, &out, "verifyPacketCommitment", host, clientId, height, delayPeriodTime, delayPeriodBlocks, prefix, proof, portId, channelId, sequence, commitmentBytes)
 
 	if err != nil {
 		return *new(bool), err
@@ -737,21 +821,21 @@ func (_Multisigclient *MultisigclientCaller) VerifyPacketCommitment(opts *bind.C
 
 // VerifyPacketCommitment is a free data retrieval call binding the contract method 0xa0aaade7.
 //
-// Solidity: function verifyPacketCommitment(address host, string clientId, (uint64,uint64) height, uint64 , uint64 , bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes32 commitmentBytes) view returns(bool)
-func (_Multisigclient *MultisigclientSession) VerifyPacketCommitment(host common.Address, clientId string, height HeightData, arg3 uint64, arg4 uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, commitmentBytes [32]byte) (bool, error) {
-	return _Multisigclient.Contract.VerifyPacketCommitment(&_Multisigclient.CallOpts, host, clientId, height, arg3, arg4, prefix, proof, portId, channelId, sequence, commitmentBytes)
+// Solidity: function verifyPacketCommitment(address host, string clientId, (uint64,uint64) height, uint64 delayPeriodTime, uint64 delayPeriodBlocks, bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes32 commitmentBytes) view returns(bool)
+func (_Multisigclient *MultisigclientSession) VerifyPacketCommitment(host common.Address, clientId string, height HeightData, delayPeriodTime uint64, delayPeriodBlocks uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, commitmentBytes [32]byte) (bool, error) {
+	return _Multisigclient.Contract.VerifyPacketCommitment(&_Multisigclient.CallOpts, host, clientId, height, delayPeriodTime, delayPeriodBlocks, prefix, proof, portId, channelId, sequence, commitmentBytes)
 }
 
 // VerifyPacketCommitment is a free data retrieval call binding the contract method 0xa0aaade7.
 //
-// Solidity: function verifyPacketCommitment(address host, string clientId, (uint64,uint64) height, uint64 , uint64 , bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes32 commitmentBytes) view returns(bool)
-func (_Multisigclient *MultisigclientCallerSession) VerifyPacketCommitment(host common.Address, clientId string, height HeightData, arg3 uint64, arg4 uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, commitmentBytes [32]byte) (bool, error) {
-	return _Multisigclient.Contract.VerifyPacketCommitment(&_Multisigclient.CallOpts, host, clientId, height, arg3, arg4, prefix, proof, portId, channelId, sequence, commitmentBytes)
+// Solidity: function verifyPacketCommitment(address host, string clientId, (uint64,uint64) height, uint64 delayPeriodTime, uint64 delayPeriodBlocks, bytes prefix, bytes proof, string portId, string channelId, uint64 sequence, bytes32 commitmentBytes) view returns(bool)
+func (_Multisigclient *MultisigclientCallerSession) VerifyPacketCommitment(host common.Address, clientId string, height HeightData, delayPeriodTime uint64, delayPeriodBlocks uint64, prefix []byte, proof []byte, portId string, channelId string, sequence uint64, commitmentBytes [32]byte) (bool, error) {
+	return _Multisigclient.Contract.VerifyPacketCommitment(&_Multisigclient.CallOpts, host, clientId, height, delayPeriodTime, delayPeriodBlocks, prefix, proof, portId, channelId, sequence, commitmentBytes)
 }
 
-// VerifySignature is a free data retrieval call binding the contract method 0xe332d75c.
+// VerifySignature is a free data retrieval call binding the contract method 0x947d53e8.
 //
-// Solidity: function verifySignature((bytes[],string,uint64) consensusState, (bytes[],uint64) multisig, bytes signBytes) pure returns(bool)
+// Solidity: function verifySignature((bytes[],string,uint64,uint64,uint64[]) consensusState, (bytes[],uint64,bytes,uint8,bytes,(bytes,uint64,uint64,bytes[])) multisig, bytes signBytes) pure returns(bool)
 func (_Multisigclient *MultisigclientCaller) VerifySignature(opts *bind.CallOpts, consensusState ConsensusStateData, multisig MultiSignatureData, signBytes []byte) (bool, error) {
 	var out []interface{}
 	err := _Multisigclient.contract.Call(opts, &out, "verifySignature", consensusState, multisig, signBytes)
@@ -766,16 +850,16 @@ func (_Multisigclient *MultisigclientCaller) VerifySignature(opts *bind.CallOpts
 
 }
 
-// VerifySignature is a free data retrieval call binding the contract method 0xe332d75c.
+// VerifySignature is a free data retrieval call binding the contract method 0x947d53e8.
 //
-// Solidity: function verifySignature((bytes[],string,uint64) consensusState, (bytes[],uint64) multisig, bytes signBytes) pure returns(bool)
+// Solidity: function verifySignature((bytes[],string,uint64,uint64,uint64[]) consensusState, (bytes[],uint64,bytes,uint8,bytes,(bytes,uint64,uint64,bytes[])) multisig, bytes signBytes) pure returns(bool)
 func (_Multisigclient *MultisigclientSession) VerifySignature(consensusState ConsensusStateData, multisig MultiSignatureData, signBytes []byte) (bool, error) {
 	return _Multisigclient.Contract.VerifySignature(&_Multisigclient.CallOpts, consensusState, multisig, signBytes)
 }
 
-// VerifySignature is a free data retrieval call binding the contract method 0xe332d75c.
+// VerifySignature is a free data retrieval call binding the contract method 0x947d53e8.
 //
-// Solidity: function verifySignature((bytes[],string,uint64) consensusState, (bytes[],uint64) multisig, bytes signBytes) pure returns(bool)
+// Solidity: function verifySignature((bytes[],string,uint64,uint64,uint64[]) consensusState, (bytes[],uint64,bytes,uint8,bytes,(bytes,uint64,uint64,bytes[])) multisig, bytes signBytes) pure returns(bool)
 func (_Multisigclient *MultisigclientCallerSession) VerifySignature(consensusState ConsensusStateData, multisig MultiSignatureData, signBytes []byte) (bool, error) {
 	return _Multisigclient.Contract.VerifySignature(&_Multisigclient.CallOpts, consensusState, multisig, signBytes)
 }
@@ -2,8 +2,8 @@ package testing
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"crypto/sha256"
+	"math/big"
 	"testing"
 	"time"
 
@@ -14,6 +14,7 @@ import (
 	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
 	"github.com/cosmos/ibc-go/modules/core/23-commitment/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gogo/protobuf/proto"
 	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchost"
 	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/ibc/client"
@@ -52,26 +53,14 @@ func (suite *ETHMultisigTestSuite) TestMultisig() {
 	proofHeight := clienttypes.NewHeight(0, 1)
 	prefix := []byte("ibc")
 
-	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, []*ecdsa.PrivateKey{suite.chain.prvKey(0)}, prefix)
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.chain.prvKey(0)), prefix)
 
 	consensusState := makeMultisigConsensusState(
 		[]common.Address{suite.chain.CallOpts(ctx, 0).From},
 		diversifier,
 		uint64(time.Now().UnixNano()),
 	)
-	anyConsensusStateBytes, err := suite.cdc.MarshalInterface(consensusState)
-	suite.Require().NoError(err)
-	err = suite.chain.TxSyncIfNoError(ctx)(
-		suite.chain.ibcHost.SetConsensusState(
-			suite.chain.TxOpts(ctx, 0),
-			clientID,
-			ibchost.HeightData{
-				RevisionNumber: 0,
-				RevisionHeight: 1,
-			},
-			anyConsensusStateBytes,
-		))
-	suite.Require().NoError(err)
+	suite.setupClient(ctx, clientID, makeMultisigClientState(1), consensusState)
 
 	// VerifyClientState
 	{
@@ -202,8 +191,10 @@ func (suite *ETHMultisigTestSuite) TestMultisig() {
 		suite.Require().True(ok)
 	}
 
-	// VerifyPacketCommitment
-	{
+	// VerifyPacketCommitment with both the legacy and the compact proofs
+	for _, version := range []ethmultisigtypes.MultiSignature_Version{ethmultisigtypes.LEGACY, ethmultisigtypes.COMPACT} {
+		prover, err := prover.WithProofVersion(version)
+		suite.Require().NoError(err)
 		commitment := sha256.Sum256([]byte("test"))
 		proof, _, err := prover.SignPacketState(proofHeight, portID, channelID, 1, commitment[:])
 		suite.Require().NoError(err)
@@ -248,6 +239,124 @@ func (suite *ETHMultisigTestSuite) TestMultisig() {
 	}
 }
 
+func (suite *ETHMultisigTestSuite) TestMultisigSignatureTimestamp() {
+	ctx := context.TODO()
+
+	const (
+		diversifier       = "tester"
+		clientID          = "testclient-1"
+		portID, channelID = "port-0", "channel-0"
+	)
+	proofHeight := clienttypes.NewHeight(0, 1)
+	prefix := []byte("ibc")
+
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.chain.prvKey(0)), prefix)
+	clientState := makeMultisigClientState(1)
+	clientState.MaxClockDrift = uint64(time.Minute)
+	clientState.MaxSignatureAge = uint64(time.Hour)
+	suite.setupClient(ctx, clientID, clientState, makeMultisigConsensusState(
+		[]common.Address{suite.chain.CallOpts(ctx, 0).From},
+		diversifier,
+		uint64(time.Now().Add(-2*time.Hour).UnixNano()),
+	))
+	header, err := suite.chain.ETHClient.HeaderByNumber(ctx, nil)
+	suite.Require().NoError(err)
+	blockTime := time.Unix(int64(header.Time), 0)
+
+	commitment := sha256.Sum256([]byte("test"))
+	verify := func(signedAt time.Time) bool {
+		proof, _, err := prover.WithClock(func() time.Time { return signedAt }).SignPacketState(proofHeight, portID, channelID, 1, commitment[:])
+		suite.Require().NoError(err)
+		proofBytes, err := proto.Marshal(proof)
+		suite.Require().NoError(err)
+		ok, err := suite.chain.multisigClient.VerifyPacketCommitment(
+			suite.chain.CallOpts(ctx, 0),
+			suite.chain.ContractConfig.GetIBCHostAddress(),
+			clientID,
+			multisigclient.HeightData{
+				RevisionNumber: 0,
+				RevisionHeight: 1,
+			},
+			0, 0,
+			prefix, proofBytes, portID, channelID, 1, commitment,
+		)
+		suite.Require().NoError(err)
+		return ok
+	}
+
+	suite.Require().True(verify(blockTime))
+	// future-dated signatures are rejected
+	suite.Require().False(verify(blockTime.Add(time.Hour)))
+	// stale signatures are rejected
+	suite.Require().False(verify(blockTime.Add(-90 * time.Minute)))
+}
+
+func (suite *ETHMultisigTestSuite) TestMultisigConsensusHeight() {
+	ctx := context.TODO()
+
+	const (
+		diversifier          = "tester"
+		clientID             = "testclient-2"
+		counterpartyClientID = "testcounterparty-0"
+	)
+	prefix := []byte("ibc")
+
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.chain.prvKey(0)), prefix)
+	consensusState := makeMultisigConsensusState(
+		[]common.Address{suite.chain.CallOpts(ctx, 0).From},
+		diversifier,
+		uint64(time.Now().UnixNano()),
+	)
+	suite.setupClient(ctx, clientID, makeMultisigClientState(5), consensusState)
+	// a consensus state below the latest height, which only the Go client can have after an update
+	anyConsensusStateBytes, err := suite.cdc.MarshalInterface(consensusState)
+	suite.Require().NoError(err)
+	err = suite.chain.TxSyncIfNoError(ctx)(
+		suite.chain.ibcHost.SetConsensusState(suite.chain.TxOpts(ctx, 0), clientID, ibchost.HeightData{RevisionNumber: 0, RevisionHeight: 1}, anyConsensusStateBytes),
+	)
+	suite.Require().NoError(err)
+
+	verify := func(height uint64) (bool, error) {
+		targetClientState := makeMultisigClientState(1)
+		proofClient, _, err := prover.SignClientState(clienttypes.NewHeight(0, height), counterpartyClientID, targetClientState)
+		suite.Require().NoError(err)
+		anyClientStateBytes, err := suite.cdc.MarshalInterface(targetClientState)
+		suite.Require().NoError(err)
+		proofBytes, err := proto.Marshal(proofClient)
+		suite.Require().NoError(err)
+		return suite.chain.multisigClient.VerifyClientState(
+			suite.chain.CallOpts(ctx, 0),
+			suite.chain.ContractConfig.GetIBCHostAddress(),
+			clientID,
+			multisigclient.HeightData{
+				RevisionNumber: 0,
+				RevisionHeight: height,
+			}, prefix, counterpartyClientID, proofBytes, anyClientStateBytes,
+		)
+	}
+
+	// the proofs are verified with the consensus state at their height or the latest one above the latest height
+	for _, height := range []uint64{1, 5, 8} {
+		ok, err := verify(height)
+		suite.Require().NoError(err)
+		suite.Require().True(ok)
+	}
+	// a proof between the consensus states is rejected as the Go client does
+	_, err = verify(3)
+	suite.Require().Error(err)
+}
+
+func (suite *ETHMultisigTestSuite) TestMultisigSignatureVectors() {
+	ctx := context.TODO()
+	for _, v := range loadSignatureVectors(suite.T()) {
+		var hash [32]byte
+		copy(hash[:], crypto.Keccak256(v.SignBytes))
+		signer, err := suite.chain.multisigClient.RecoverSigner(suite.chain.CallOpts(ctx, 0), hash, []byte(v.Signature))
+		suite.Require().NoError(err, v.Name)
+		suite.Require().Equal(v.Valid, signer == v.Signer, v.Name)
+	}
+}
+
 func (suite *ETHMultisigTestSuite) TestMultisigSign() {
 	const (
 		diversifier          = "tester"
@@ -257,7 +366,7 @@ func (suite *ETHMultisigTestSuite) TestMultisigSign() {
 	proofHeight := clienttypes.NewHeight(0, 1)
 	prefix := []byte("ibc")
 
-	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, []*ecdsa.PrivateKey{suite.chain.prvKey(0)}, prefix)
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.chain.prvKey(0)), prefix)
 
 	targetClientState := makeMultisigClientState(1)
 	proofClient, signBytes, err := prover.SignClientState(proofHeight, counterpartyClientID, targetClientState)
@@ -267,6 +376,38 @@ func (suite *ETHMultisigTestSuite) TestMultisigSign() {
 	suite.Require().NoError(err)
 }
 
+// setupClient stores the client state, the consensus state at its latest height and their processed time and height in the IBC host
+func (suite *ETHMultisigTestSuite) setupClient(ctx context.Context, clientID string, clientState *ethmultisigtypes.ClientState, consensusState *ethmultisigtypes.ConsensusState) {
+	height := ibchost.HeightData{
+		RevisionNumber: clientState.LatestHeight.RevisionNumber,
+		RevisionHeight: clientState.LatestHeight.RevisionHeight,
+	}
+	anyClientStateBytes, err := suite.cdc.MarshalInterface(clientState)
+	suite.Require().NoError(err)
+	err = suite.chain.TxSyncIfNoError(ctx)(
+		suite.chain.ibcHost.SetClientState(suite.chain.TxOpts(ctx, 0), clientID, anyClientStateBytes),
+	)
+	suite.Require().NoError(err)
+	anyConsensusStateBytes, err := suite.cdc.MarshalInterface(consensusState)
+	suite.Require().NoError(err)
+	err = suite.chain.TxSyncIfNoError(ctx)(
+		suite.chain.ibcHost.SetConsensusState(suite.chain.TxOpts(ctx, 0), clientID, height, anyConsensusStateBytes),
+	)
+	suite.Require().NoError(err)
+
+	// the processed time and height are checked against the delay period of the packet verification
+	header, err := suite.chain.ETHClient.HeaderByNumber(ctx, nil)
+	suite.Require().NoError(err)
+	err = suite.chain.TxSyncIfNoError(ctx)(
+		suite.chain.ibcHost.SetProcessedTime(suite.chain.TxOpts(ctx, 0), clientID, height, new(big.Int).SetUint64(header.Time)),
+	)
+	suite.Require().NoError(err)
+	err = suite.chain.TxSyncIfNoError(ctx)(
+		suite.chain.ibcHost.SetProcessedHeight(suite.chain.TxOpts(ctx, 0), clientID, height, header.Number),
+	)
+	suite.Require().NoError(err)
+}
+
 func makeMultisigClientState(latestHeight uint64) *ethmultisigtypes.ClientState {
 	return &ethmultisigtypes.ClientState{
 		LatestHeight: client.Height{
@@ -279,7 +420,7 @@ func makeMultisigClientState(latestHeight uint64) *ethmultisigtypes.ClientState
 func makeMultisigConsensusState(addresses []common.Address, diversifier string, timestamp uint64) *ethmultisigtypes.ConsensusState {
 	var addrs [][]byte
 	for _, addr := range addresses {
-		addrs = append(addrs, addr[:])
+		addrs = append(addrs, addr.Bytes())
 	}
 	return &ethmultisigtypes.ConsensusState{
 		Addresses:   addrs,
@@ -0,0 +1,1229 @@
+package testing
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/modules/core/24-host"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/suite"
+	dbm "github.com/tendermint/tm-db"
+
+	ethmultisigtypes "github.com/datachainlab/ibc-ethmultisig-client/modules/light-clients/xx-ethmultisig/types"
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig"
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig/wallet"
+)
+
+// LightClientTestSuite tests the light client on a cosmos host without an ethereum chain
+type LightClientTestSuite struct {
+	suite.Suite
+
+	cdc   codec.ProtoCodecMarshaler
+	store sdk.KVStore
+}
+
+func (suite *LightClientTestSuite) SetupTest() {
+	registry := codectypes.NewInterfaceRegistry()
+	ethmultisigtypes.RegisterInterfaces(registry)
+	suite.cdc = codec.NewProtoCodec(registry)
+	suite.store = dbadapter.Store{DB: dbm.NewMemDB()}
+}
+
+func (suite *LightClientTestSuite) TestCheckHeaderAndUpdateState() {
+	const diversifier = "tester"
+	prefix := []byte("ibc")
+
+	keys := suite.prvKeys(0, 1, 2)
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(keys...), prefix)
+	clientState := suite.createClient(prover.Addresses(), diversifier)
+
+	newKeys := suite.prvKeys(3, 4)
+	newProver := ethmultisig.NewETHMultisig(suite.cdc, "tester2", ethmultisig.NewKeySigners(newKeys...), prefix)
+
+	// the header height must be greater than the latest height
+	header, _, err := prover.SignHeader(clienttypes.NewHeight(0, 1), newProver.Addresses(), 0, "tester2")
+	suite.Require().NoError(err)
+	_, _, err = clientState.CheckHeaderAndUpdateState(sdk.Context{}, suite.cdc, suite.store, header)
+	suite.Require().Error(err)
+
+	// the header must be signed by the current signers
+	header, _, err = newProver.SignHeader(clienttypes.NewHeight(0, 2), newProver.Addresses(), 0, "tester2")
+	suite.Require().NoError(err)
+	_, _, err = clientState.CheckHeaderAndUpdateState(sdk.Context{}, suite.cdc, suite.store, header)
+	suite.Require().Error(err)
+
+	// the signed header data must not be modified
+	header, _, err = prover.SignHeader(clienttypes.NewHeight(0, 2), newProver.Addresses(), 0, "tester2")
+	suite.Require().NoError(err)
+	header.NewDiversifier = "tester3"
+	_, _, err = clientState.CheckHeaderAndUpdateState(sdk.Context{}, suite.cdc, suite.store, header)
+	suite.Require().Error(err)
+
+	header, _, err = prover.SignHeader(clienttypes.NewHeight(0, 2), newProver.Addresses(), 0, "tester2")
+	suite.Require().NoError(err)
+	newClientState, newConsensusState, err := clientState.CheckHeaderAndUpdateState(sdk.Context{}, suite.cdc, suite.store, header)
+	suite.Require().NoError(err)
+	suite.Require().Equal(clienttypes.NewHeight(0, 2), newClientState.GetLatestHeight())
+	cons := newConsensusState.(*ethmultisigtypes.ConsensusState)
+	suite.Require().Equal(newProver.Addresses(), cons.GetAddresses())
+	suite.Require().Equal("tester2", cons.Diversifier)
+	suite.Require().Equal(header.Timestamp, cons.Timestamp)
+}
+
+func (suite *LightClientTestSuite) TestCheckMisbehaviourAndUpdateState() {
+	const diversifier = "tester"
+	prefix := []byte("ibc")
+
+	keys := suite.prvKeys(0, 1, 2)
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(keys...), prefix)
+	clientState := suite.createClient(prover.Addresses(), diversifier)
+	otherProver := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(3, 4, 5)...), prefix)
+
+	height := clienttypes.NewHeight(0, 5)
+	stateSignatureAndData := func(prover ethmultisig.ETHMultisig, dataType ethmultisigtypes.SignBytes_DataType, path, value []byte) *ethmultisigtypes.SignatureAndData {
+		proof, signBz, err := prover.SignState(height, dataType, path, value)
+		suite.Require().NoError(err)
+		var signBytes ethmultisigtypes.SignBytes
+		suite.Require().NoError(suite.cdc.Unmarshal(signBz, &signBytes))
+		return &ethmultisigtypes.SignatureAndData{Signature: proof, SignBytes: &signBytes}
+	}
+	signatureAndData := func(prover ethmultisig.ETHMultisig, value []byte) *ethmultisigtypes.SignatureAndData {
+		return stateSignatureAndData(prover, ethmultisigtypes.PACKETCOMMITMENT, []byte("path"), value)
+	}
+	newMisbehaviour := func(one, two *ethmultisigtypes.SignatureAndData) *ethmultisigtypes.Misbehaviour {
+		return &ethmultisigtypes.Misbehaviour{
+			ClientId:     "testclient-0",
+			Height:       one.SignBytes.Height,
+			SignatureOne: one,
+			SignatureTwo: two,
+		}
+	}
+
+	// the signed messages must be different
+	sd := signatureAndData(prover, []byte("value0"))
+	suite.Require().Error(newMisbehaviour(sd, sd).ValidateBasic())
+
+	// the different states at the same height are not a misbehaviour,
+	// such as the connection and client states of a connection handshake
+	connection := stateSignatureAndData(prover, ethmultisigtypes.CONNECTION, []byte("connections/connection-0"), []byte("connection"))
+	client := stateSignatureAndData(prover, ethmultisigtypes.CLIENT, []byte("clients/testclient-0/clientState"), []byte("client"))
+	suite.Require().ErrorIs(newMisbehaviour(connection, client).ValidateBasic(), clienttypes.ErrInvalidMisbehaviour)
+	otherConnection := stateSignatureAndData(prover, ethmultisigtypes.CONNECTION, []byte("connections/connection-1"), []byte("other connection"))
+	suite.Require().ErrorIs(newMisbehaviour(connection, otherConnection).ValidateBasic(), clienttypes.ErrInvalidMisbehaviour)
+
+	// the messages must be signed by the current signers
+	misbehaviour := newMisbehaviour(signatureAndData(prover, []byte("value0")), signatureAndData(otherProver, []byte("value1")))
+	suite.Require().NoError(misbehaviour.ValidateBasic())
+	_, err := clientState.CheckMisbehaviourAndUpdateState(sdk.Context{}, suite.cdc, suite.store, misbehaviour)
+	suite.Require().Error(err)
+
+	misbehaviour = newMisbehaviour(signatureAndData(prover, []byte("value0")), signatureAndData(prover, []byte("value1")))
+	suite.Require().NoError(misbehaviour.ValidateBasic())
+	newClientState, err := clientState.CheckMisbehaviourAndUpdateState(sdk.Context{}, suite.cdc, suite.store, misbehaviour)
+	suite.Require().NoError(err)
+	suite.Require().Equal(exported.Frozen, newClientState.Status(sdk.Context{}, suite.store, suite.cdc))
+	suite.Require().Equal(exported.Active, clientState.Status(sdk.Context{}, suite.store, suite.cdc))
+}
+
+func (suite *LightClientTestSuite) TestVerifyConnectionAndChannelState() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	proofHeight := clienttypes.NewHeight(0, 1)
+
+	keys := suite.prvKeys(0, 1, 2)
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(keys...), prefix.Bytes())
+	clientState := suite.createClient(prover.Addresses(), diversifier)
+
+	connection := conntypes.NewConnectionEnd(
+		conntypes.TRYOPEN, "testclient-0",
+		conntypes.NewCounterparty("testcounterparty-0", "connection-1", prefix),
+		[]*conntypes.Version{conntypes.DefaultIBCVersion}, 0,
+	)
+	proof, err := marshalProof(prover.SignConnectionState(proofHeight, "connection-0", connection))
+	suite.Require().NoError(err)
+	suite.Require().NoError(clientState.VerifyConnectionState(suite.store, suite.cdc, proofHeight, &prefix, proof, "connection-0", connection))
+	suite.Require().Error(clientState.VerifyConnectionState(suite.store, suite.cdc, proofHeight, &prefix, proof, "connection-1", connection))
+	connection.State = conntypes.OPEN
+	suite.Require().Error(clientState.VerifyConnectionState(suite.store, suite.cdc, proofHeight, &prefix, proof, "connection-0", connection))
+
+	channel := chantypes.NewChannel(
+		chantypes.TRYOPEN, chantypes.UNORDERED,
+		chantypes.NewCounterparty("transfer", "channel-1"),
+		[]string{"connection-0"}, "ics20-1",
+	)
+	proof, err = marshalProof(prover.SignChannelState(proofHeight, "transfer", "channel-0", channel))
+	suite.Require().NoError(err)
+	suite.Require().NoError(clientState.VerifyChannelState(suite.store, suite.cdc, proofHeight, &prefix, proof, "transfer", "channel-0", channel))
+	suite.Require().Error(clientState.VerifyChannelState(suite.store, suite.cdc, proofHeight, &prefix, proof, "transfer", "channel-1", channel))
+	channel.State = chantypes.OPEN
+	suite.Require().Error(clientState.VerifyChannelState(suite.store, suite.cdc, proofHeight, &prefix, proof, "transfer", "channel-0", channel))
+}
+
+func (suite *LightClientTestSuite) TestSequenceReplayProtection() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), prefix.Bytes())
+	suite.createClient(prover.Addresses(), diversifier)
+
+	channel := chantypes.NewChannel(
+		chantypes.OPEN, chantypes.UNORDERED,
+		chantypes.NewCounterparty("transfer", "channel-1"),
+		[]string{"connection-0"}, "ics20-1",
+	)
+	signChannel := func(sequence uint64, channel chantypes.Channel) []byte {
+		proof, err := marshalProof(prover.SignChannelState(clienttypes.NewHeight(0, sequence), "transfer", "channel-0", channel))
+		suite.Require().NoError(err)
+		return proof
+	}
+	// the client state is loaded from the store for each verification as the keeper does
+	verifyChannel := func(sequence uint64, proof []byte, channel chantypes.Channel) error {
+		return suite.getClientState().VerifyChannelState(suite.store, suite.cdc, clienttypes.NewHeight(0, sequence), &prefix, proof, "transfer", "channel-0", channel)
+	}
+
+	openProof := signChannel(2, channel)
+	suite.Require().NoError(verifyChannel(2, openProof, channel))
+	suite.Require().Equal(uint64(2), suite.getClientState().Sequence)
+
+	// the same proof cannot be verified twice
+	suite.Require().ErrorIs(verifyChannel(2, openProof, channel), ethmultisigtypes.ErrInvalidSequence)
+
+	// proofs of the other data types cannot share the sequence either, as the connection handshake signs its states in a batch
+	connection := conntypes.NewConnectionEnd(
+		conntypes.OPEN, "testclient-0",
+		conntypes.NewCounterparty("testcounterparty-0", "connection-1", prefix),
+		[]*conntypes.Version{conntypes.DefaultIBCVersion}, 0,
+	)
+	proof, err := marshalProof(prover.SignConnectionState(clienttypes.NewHeight(0, 2), "connection-0", connection))
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(suite.getClientState().VerifyConnectionState(suite.store, suite.cdc, clienttypes.NewHeight(0, 2), &prefix, proof, "connection-0", connection), ethmultisigtypes.ErrInvalidSequence)
+
+	// the earlier OPEN state cannot be reused after the channel is closed
+	closedChannel := channel
+	closedChannel.State = chantypes.CLOSED
+	suite.Require().NoError(verifyChannel(4, signChannel(4, closedChannel), closedChannel))
+	suite.Require().ErrorIs(verifyChannel(2, openProof, channel), ethmultisigtypes.ErrInvalidSequence)
+	suite.Require().ErrorIs(verifyChannel(3, signChannel(3, channel), channel), ethmultisigtypes.ErrInvalidSequence)
+
+	// a newer sequence must not have an older timestamp
+	msg, _, err := prover.SignChannelState(clienttypes.NewHeight(0, 5), "transfer", "channel-0", channel)
+	suite.Require().NoError(err)
+	msg.Timestamp = suite.getClientState().Timestamp - 1
+	proof, err = proto.Marshal(msg)
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(verifyChannel(5, proof, channel), ethmultisigtypes.ErrInvalidTimestamp)
+
+	// a failed verification does not consume the sequence
+	suite.Require().Error(verifyChannel(5, signChannel(5, channel), closedChannel))
+	suite.Require().Equal(uint64(4), suite.getClientState().Sequence)
+	suite.Require().NoError(verifyChannel(5, signChannel(5, channel), channel))
+
+	// the proof height must be at the revision of the latest height
+	proofHeight := clienttypes.NewHeight(1, 7)
+	proof, err = marshalProof(prover.SignChannelState(proofHeight, "transfer", "channel-0", channel))
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(suite.getClientState().VerifyChannelState(suite.store, suite.cdc, proofHeight, &prefix, proof, "transfer", "channel-0", channel), ethmultisigtypes.ErrInvalidSequence)
+}
+
+func (suite *LightClientTestSuite) TestVerifyPacketCommitmentAndAcknowledgement() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	proofHeight := clienttypes.NewHeight(0, 1)
+
+	keys := suite.prvKeys(0, 1, 2)
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(keys...), prefix.Bytes())
+	clientState := suite.createClient(prover.Addresses(), diversifier)
+
+	commitment := sha256.Sum256([]byte("packet"))
+	proof, err := marshalProof(prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:]))
+	suite.Require().NoError(err)
+	suite.Require().NoError(clientState.VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1, commitment[:]))
+	suite.Require().Error(clientState.VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 2, commitment[:]))
+
+	ack := []byte("ack")
+	proof, err = marshalProof(prover.SignPacketAcknowledgementState(proofHeight, "transfer", "channel-0", 1, chantypes.CommitAcknowledgement(ack)))
+	suite.Require().NoError(err)
+	suite.Require().NoError(clientState.VerifyPacketAcknowledgement(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1, ack))
+	suite.Require().Error(clientState.VerifyPacketAcknowledgement(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1, []byte("other")))
+}
+
+func (suite *LightClientTestSuite) TestVerifyPacketReceiptAbsenceAndNextSequenceRecv() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	proofHeight := clienttypes.NewHeight(0, 1)
+
+	keys := suite.prvKeys(0, 1, 2)
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(keys...), prefix.Bytes())
+	clientState := suite.createClient(prover.Addresses(), diversifier)
+
+	proof, err := marshalProof(prover.SignPacketReceiptAbsence(proofHeight, "transfer", "channel-0", 1))
+	suite.Require().NoError(err)
+	suite.Require().NoError(clientState.VerifyPacketReceiptAbsence(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1))
+	suite.Require().Error(clientState.VerifyPacketReceiptAbsence(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 2))
+
+	proof, err = marshalProof(prover.SignNextSequenceRecv(proofHeight, "transfer", "channel-0", 3))
+	suite.Require().NoError(err)
+	suite.Require().NoError(clientState.VerifyNextSequenceRecv(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 3))
+	suite.Require().Error(clientState.VerifyNextSequenceRecv(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 4))
+}
+
+func (suite *LightClientTestSuite) TestVerifyPacketDelayPeriod() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	commitment := sha256.Sum256([]byte("packet"))
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), prefix.Bytes())
+	processedTime := time.Now()
+	ctx := sdk.Context{}.WithBlockTime(processedTime).WithBlockHeight(10)
+
+	// the consensus state is created at the block time and height of ctx
+	clientState := makeMultisigClientState(1)
+	consensusState := makeMultisigConsensusState(prover.Addresses(), diversifier, uint64(processedTime.UnixNano()))
+	suite.setConsensusState(clientState.GetLatestHeight(), consensusState)
+	suite.Require().NoError(clientState.Initialize(ctx, suite.cdc, suite.store, consensusState))
+
+	verify := func(ctx sdk.Context, height uint64, delayTimePeriod, delayBlockPeriod uint64) error {
+		proofHeight := clienttypes.NewHeight(0, height)
+		proof, err := marshalProof(prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:]))
+		suite.Require().NoError(err)
+		return clientState.VerifyPacketCommitment(ctx, suite.store, suite.cdc, proofHeight, delayTimePeriod, delayBlockPeriod, &prefix, proof, "transfer", "channel-0", 1, commitment[:])
+	}
+
+	// the delay periods are inclusive
+	delayTimePeriod := uint64(time.Hour)
+	suite.Require().NoError(verify(ctx, 1, 0, 0))
+	suite.Require().ErrorIs(verify(ctx.WithBlockHeight(20).WithBlockTime(processedTime.Add(time.Hour-1)), 1, delayTimePeriod, 0), ethmultisigtypes.ErrDelayPeriodNotPassed)
+	suite.Require().NoError(verify(ctx.WithBlockHeight(20).WithBlockTime(processedTime.Add(time.Hour)), 1, delayTimePeriod, 0))
+	suite.Require().ErrorIs(verify(ctx.WithBlockHeight(14).WithBlockTime(processedTime.Add(time.Hour)), 1, 0, 5), ethmultisigtypes.ErrDelayPeriodNotPassed)
+	suite.Require().NoError(verify(ctx.WithBlockHeight(15).WithBlockTime(processedTime.Add(time.Hour)), 1, 0, 5))
+
+	// a proof above the latest height is delayed by the latest consensus state
+	suite.Require().ErrorIs(verify(ctx.WithBlockHeight(14), 2, 0, 5), ethmultisigtypes.ErrDelayPeriodNotPassed)
+	suite.Require().NoError(verify(ctx.WithBlockHeight(15), 2, 0, 5))
+
+	// the delay period restarts from the consensus state created by an update
+	header, _, err := prover.SignHeader(clienttypes.NewHeight(0, 3), prover.Addresses(), 0, diversifier)
+	suite.Require().NoError(err)
+	updateCtx := ctx.WithBlockHeight(20).WithBlockTime(processedTime.Add(time.Hour))
+	newClientState, newConsensusState, err := clientState.CheckHeaderAndUpdateState(updateCtx, suite.cdc, suite.store, header)
+	suite.Require().NoError(err)
+	suite.setConsensusState(header.GetHeight(), newConsensusState.(*ethmultisigtypes.ConsensusState))
+	clientState = newClientState.(*ethmultisigtypes.ClientState)
+	suite.Require().ErrorIs(verify(updateCtx, 4, delayTimePeriod, 0), ethmultisigtypes.ErrDelayPeriodNotPassed)
+	suite.Require().ErrorIs(verify(updateCtx.WithBlockTime(processedTime.Add(2*time.Hour)), 4, delayTimePeriod, 5), ethmultisigtypes.ErrDelayPeriodNotPassed)
+	suite.Require().NoError(verify(updateCtx.WithBlockHeight(25).WithBlockTime(processedTime.Add(2*time.Hour)), 4, delayTimePeriod, 5))
+
+	// a consensus state without the processed time cannot be used with a delay period
+	suite.store.Delete(ethmultisigtypes.ProcessedTimeKey(clienttypes.NewHeight(0, 3)))
+	suite.Require().ErrorIs(verify(updateCtx.WithBlockHeight(25).WithBlockTime(processedTime.Add(2*time.Hour)), 4, delayTimePeriod, 5), ethmultisigtypes.ErrProcessedTimeNotFound)
+}
+
+func (suite *LightClientTestSuite) TestClientExpiry() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	commitment := sha256.Sum256([]byte("packet"))
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), prefix.Bytes())
+	clientState := suite.createClient(prover.Addresses(), diversifier)
+	clientState.TrustingPeriod = uint64(time.Hour)
+	consensusState, err := clienttypes.UnmarshalConsensusState(suite.cdc, suite.store.Get(host.ConsensusStateKey(clientState.GetLatestHeight())))
+	suite.Require().NoError(err)
+	latestTime := time.Unix(0, int64(consensusState.GetTimestamp()))
+
+	// the trusting period is exclusive
+	suite.Require().Equal(exported.Active, clientState.Status(sdk.Context{}.WithBlockTime(latestTime.Add(time.Hour-1)), suite.store, suite.cdc))
+	expiredCtx := sdk.Context{}.WithBlockTime(latestTime.Add(time.Hour))
+	suite.Require().Equal(exported.Expired, clientState.Status(expiredCtx, suite.store, suite.cdc))
+
+	// an expired client can neither verify packets nor be updated
+	proofHeight := clienttypes.NewHeight(0, 1)
+	proof, err := marshalProof(prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:]))
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(clientState.VerifyPacketCommitment(expiredCtx, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1, commitment[:]), clienttypes.ErrClientNotActive)
+	header, _, err := prover.SignHeader(clienttypes.NewHeight(0, 2), prover.Addresses(), 0, diversifier)
+	suite.Require().NoError(err)
+	_, _, err = clientState.CheckHeaderAndUpdateState(expiredCtx, suite.cdc, suite.store, header)
+	suite.Require().Error(err)
+
+	// an update before the expiry extends the trusting period from the header timestamp
+	activeCtx := sdk.Context{}.WithBlockTime(latestTime.Add(time.Hour - 1))
+	newClientState, newConsensusState, err := clientState.CheckHeaderAndUpdateState(activeCtx, suite.cdc, suite.store, header)
+	suite.Require().NoError(err)
+	suite.setConsensusState(header.GetHeight(), newConsensusState.(*ethmultisigtypes.ConsensusState))
+	headerTime := time.Unix(0, int64(header.Timestamp))
+	suite.Require().Equal(exported.Active, newClientState.Status(sdk.Context{}.WithBlockTime(headerTime.Add(time.Hour-1)), suite.store, suite.cdc))
+	suite.Require().Equal(exported.Expired, newClientState.Status(sdk.Context{}.WithBlockTime(headerTime.Add(time.Hour)), suite.store, suite.cdc))
+
+	// a zero trusting period never expires
+	clientState.TrustingPeriod = 0
+	suite.Require().Equal(exported.Active, clientState.Status(sdk.Context{}.WithBlockTime(latestTime.Add(24*365*time.Hour)), suite.store, suite.cdc))
+}
+
+func (suite *LightClientTestSuite) TestSignatureTimestampWindow() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	commitment := sha256.Sum256([]byte("packet"))
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), prefix.Bytes())
+	clientState := makeMultisigClientState(1)
+	clientState.MaxClockDrift = uint64(time.Minute)
+	clientState.MaxSignatureAge = uint64(time.Hour)
+	// the signatures must not be older than the initial consensus state
+	blockTime := time.Now().Add(2 * time.Hour)
+	ctx := sdk.Context{}.WithBlockTime(blockTime)
+	// the processed time of the initial consensus state is the block time
+	consensusState := makeMultisigConsensusState(prover.Addresses(), diversifier, uint64(time.Now().UnixNano()))
+	suite.setConsensusState(clientState.GetLatestHeight(), consensusState)
+	suite.Require().NoError(clientState.Initialize(ctx, suite.cdc, suite.store, consensusState))
+
+	verify := func(signedAt time.Time) error {
+		proofHeight := clienttypes.NewHeight(0, 1)
+		proof, err := marshalProof(prover.WithClock(func() time.Time { return signedAt }).SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:]))
+		suite.Require().NoError(err)
+		return clientState.VerifyPacketCommitment(ctx, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1, commitment[:])
+	}
+
+	// the window is inclusive
+	suite.Require().NoError(verify(blockTime.Add(time.Minute)))
+	suite.Require().ErrorIs(verify(blockTime.Add(time.Minute+1)), ethmultisigtypes.ErrInvalidTimestamp)
+	suite.Require().ErrorIs(verify(blockTime.Add(-time.Hour-1)), ethmultisigtypes.ErrInvalidTimestamp)
+
+	// a future-dated header cannot push the time of the client forward
+	header, _, err := prover.WithClock(func() time.Time { return blockTime.Add(time.Hour) }).SignHeader(clienttypes.NewHeight(0, 2), prover.Addresses(), 0, diversifier)
+	suite.Require().NoError(err)
+	_, _, err = clientState.CheckHeaderAndUpdateState(ctx, suite.cdc, suite.store, header)
+	suite.Require().Error(err)
+	header, _, err = prover.WithClock(func() time.Time { return blockTime }).SignHeader(clienttypes.NewHeight(0, 2), prover.Addresses(), 0, diversifier)
+	suite.Require().NoError(err)
+	_, _, err = clientState.CheckHeaderAndUpdateState(ctx, suite.cdc, suite.store, header)
+	suite.Require().NoError(err)
+}
+
+func (suite *LightClientTestSuite) TestSignatureVectors() {
+	for _, v := range loadSignatureVectors(suite.T()) {
+		multiSig := &ethmultisigtypes.MultiSignature{Signatures: [][]byte{v.Signature}}
+		err := ethmultisigtypes.VerifySignature([]common.Address{v.Signer}, multiSig, v.SignBytes)
+		if v.Valid {
+			suite.Require().NoError(err, v.Name)
+		} else {
+			suite.Require().Error(err, v.Name)
+		}
+	}
+}
+
+func (suite *LightClientTestSuite) TestThresholdSignature() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	proofHeight := clienttypes.NewHeight(0, 1)
+
+	signers := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2, 3, 4)...), prefix.Bytes()).Addresses()
+	clientState := suite.createClient(signers, diversifier)
+	consensusState := makeMultisigConsensusState(signers, diversifier, uint64(time.Now().UnixNano()))
+	consensusState.Threshold = 3
+	suite.setConsensusState(proofHeight, consensusState)
+
+	commitment := sha256.Sum256([]byte("packet"))
+	verify := func(prover ethmultisig.ETHMultisig) error {
+		proof, err := marshalProof(prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:]))
+		suite.Require().NoError(err)
+		return clientState.VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1, commitment[:])
+	}
+	newProver := func(indexes ...uint32) ethmultisig.ETHMultisig {
+		prover, err := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(indexes...)...), prefix.Bytes()).WithSignerSet(signers)
+		suite.Require().NoError(err)
+		return prover
+	}
+
+	// any quorum of the signers is accepted regardless of the key order
+	suite.Require().NoError(verify(newProver(0, 2, 4)))
+	suite.Require().NoError(verify(newProver(4, 3, 1)))
+	suite.Require().NoError(verify(newProver(0, 1, 2, 3, 4)))
+	// a proof without a signer bitmap must be signed by every signer
+	suite.Require().NoError(verify(ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2, 3, 4)...), prefix.Bytes())))
+	suite.Require().Error(verify(ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), prefix.Bytes())))
+
+	// the number of signers must reach the threshold
+	suite.Require().Error(verify(newProver(0, 1)))
+
+	// a signer outside of the signer set cannot be a part of the set
+	_, err := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 5)...), prefix.Bytes()).WithSignerSet(signers)
+	suite.Require().Error(err)
+
+	// a signer cannot be counted twice
+	proof, _, err := newProver(0, 1, 2).SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:])
+	suite.Require().NoError(err)
+	proof.Signatures[1] = proof.Signatures[0]
+	bz, err := proto.Marshal(proof)
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(clientState.VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, bz, "transfer", "channel-0", 1, commitment[:]), ethmultisigtypes.ErrInvalidSignature)
+	_, err = ethmultisigtypes.NewSignerBitmap(len(signers), []int{0, 0, 1})
+	suite.Require().ErrorIs(err, ethmultisigtypes.ErrDuplicateSigner)
+
+	// duplicate addresses in the signer set are rejected
+	consensusState = makeMultisigConsensusState(append(signers, signers[0]), diversifier, uint64(time.Now().UnixNano()))
+	consensusState.Threshold = 3
+	suite.setConsensusState(proofHeight, consensusState)
+	suite.Require().Error(verify(ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2, 3, 4, 0)...), prefix.Bytes())))
+}
+
+func (suite *LightClientTestSuite) TestWeightedSignature() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+
+	signers := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2, 3)...), prefix.Bytes()).Addresses()
+	clientState := suite.createClient(signers, diversifier)
+	consensusState := makeMultisigConsensusState(signers, diversifier, uint64(time.Now().UnixNano()))
+	consensusState.Powers = []uint64{5, 3, 1, 1}
+	consensusState.Threshold = 6
+	suite.Require().NoError(consensusState.ValidateBasic())
+	suite.setConsensusState(clientState.GetLatestHeight(), consensusState)
+
+	newProver := func(indexes ...uint32) ethmultisig.ETHMultisig {
+		prover, err := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(indexes...)...), prefix.Bytes()).WithSignerSet(signers)
+		suite.Require().NoError(err)
+		return prover
+	}
+	commitment := sha256.Sum256([]byte("packet"))
+	verify := func(prover ethmultisig.ETHMultisig, proofHeight clienttypes.Height) error {
+		proof, err := marshalProof(prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:]))
+		suite.Require().NoError(err)
+		return suite.getClientState().VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1, commitment[:])
+	}
+
+	// the signatures pass once their voting powers add up to the threshold
+	suite.Require().ErrorIs(verify(newProver(1, 2, 3), clienttypes.NewHeight(0, 1)), ethmultisigtypes.ErrInsufficientPower)
+	suite.Require().ErrorIs(verify(newProver(0), clienttypes.NewHeight(0, 1)), ethmultisigtypes.ErrInsufficientPower)
+	suite.Require().NoError(verify(newProver(0, 2), clienttypes.NewHeight(0, 1)))
+
+	// a header can change the voting powers
+	header, _, err := newProver(0, 1).SignWeightedHeader(clienttypes.NewHeight(0, 2), signers, []uint64{1, 1, 1, 7}, 7, diversifier)
+	suite.Require().NoError(err)
+	// the voting powers are a part of the sign bytes
+	header.NewPowers = []uint64{7, 1, 1, 1}
+	_, _, err = suite.getClientState().CheckHeaderAndUpdateState(sdk.Context{}, suite.cdc, suite.store, header)
+	suite.Require().Error(err)
+	header.NewPowers = []uint64{1, 1, 1, 7}
+	suite.updateClient(suite.getClientState(), header)
+	suite.Require().ErrorIs(verify(newProver(0, 1, 2), clienttypes.NewHeight(0, 3)), ethmultisigtypes.ErrInsufficientPower)
+	suite.Require().NoError(verify(newProver(3), clienttypes.NewHeight(0, 3)))
+
+	// a zero threshold requires the total power
+	header, _, err = newProver(3).SignWeightedHeader(clienttypes.NewHeight(0, 4), signers, []uint64{1, 1, 1, 7}, 0, diversifier)
+	suite.Require().NoError(err)
+	suite.updateClient(suite.getClientState(), header)
+	suite.Require().ErrorIs(verify(newProver(0, 1, 3), clienttypes.NewHeight(0, 5)), ethmultisigtypes.ErrInsufficientPower)
+	suite.Require().NoError(verify(newProver(0, 1, 2, 3), clienttypes.NewHeight(0, 5)))
+
+	// the voting powers must match the addresses and be positive, and the threshold must not exceed the total power
+	for _, tc := range []struct {
+		powers    []uint64
+		threshold uint64
+		expErr    error
+	}{
+		{[]uint64{1, 1, 1}, 0, ethmultisigtypes.ErrInvalidSignerSet},
+		{[]uint64{1, 1, 0, 1}, 0, ethmultisigtypes.ErrInvalidSignerSet},
+		{[]uint64{1, 1, 1, ^uint64(0)}, 0, ethmultisigtypes.ErrInvalidSignerSet},
+		{[]uint64{1, 1, 1, 7}, 11, ethmultisigtypes.ErrInvalidThreshold},
+	} {
+		consensusState.Powers, consensusState.Threshold = tc.powers, tc.threshold
+		suite.Require().ErrorIs(consensusState.ValidateBasic(), tc.expErr)
+		header, _, err := newProver(0, 1, 2, 3).SignWeightedHeader(clienttypes.NewHeight(0, 4), signers, tc.powers, tc.threshold, diversifier)
+		suite.Require().NoError(err)
+		suite.Require().ErrorIs(header.ValidateBasic(), tc.expErr)
+	}
+}
+
+func (suite *LightClientTestSuite) TestCompactMultiSignature() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	proofHeight := clienttypes.NewHeight(0, 1)
+
+	signers := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), prefix.Bytes()).Addresses()
+	clientState := suite.createClient(signers, diversifier)
+	consensusState := makeMultisigConsensusState(signers, diversifier, uint64(time.Now().UnixNano()))
+	consensusState.Threshold = 2
+	suite.setConsensusState(proofHeight, consensusState)
+
+	prover, err := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 2)...), prefix.Bytes()).WithSignerSet(signers)
+	suite.Require().NoError(err)
+	compactProver, err := prover.WithProofVersion(ethmultisigtypes.COMPACT)
+	suite.Require().NoError(err)
+	_, err = prover.WithProofVersion(2)
+	suite.Require().Error(err)
+
+	commitment := sha256.Sum256([]byte("packet"))
+	legacy, signBytes, err := prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:])
+	suite.Require().NoError(err)
+	compact, _, err := compactProver.WithClock(func() time.Time { return time.Unix(0, int64(legacy.Timestamp)) }).SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:])
+	suite.Require().NoError(err)
+	suite.Require().Equal(ethmultisigtypes.COMPACT, compact.Version)
+	suite.Require().Empty(compact.Signatures)
+	suite.Require().Len(compact.CompactSignatures, 2*ethmultisigtypes.CompactSignatureLength)
+	suite.Require().Less(compact.Size(), legacy.Size())
+	converted, err := legacy.ToCompact()
+	suite.Require().NoError(err)
+	suite.Require().Equal(compact, converted)
+
+	// both versions are accepted
+	suite.Require().NoError(consensusState.VerifySignature(legacy, signBytes))
+	suite.Require().NoError(consensusState.VerifySignature(compact, signBytes))
+	proof, err := proto.Marshal(compact)
+	suite.Require().NoError(err)
+	suite.Require().NoError(clientState.VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1, commitment[:]))
+
+	// the signatures must match the version
+	mixed := *compact
+	mixed.Signatures = legacy.Signatures
+	suite.Require().ErrorIs(consensusState.VerifySignature(&mixed, signBytes), ethmultisigtypes.ErrInvalidSignature)
+	mixed = *legacy
+	mixed.CompactSignatures = compact.CompactSignatures
+	suite.Require().ErrorIs(consensusState.VerifySignature(&mixed, signBytes), ethmultisigtypes.ErrInvalidSignature)
+	truncated := *compact
+	truncated.CompactSignatures = compact.CompactSignatures[:len(compact.CompactSignatures)-1]
+	suite.Require().ErrorIs(consensusState.VerifySignature(&truncated, signBytes), ethmultisigtypes.ErrInvalidSignature)
+	unknown := *compact
+	unknown.Version = 2
+	suite.Require().ErrorIs(consensusState.VerifySignature(&unknown, signBytes), ethmultisigtypes.ErrInvalidProof)
+
+	// the y-parity is a part of the compact signature
+	flipped := *compact
+	flipped.CompactSignatures = append([]byte{}, compact.CompactSignatures...)
+	flipped.CompactSignatures[32] ^= 0x80
+	suite.Require().Error(consensusState.VerifySignature(&flipped, signBytes))
+
+	// the compact form of a valid signature recovers the same signer
+	for _, v := range loadSignatureVectors(suite.T()) {
+		if !v.Valid {
+			continue
+		}
+		bz, err := ethmultisigtypes.CompactSignature(v.Signature)
+		suite.Require().NoError(err, v.Name)
+		sig, err := ethmultisigtypes.ExpandCompactSignature(bz)
+		suite.Require().NoError(err, v.Name)
+		signer, err := ethmultisigtypes.RecoverSigner(crypto.Keccak256(v.SignBytes), sig)
+		suite.Require().NoError(err, v.Name)
+		suite.Require().Equal(v.Signer, signer, v.Name)
+	}
+}
+
+func (suite *LightClientTestSuite) TestBatchProof() {
+	for n := 1; n <= 9; n++ {
+		var leaves [][]byte
+		for i := 0; i < n; i++ {
+			leaves = append(leaves, []byte(fmt.Sprintf("leaf-%d", i)))
+		}
+		proofs, err := ethmultisigtypes.NewBatchProofs(leaves)
+		suite.Require().NoError(err)
+		for i, proof := range proofs {
+			suite.Require().Equal(proofs[0].Root, proof.Root)
+			suite.Require().NoError(proof.Verify(leaves[i]), "n=%d i=%d", n, i)
+			suite.Require().ErrorIs(proof.Verify([]byte("other")), ethmultisigtypes.ErrInvalidBatchProof)
+			if n == 1 {
+				continue
+			}
+			wrongIndex := *proof
+			wrongIndex.Index = uint64((i + 1) % n)
+			suite.Require().Error(wrongIndex.Verify(leaves[i]), "n=%d i=%d", n, i)
+			extraSibling := *proof
+			extraSibling.Siblings = append(append([][]byte{}, proof.Siblings...), proof.Root)
+			suite.Require().Error(extraSibling.Verify(leaves[i]), "n=%d i=%d", n, i)
+		}
+	}
+	_, err := ethmultisigtypes.NewBatchProofs(nil)
+	suite.Require().ErrorIs(err, ethmultisigtypes.ErrInvalidBatchProof)
+
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	proofHeight := clienttypes.NewHeight(0, 1)
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), prefix.Bytes())
+	suite.createClient(prover.Addresses(), diversifier)
+
+	// every packet of the batch is verified with the single root signature
+	var states []ethmultisigtypes.StateData
+	var commitments [][]byte
+	for seq := uint64(1); seq <= 5; seq++ {
+		commitment := sha256.Sum256([]byte(fmt.Sprintf("packet-%d", seq)))
+		path, err := ethmultisigtypes.PacketCommitmentKey(prefix.Bytes(), "transfer", "channel-0", seq)
+		suite.Require().NoError(err)
+		states = append(states, ethmultisigtypes.StateData{Path: path, Value: commitment[:]})
+		commitments = append(commitments, commitment[:])
+	}
+	ackCommitment := sha256.Sum256([]byte("ack"))
+	ackPath, err := ethmultisigtypes.PacketAcknowledgementCommitmentKey(prefix.Bytes(), "transfer", "channel-0", 1)
+	suite.Require().NoError(err)
+	states = append(states, ethmultisigtypes.StateData{Path: ackPath, Value: ackCommitment[:]})
+	proofs, _, err := prover.SignStates(proofHeight, states)
+	suite.Require().NoError(err)
+	suite.Require().Len(proofs, len(states))
+
+	verifyPacket := func(proof *ethmultisigtypes.MultiSignature, height clienttypes.Height, seq uint64, commitment []byte) error {
+		bz, err := proto.Marshal(proof)
+		suite.Require().NoError(err)
+		return suite.getClientState().VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, height, 0, 0, &prefix, bz, "transfer", "channel-0", seq, commitment)
+	}
+	// a leaf cannot prove another state
+	suite.Require().ErrorIs(verifyPacket(proofs[0], proofHeight, 1, commitments[1]), ethmultisigtypes.ErrInvalidBatchProof)
+	for i := range commitments {
+		suite.Require().NoError(verifyPacket(proofs[i], proofHeight, uint64(i+1), commitments[i]))
+	}
+	bz, err := proto.Marshal(proofs[5])
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.getClientState().VerifyPacketAcknowledgement(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, bz, "transfer", "channel-0", 1, []byte("ack")))
+	suite.Require().Equal(proofs[0].Batch.Root, suite.getClientState().BatchRoot)
+
+	// neither a state nor another batch can reuse the sequence of the batch
+	proof, _, err := prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitments[0])
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(verifyPacket(proof, proofHeight, 1, commitments[0]), ethmultisigtypes.ErrInvalidSequence)
+	otherProofs, _, err := prover.SignStates(proofHeight, states[:1])
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(verifyPacket(otherProofs[0], proofHeight, 1, commitments[0]), ethmultisigtypes.ErrInvalidSequence)
+
+	// the batch cannot be used once the sequence has advanced
+	proof, _, err = prover.SignPacketState(clienttypes.NewHeight(0, 2), "transfer", "channel-0", 1, commitments[0])
+	suite.Require().NoError(err)
+	suite.Require().NoError(verifyPacket(proof, clienttypes.NewHeight(0, 2), 1, commitments[0]))
+	suite.Require().Empty(suite.getClientState().BatchRoot)
+	suite.Require().ErrorIs(verifyPacket(proofs[1], proofHeight, 2, commitments[1]), ethmultisigtypes.ErrInvalidSequence)
+
+	// the root signature is bound to the batch root
+	proofs, _, err = prover.SignStates(clienttypes.NewHeight(0, 3), states)
+	suite.Require().NoError(err)
+	tampered := *proofs[0]
+	otherProofs, _, err = prover.SignStates(clienttypes.NewHeight(0, 3), states[:2])
+	suite.Require().NoError(err)
+	tampered.Batch = otherProofs[0].Batch
+	suite.Require().Error(verifyPacket(&tampered, clienttypes.NewHeight(0, 3), 1, commitments[0]))
+}
+
+func (suite *LightClientTestSuite) TestParallelSignerRecoverer() {
+	recoverer, err := ethmultisigtypes.NewParallelRecoverer(4, 16)
+	suite.Require().NoError(err)
+	ethmultisigtypes.SetSignerRecoverer(recoverer)
+	defer ethmultisigtypes.SetSignerRecoverer(nil)
+
+	// the parallel recoverer accepts and rejects the same signatures as the sequential one,
+	// both before and after the signers are cached
+	for i := 0; i < 2; i++ {
+		suite.TestSignatureVectors()
+		suite.TestThresholdSignature()
+	}
+
+	// a cached signer is only reused for the same hash
+	prover := ethmultisig.NewETHMultisig(suite.cdc, "tester", ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), nil)
+	proof, signBytes, err := prover.SignState(clienttypes.NewHeight(0, 1), ethmultisigtypes.CLIENT, []byte("path"), []byte("value"))
+	suite.Require().NoError(err)
+	suite.Require().NoError(ethmultisigtypes.VerifySignature(prover.Addresses(), proof, signBytes))
+	suite.Require().Error(ethmultisigtypes.VerifySignature(prover.Addresses(), proof, append(signBytes, 0)))
+
+	_, err = ethmultisigtypes.NewParallelRecoverer(0, 16)
+	suite.Require().Error(err)
+	_, err = ethmultisigtypes.NewParallelRecoverer(1, -1)
+	suite.Require().Error(err)
+}
+
+func (suite *LightClientTestSuite) TestConsensusStateHistory() {
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	commitment := sha256.Sum256([]byte("packet"))
+
+	var provers []ethmultisig.ETHMultisig
+	for i := uint32(0); i < 4; i++ {
+		provers = append(provers, ethmultisig.NewETHMultisig(suite.cdc, fmt.Sprintf("tester%v", i), ethmultisig.NewKeySigners(suite.prvKeys(i)...), prefix.Bytes()))
+	}
+	clientState := suite.createClient(provers[0].Addresses(), "tester0")
+	clientState.MaxConsensusStates = 2
+	suite.store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(suite.cdc, clientState))
+
+	// the proofs are verified with the stored client state, as the client keeper does
+	verify := func(prover ethmultisig.ETHMultisig, height uint64) error {
+		proofHeight := clienttypes.NewHeight(0, height)
+		proof, err := marshalProof(prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:]))
+		suite.Require().NoError(err)
+		return suite.getClientState().VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &prefix, proof, "transfer", "channel-0", 1, commitment[:])
+	}
+	rotate := func(prover ethmultisig.ETHMultisig, height uint64, next ethmultisig.ETHMultisig, diversifier string) {
+		header, _, err := prover.SignHeader(clienttypes.NewHeight(0, height), next.Addresses(), 0, diversifier)
+		suite.Require().NoError(err)
+		suite.updateClient(suite.getClientState(), header)
+	}
+
+	suite.Require().Error(verify(provers[1], 1))
+	suite.Require().NoError(verify(provers[0], 1))
+
+	// the rotated-out signer set cannot sign proofs below or above the rotation height
+	rotate(provers[0], 3, provers[1], "tester1")
+	suite.Require().Equal(uint64(3), suite.getClientState().Sequence)
+	suite.Require().Error(verify(provers[0], 2))
+	suite.Require().Error(verify(provers[0], 3))
+	suite.Require().Error(verify(provers[0], 4))
+	suite.Require().Error(verify(provers[1], 3))
+	suite.Require().NoError(verify(provers[1], 4))
+
+	// the oldest consensus state is pruned by the max count
+	rotate(provers[1], 5, provers[2], "tester2")
+	suite.Require().False(suite.store.Has(host.ConsensusStateKey(clienttypes.NewHeight(0, 1))))
+	suite.Require().True(suite.store.Has(host.ConsensusStateKey(clienttypes.NewHeight(0, 3))))
+	suite.Require().Error(verify(provers[1], 6))
+	suite.Require().NoError(verify(provers[2], 6))
+
+	// the consensus states older than the max age are pruned
+	clientState = suite.getClientState()
+	clientState.MaxConsensusStates = 0
+	clientState.ConsensusStateMaxAge = uint64(time.Hour)
+	suite.store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(suite.cdc, clientState))
+	cons := makeMultisigConsensusState(provers[1].Addresses(), "tester1", uint64(time.Now().Add(-2*time.Hour).UnixNano()))
+	suite.setConsensusState(clienttypes.NewHeight(0, 3), cons)
+	rotate(provers[2], 7, provers[3], "tester3")
+	suite.Require().False(suite.store.Has(host.ConsensusStateKey(clienttypes.NewHeight(0, 3))))
+	suite.Require().True(suite.store.Has(host.ConsensusStateKey(clienttypes.NewHeight(0, 5))))
+	suite.Require().Error(verify(provers[2], 8))
+	suite.Require().NoError(verify(provers[3], 8))
+}
+
+func (suite *LightClientTestSuite) TestConsensusHeight() {
+	const diversifier = "tester"
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0)...), prefix.Bytes())
+
+	// the same consensus states as the Solidity client in TestMultisigConsensusHeight
+	clientState := makeMultisigClientState(5)
+	consensusState := makeMultisigConsensusState(prover.Addresses(), diversifier, uint64(time.Now().UnixNano()))
+	suite.setConsensusState(clientState.GetLatestHeight(), consensusState)
+	suite.Require().NoError(clientState.Initialize(sdk.Context{}, suite.cdc, suite.store, consensusState))
+	suite.setConsensusState(clienttypes.NewHeight(0, 1), consensusState)
+
+	verify := func(height uint64) error {
+		targetClientState := makeMultisigClientState(1)
+		proofHeight := clienttypes.NewHeight(0, height)
+		proof, err := marshalProof(prover.SignClientState(proofHeight, "testcounterparty-0", targetClientState))
+		suite.Require().NoError(err)
+		return clientState.VerifyClientState(suite.store, suite.cdc, proofHeight, &prefix, "testcounterparty-0", proof, targetClientState)
+	}
+
+	// the proofs are verified with the consensus state at their height or the latest one above the latest height
+	for _, height := range []uint64{1, 5, 8} {
+		suite.Require().NoError(verify(height))
+	}
+	// a proof between the consensus states is rejected as the Solidity client does
+	suite.Require().ErrorIs(verify(3), clienttypes.ErrConsensusStateNotFound)
+}
+
+func (suite *LightClientTestSuite) TestVerifyUpgradeAndUpdateState() {
+	const diversifier = "tester"
+	prefix := []byte("ibc")
+
+	prover := ethmultisig.NewETHMultisig(suite.cdc, diversifier, ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), prefix)
+	clientState := suite.createClient(prover.Addresses(), diversifier)
+	clientState.MaxConsensusStates = 10
+	newProver := ethmultisig.NewETHMultisig(suite.cdc, "tester2", ethmultisig.NewKeySigners(suite.prvKeys(3, 4)...), prefix)
+
+	upgradedClient := makeMultisigClientState(1)
+	upgradedClient.LatestHeight.RevisionNumber = 1
+	upgradedConsState := makeMultisigConsensusState(newProver.Addresses(), "tester2", uint64(time.Now().UnixNano()))
+	upgradedConsState.Threshold = 1
+
+	signUpgrade := func(prover ethmultisig.ETHMultisig, height exported.Height) []byte {
+		proof, err := marshalProof(prover.SignUpgrade(height.(clienttypes.Height), upgradedClient, upgradedConsState))
+		suite.Require().NoError(err)
+		return proof
+	}
+
+	// the upgrade must be signed by the current signers at the latest height
+	proof := signUpgrade(newProver, clientState.GetLatestHeight())
+	_, _, err := clientState.VerifyUpgradeAndUpdateState(sdk.Context{}, suite.cdc, suite.store, upgradedClient, upgradedConsState, proof, proof)
+	suite.Require().Error(err)
+	proof = signUpgrade(prover, clienttypes.NewHeight(0, 2))
+	_, _, err = clientState.VerifyUpgradeAndUpdateState(sdk.Context{}, suite.cdc, suite.store, upgradedClient, upgradedConsState, proof, proof)
+	suite.Require().Error(err)
+
+	// the upgraded states must match the signed ones
+	proof = signUpgrade(prover, clientState.GetLatestHeight())
+	otherConsState := makeMultisigConsensusState(prover.Addresses(), "tester2", upgradedConsState.Timestamp)
+	_, _, err = clientState.VerifyUpgradeAndUpdateState(sdk.Context{}, suite.cdc, suite.store, upgradedClient, otherConsState, proof, proof)
+	suite.Require().Error(err)
+
+	// the consensus state proof must be the same as the client state proof
+	proof = signUpgrade(prover, clientState.GetLatestHeight())
+	_, _, err = clientState.VerifyUpgradeAndUpdateState(sdk.Context{}, suite.cdc, suite.store, upgradedClient, upgradedConsState, proof, signUpgrade(prover.WithClock(func() time.Time { return time.Now().Add(time.Second) }), clientState.GetLatestHeight()))
+	suite.Require().ErrorIs(err, ethmultisigtypes.ErrInvalidProof)
+
+	// the signature must be in the timestamp window of the block time
+	windowClientState := *clientState
+	windowClientState.MaxSignatureAge = uint64(time.Hour)
+	proof = signUpgrade(prover, clientState.GetLatestHeight())
+	ctx := sdk.Context{}.WithBlockTime(time.Now().Add(2 * time.Hour))
+	_, _, err = windowClientState.VerifyUpgradeAndUpdateState(ctx, suite.cdc, suite.store, upgradedClient, upgradedConsState, proof, proof)
+	suite.Require().ErrorIs(err, ethmultisigtypes.ErrInvalidTimestamp)
+
+	// the upgraded height must be greater than the latest height
+	lowerClient := makeMultisigClientState(1)
+	proof = signUpgrade(prover, clientState.GetLatestHeight())
+	_, _, err = clientState.VerifyUpgradeAndUpdateState(sdk.Context{}, suite.cdc, suite.store, lowerClient, upgradedConsState, proof, proof)
+	suite.Require().Error(err)
+
+	// custom fields are not signed and carried over from the current client state
+	upgradedClient.MaxConsensusStates = 100
+	proof = signUpgrade(prover, clientState.GetLatestHeight())
+	newClientState, newConsState, err := clientState.VerifyUpgradeAndUpdateState(sdk.Context{}, suite.cdc, suite.store, upgradedClient, upgradedConsState, proof, proof)
+	suite.Require().NoError(err)
+	suite.Require().Equal(clienttypes.NewHeight(1, 1), newClientState.GetLatestHeight())
+	suite.Require().Equal(uint64(10), newClientState.(*ethmultisigtypes.ClientState).MaxConsensusStates)
+	suite.Require().Equal(upgradedConsState, newConsState)
+	suite.Require().Zero(newClientState.(*ethmultisigtypes.ClientState).Sequence)
+
+	// the sequence is raised to the upgraded height within the same revision
+	upgradedClient = makeMultisigClientState(5)
+	proof = signUpgrade(prover, clientState.GetLatestHeight())
+	newClientState, _, err = clientState.VerifyUpgradeAndUpdateState(sdk.Context{}, suite.cdc, suite.store, upgradedClient, upgradedConsState, proof, proof)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(5), newClientState.(*ethmultisigtypes.ClientState).Sequence)
+}
+
+func (suite *LightClientTestSuite) TestCheckSubstituteAndUpdateState() {
+	prefix := []byte("ibc")
+	prover := ethmultisig.NewETHMultisig(suite.cdc, "tester", ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), prefix)
+	subjectClientState := suite.createClient(prover.Addresses(), "tester")
+	subjectClientStore := suite.store
+
+	newProver := ethmultisig.NewETHMultisig(suite.cdc, "tester2", ethmultisig.NewKeySigners(suite.prvKeys(3, 4)...), prefix)
+	suite.store = dbadapter.Store{DB: dbm.NewMemDB()}
+	// the substitute is created at height 5 and has not verified any proofs
+	substituteClientState := makeMultisigClientState(5)
+	substituteConsensusState := makeMultisigConsensusState(newProver.Addresses(), "tester2", uint64(time.Now().UnixNano()))
+	suite.setConsensusState(substituteClientState.GetLatestHeight(), substituteConsensusState)
+	suite.Require().NoError(substituteClientState.Initialize(sdk.Context{}, suite.cdc, suite.store, substituteConsensusState))
+	substituteClientStore := suite.store
+
+	// an active client cannot be substituted
+	_, err := subjectClientState.CheckSubstituteAndUpdateState(sdk.Context{}, suite.cdc, subjectClientStore, substituteClientStore, substituteClientState)
+	suite.Require().Error(err)
+
+	subjectClientState.FrozenHeight.RevisionHeight = 2
+
+	// the immutable fields must match
+	substituteClientState.MaxConsensusStates = 1
+	_, err = subjectClientState.CheckSubstituteAndUpdateState(sdk.Context{}, suite.cdc, subjectClientStore, substituteClientStore, substituteClientState)
+	suite.Require().Error(err)
+	substituteClientState.MaxConsensusStates = 0
+
+	// the substitute must be ahead of the subject
+	aheadClientState := *subjectClientState
+	aheadClientState.LatestHeight.RevisionHeight = 7
+	_, err = aheadClientState.CheckSubstituteAndUpdateState(sdk.Context{}, suite.cdc, subjectClientStore, substituteClientStore, substituteClientState)
+	suite.Require().ErrorIs(err, clienttypes.ErrInvalidSubstitute)
+	aheadClientState.LatestHeight.RevisionHeight = 5
+	_, err = aheadClientState.CheckSubstituteAndUpdateState(sdk.Context{}, suite.cdc, subjectClientStore, substituteClientStore, substituteClientState)
+	suite.Require().ErrorIs(err, clienttypes.ErrInvalidSubstitute)
+
+	newClientState, err := subjectClientState.CheckSubstituteAndUpdateState(sdk.Context{}, suite.cdc, subjectClientStore, substituteClientStore, substituteClientState)
+	suite.Require().NoError(err)
+	suite.Require().Equal(exported.Active, newClientState.Status(sdk.Context{}, subjectClientStore, suite.cdc))
+	suite.Require().Equal(substituteClientState.GetLatestHeight(), newClientState.GetLatestHeight())
+
+	// the signer set of the subject cannot sign proofs below the latest height of the substitute
+	suite.store = subjectClientStore
+	suite.store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(suite.cdc, newClientState))
+	merklePrefix := commitmenttypes.NewMerklePrefix(prefix)
+	commitment := sha256.Sum256([]byte("packet"))
+	verify := func(prover ethmultisig.ETHMultisig, height uint64) error {
+		proofHeight := clienttypes.NewHeight(0, height)
+		proof, err := marshalProof(prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:]))
+		suite.Require().NoError(err)
+		return suite.getClientState().VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, proofHeight, 0, 0, &merklePrefix, proof, "transfer", "channel-0", 1, commitment[:])
+	}
+	suite.Require().Equal(uint64(5), suite.getClientState().Sequence)
+	suite.Require().ErrorIs(verify(prover, 2), ethmultisigtypes.ErrInvalidSequence)
+	suite.Require().Error(verify(prover, 6))
+	suite.Require().NoError(verify(newProver, 6))
+
+	// the subject client is updated with the signer set of the substitute
+	header, _, err := newProver.SignHeader(clienttypes.NewHeight(0, 7), prover.Addresses(), 0, "tester")
+	suite.Require().NoError(err)
+	suite.updateClient(newClientState.(*ethmultisigtypes.ClientState), header)
+}
+
+func (suite *LightClientTestSuite) TestExportMetadata() {
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	commitment := sha256.Sum256([]byte("packet"))
+	startTime := time.Now()
+	ctx := sdk.Context{}.WithBlockTime(startTime).WithBlockHeight(10)
+
+	var provers []ethmultisig.ETHMultisig
+	for i := uint32(0); i < 3; i++ {
+		provers = append(provers, ethmultisig.NewETHMultisig(suite.cdc, fmt.Sprintf("tester%v", i), ethmultisig.NewKeySigners(suite.prvKeys(i)...), prefix.Bytes()))
+	}
+	clientState := makeMultisigClientState(1)
+	clientState.MaxConsensusStates = 3
+	consensusState := makeMultisigConsensusState(provers[0].Addresses(), "tester0", uint64(startTime.UnixNano()))
+	suite.setConsensusState(clientState.GetLatestHeight(), consensusState)
+	suite.Require().NoError(clientState.Initialize(ctx, suite.cdc, suite.store, consensusState))
+	suite.store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(suite.cdc, clientState))
+
+	verify := func(ctx sdk.Context, store sdk.KVStore, prover ethmultisig.ETHMultisig, height uint64, delayTimePeriod, delayBlockPeriod uint64) error {
+		proofHeight := clienttypes.NewHeight(0, height)
+		proof, err := marshalProof(prover.SignPacketState(proofHeight, "transfer", "channel-0", 1, commitment[:]))
+		suite.Require().NoError(err)
+		clientState, err := clienttypes.UnmarshalClientState(suite.cdc, store.Get(host.ClientStateKey()))
+		suite.Require().NoError(err)
+		return clientState.VerifyPacketCommitment(ctx, store, suite.cdc, proofHeight, delayTimePeriod, delayBlockPeriod, &prefix, proof, "transfer", "channel-0", 1, commitment[:])
+	}
+
+	// the client is updated at different block times and heights and verifies proofs in between
+	for i, height := range []uint64{3, 5} {
+		suite.Require().NoError(verify(ctx, suite.store, provers[i], height-1, 0, 0))
+		ctx = ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour)).WithBlockHeight(ctx.BlockHeight() + 10)
+		header, _, err := provers[i].SignHeader(clienttypes.NewHeight(0, height), provers[i+1].Addresses(), 0, fmt.Sprintf("tester%v", i+1))
+		suite.Require().NoError(err)
+		newClientState, newConsensusState, err := suite.getClientState().CheckHeaderAndUpdateState(ctx, suite.cdc, suite.store, header)
+		suite.Require().NoError(err)
+		suite.setConsensusState(header.GetHeight(), newConsensusState.(*ethmultisigtypes.ConsensusState))
+		suite.store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(suite.cdc, newClientState))
+	}
+	suite.Require().NoError(verify(ctx, suite.store, provers[2], 6, 0, 0))
+	clientState = suite.getClientState()
+	suite.Require().Equal(uint64(6), clientState.Sequence)
+
+	// export the client as 02-client ExportGenesis does
+	metadata := clientState.ExportMetadata(suite.store)
+	// the processed time, the processed height and the iteration key of each consensus state
+	suite.Require().Len(metadata, 9)
+	consensusStates := make(map[string][]byte)
+	iterator := sdk.KVStorePrefixIterator(suite.store, []byte(host.KeyConsensusStatePrefix))
+	for ; iterator.Valid(); iterator.Next() {
+		consensusStates[string(iterator.Key())] = iterator.Value()
+	}
+	iterator.Close()
+	exportedStore := suite.store
+
+	// import the client into a new store at a later block as 02-client InitGenesis does
+	importCtx := ctx.WithBlockTime(ctx.BlockTime().Add(24 * time.Hour)).WithBlockHeight(1)
+	suite.store = dbadapter.Store{DB: dbm.NewMemDB()}
+	for _, md := range metadata {
+		suite.store.Set(md.GetKey(), md.GetValue())
+	}
+	suite.store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(suite.cdc, clientState))
+	for key, val := range consensusStates {
+		suite.store.Set([]byte(key), val)
+	}
+	consensusStateI, err := clienttypes.UnmarshalConsensusState(suite.cdc, consensusStates[string(host.ConsensusStateKey(clientState.GetLatestHeight()))])
+	suite.Require().NoError(err)
+	suite.Require().NoError(clientState.Initialize(importCtx, suite.cdc, suite.store, consensusStateI))
+	suite.Require().Equal(metadata, clientState.ExportMetadata(suite.store))
+	processedTime, found := ethmultisigtypes.GetProcessedTime(suite.store, clientState.GetLatestHeight())
+	suite.Require().True(found)
+	suite.Require().Equal(uint64(ctx.BlockTime().UnixNano()), processedTime)
+	processedHeight, found := ethmultisigtypes.GetProcessedHeight(suite.store, clientState.GetLatestHeight())
+	suite.Require().True(found)
+	suite.Require().Equal(clienttypes.GetSelfHeight(ctx), processedHeight)
+
+	// the verification results, including the delay periods, are kept after the import
+	for _, delayCtx := range []sdk.Context{
+		ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour - 1)).WithBlockHeight(ctx.BlockHeight() + 5),
+		ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour)).WithBlockHeight(ctx.BlockHeight() + 4),
+		ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour)).WithBlockHeight(ctx.BlockHeight() + 5),
+	} {
+		for height := uint64(1); height <= 8; height++ {
+			for _, prover := range provers {
+				expected := verify(delayCtx, exportedStore, prover, height, uint64(time.Hour), 5)
+				actual := verify(delayCtx, suite.store, prover, height, uint64(time.Hour), 5)
+				suite.Require().Equal(expected == nil, actual == nil, "height=%v", height)
+			}
+		}
+	}
+	// the proofs at 7 and 8 pass once the delay periods from the processed time and height of the export have passed
+	suite.Require().Equal(uint64(8), suite.getClientState().Sequence)
+
+	// the consensus states are pruned in the same way after the import
+	header, _, err := provers[2].SignHeader(clienttypes.NewHeight(0, 9), provers[0].Addresses(), 0, "tester0")
+	suite.Require().NoError(err)
+	suite.updateClient(suite.getClientState(), header)
+	suite.Require().False(suite.store.Has(host.ConsensusStateKey(clienttypes.NewHeight(0, 1))))
+	suite.Require().True(suite.store.Has(host.ConsensusStateKey(clienttypes.NewHeight(0, 3))))
+}
+
+func (suite *LightClientTestSuite) TestValidateBasic() {
+	prover := ethmultisig.NewETHMultisig(suite.cdc, "tester", ethmultisig.NewKeySigners(suite.prvKeys(0, 1, 2)...), []byte("ibc"))
+	addresses := prover.Addresses()
+	now := uint64(time.Now().UnixNano())
+
+	cases := []struct {
+		name     string
+		malleate func(*ethmultisigtypes.ConsensusState)
+		expErr   error
+	}{
+		{"valid", func(*ethmultisigtypes.ConsensusState) {}, nil},
+		{"empty addresses", func(cs *ethmultisigtypes.ConsensusState) { cs.Addresses = nil }, ethmultisigtypes.ErrInvalidSignerSet},
+		{"short address", func(cs *ethmultisigtypes.ConsensusState) { cs.Addresses[1] = cs.Addresses[1][:19] }, ethmultisigtypes.ErrInvalidAddress},
+		{"zero address", func(cs *ethmultisigtypes.ConsensusState) { cs.Addresses[1] = make([]byte, 20) }, ethmultisigtypes.ErrInvalidAddress},
+		{"duplicate signers", func(cs *ethmultisigtypes.ConsensusState) { cs.Addresses[1] = cs.Addresses[0] }, ethmultisigtypes.ErrDuplicateSigner},
+		{"threshold too large", func(cs *ethmultisigtypes.ConsensusState) { cs.Threshold = 4 }, ethmultisigtypes.ErrInvalidThreshold},
+		{"empty diversifier", func(cs *ethmultisigtypes.ConsensusState) { cs.Diversifier = " " }, ethmultisigtypes.ErrInvalidDiversifier},
+		{"zero timestamp", func(cs *ethmultisigtypes.ConsensusState) { cs.Timestamp = 0 }, ethmultisigtypes.ErrInvalidTimestamp},
+	}
+	for _, tc := range cases {
+		cs := makeMultisigConsensusState(addresses, "tester", now)
+		tc.malleate(cs)
+		err := cs.ValidateBasic()
+		if tc.expErr == nil {
+			suite.Require().NoError(err, tc.name)
+			suite.Require().NoError(makeMultisigClientState(1).Initialize(sdk.Context{}, suite.cdc, suite.store, cs), tc.name)
+		} else {
+			suite.Require().ErrorIs(err, tc.expErr, tc.name)
+			suite.Require().ErrorIs(makeMultisigClientState(1).Initialize(sdk.Context{}, suite.cdc, suite.store, cs), tc.expErr, tc.name)
+		}
+	}
+
+	clientCases := []struct {
+		name     string
+		malleate func(*ethmultisigtypes.ClientState)
+		// expErr is the error of Validate and Initialize, and expInitErr is the error of ValidateInitial, which a new client must pass
+		expErr     error
+		expInitErr error
+	}{
+		{"valid", func(*ethmultisigtypes.ClientState) {}, nil, nil},
+		{"zero latest height", func(cs *ethmultisigtypes.ClientState) { cs.LatestHeight.RevisionHeight = 0 }, ethmultisigtypes.ErrInvalidHeight, nil},
+		{"frozen", func(cs *ethmultisigtypes.ClientState) { cs.FrozenHeight.RevisionHeight = 1 }, nil, ethmultisigtypes.ErrInvalidHeight},
+		{"sequence", func(cs *ethmultisigtypes.ClientState) { cs.Sequence = 1 }, nil, ethmultisigtypes.ErrInvalidSequence},
+		{"batch root", func(cs *ethmultisigtypes.ClientState) { cs.BatchRoot = make([]byte, 32) }, nil, ethmultisigtypes.ErrInvalidSequence},
+		{"timestamp", func(cs *ethmultisigtypes.ClientState) { cs.Timestamp = now }, nil, ethmultisigtypes.ErrInvalidTimestamp},
+		{"periods within trusting period", func(cs *ethmultisigtypes.ClientState) {
+			cs.TrustingPeriod, cs.MaxClockDrift, cs.MaxSignatureAge = uint64(time.Hour), uint64(time.Hour), uint64(time.Minute)
+		}, nil, nil},
+		{"periods without trusting period", func(cs *ethmultisigtypes.ClientState) {
+			cs.MaxClockDrift, cs.MaxSignatureAge = uint64(time.Hour), uint64(time.Hour)
+		}, nil, nil},
+		{"max clock drift over trusting period", func(cs *ethmultisigtypes.ClientState) {
+			cs.TrustingPeriod, cs.MaxClockDrift = uint64(time.Hour), uint64(time.Hour+1)
+		}, ethmultisigtypes.ErrInvalidPeriod, nil},
+		{"max signature age over trusting period", func(cs *ethmultisigtypes.ClientState) {
+			cs.TrustingPeriod, cs.MaxSignatureAge = uint64(time.Hour), uint64(time.Hour+1)
+		}, ethmultisigtypes.ErrInvalidPeriod, nil},
+	}
+	for _, tc := range clientCases {
+		cs := makeMultisigClientState(1)
+		tc.malleate(cs)
+		err := cs.Initialize(sdk.Context{}, suite.cdc, suite.store, makeMultisigConsensusState(addresses, "tester", now))
+		if tc.expErr == nil {
+			suite.Require().NoError(cs.Validate(), tc.name)
+			suite.Require().NoError(err, tc.name)
+		} else {
+			suite.Require().ErrorIs(cs.Validate(), tc.expErr, tc.name)
+			suite.Require().ErrorIs(err, tc.expErr, tc.name)
+		}
+		if tc.expInitErr == nil {
+			suite.Require().NoError(cs.ValidateInitial(), tc.name)
+		} else {
+			suite.Require().ErrorIs(cs.ValidateInitial(), tc.expInitErr, tc.name)
+		}
+	}
+
+	header, _, err := prover.SignHeader(clienttypes.NewHeight(0, 2), addresses, 2, "tester2")
+	suite.Require().NoError(err)
+	suite.Require().NoError(header.ValidateBasic())
+	header.Signature = nil
+	suite.Require().ErrorIs(header.ValidateBasic(), ethmultisigtypes.ErrInvalidSignature)
+	header, _, err = prover.SignHeader(clienttypes.NewHeight(0, 2), addresses, 4, "tester2")
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(header.ValidateBasic(), ethmultisigtypes.ErrInvalidThreshold)
+	header, _, err = prover.SignHeader(clienttypes.NewHeight(0, 2), nil, 0, "tester2")
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(header.ValidateBasic(), ethmultisigtypes.ErrInvalidSignerSet)
+	header, _, err = prover.SignHeader(clienttypes.NewHeight(0, 2), addresses, 0, "")
+	suite.Require().NoError(err)
+	suite.Require().ErrorIs(header.ValidateBasic(), ethmultisigtypes.ErrInvalidDiversifier)
+
+	// an invalid header is rejected on update
+	clientState := suite.createClient(addresses, "tester")
+	_, _, err = clientState.CheckHeaderAndUpdateState(sdk.Context{}, suite.cdc, suite.store, header)
+	suite.Require().Error(err)
+}
+
+// createClient stores a client state and the initial consensus state at height 1
+func (suite *LightClientTestSuite) createClient(addresses []common.Address, diversifier string) *ethmultisigtypes.ClientState {
+	clientState := makeMultisigClientState(1)
+	consensusState := makeMultisigConsensusState(addresses, diversifier, uint64(time.Now().UnixNano()))
+	suite.setConsensusState(clientState.GetLatestHeight(), consensusState)
+	suite.store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(suite.cdc, clientState))
+	suite.Require().NoError(clientState.Initialize(sdk.Context{}, suite.cdc, suite.store, consensusState))
+	return clientState
+}
+
+// updateClient updates the client with the header and stores the new states as the client keeper does
+func (suite *LightClientTestSuite) updateClient(clientState *ethmultisigtypes.ClientState, header *ethmultisigtypes.Header) *ethmultisigtypes.ClientState {
+	newClientState, newConsensusState, err := clientState.CheckHeaderAndUpdateState(sdk.Context{}, suite.cdc, suite.store, header)
+	suite.Require().NoError(err)
+	suite.setConsensusState(header.GetHeight(), newConsensusState.(*ethmultisigtypes.ConsensusState))
+	suite.store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(suite.cdc, newClientState))
+	return newClientState.(*ethmultisigtypes.ClientState)
+}
+
+func (suite *LightClientTestSuite) getClientState() *ethmultisigtypes.ClientState {
+	clientState, err := clienttypes.UnmarshalClientState(suite.cdc, suite.store.Get(host.ClientStateKey()))
+	suite.Require().NoError(err)
+	return clientState.(*ethmultisigtypes.ClientState)
+}
+
+func (suite *LightClientTestSuite) getConsensusState(height exported.Height) *ethmultisigtypes.ConsensusState {
+	consensusState, err := clienttypes.UnmarshalConsensusState(suite.cdc, suite.store.Get(host.ConsensusStateKey(height)))
+	suite.Require().NoError(err)
+	return consensusState.(*ethmultisigtypes.ConsensusState)
+}
+
+func (suite *LightClientTestSuite) setConsensusState(height exported.Height, consensusState *ethmultisigtypes.ConsensusState) {
+	suite.store.Set(host.ConsensusStateKey(height), clienttypes.MustMarshalConsensusState(suite.cdc, consensusState))
+}
+
+func (suite *LightClientTestSuite) prvKeys(indexes ...uint32) []*ecdsa.PrivateKey {
+	var keys []*ecdsa.PrivateKey
+	for _, idx := range indexes {
+		key, err := wallet.GetPrvKeyFromMnemonicAndHDWPath(testMnemonicPhrase, fmt.Sprintf("m/44'/60'/0'/0/%v", idx))
+		suite.Require().NoError(err)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// signatureVector is a test vector that both the Go and the Solidity client must accept or reject
+type signatureVector struct {
+	Name      string         `json:"name"`
+	SignBytes hexutil.Bytes  `json:"sign_bytes"`
+	Signer    common.Address `json:"signer"`
+	Signature hexutil.Bytes  `json:"signature"`
+	Valid     bool           `json:"valid"`
+}
+
+func loadSignatureVectors(t *testing.T) []signatureVector {
+	bz, err := ioutil.ReadFile("testdata/signature_vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []signatureVector
+	if err := json.Unmarshal(bz, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	return vectors
+}
+
+func marshalProof(proof *ethmultisigtypes.MultiSignature, _ []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(proof)
+}
+
+func TestLightClientTestSuite(t *testing.T) {
+	suite.Run(t, new(LightClientTestSuite))
+}
@@ -0,0 +1,430 @@
+package testing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/modules/core/24-host"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/ibc/client"
+	"github.com/hyperledger-labs/yui-relayer/core"
+
+	ethmultisigtypes "github.com/datachainlab/ibc-ethmultisig-client/modules/light-clients/xx-ethmultisig/types"
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig"
+)
+
+// testProverChain is the chain of the prover. The methods that the tests do not use are left unimplemented.
+type testProverChain struct {
+	core.ChainI
+
+	cdc  codec.ProtoCodecMarshaler
+	path *core.PathEnd
+	// latestHeight is the latest height of the chain and connections are the connection states kept at each height
+	latestHeight int64
+	connections  map[int64]conntypes.ConnectionEnd
+	// clientState and consensusState are the client of the counterparty chain at every height
+	clientState    exported.ClientState
+	consensusState exported.ConsensusState
+}
+
+func (c testProverChain) GetLatestHeight() (int64, error) {
+	return c.latestHeight, nil
+}
+
+func (c testProverChain) QueryConnection(height int64) (*conntypes.QueryConnectionResponse, error) {
+	connection, ok := c.connections[height]
+	if !ok {
+		return nil, fmt.Errorf("the state at height %v has been pruned", height)
+	}
+	return &conntypes.QueryConnectionResponse{Connection: &connection}, nil
+}
+
+func (c testProverChain) QueryClientState(height int64) (*clienttypes.QueryClientStateResponse, error) {
+	anyClientState, err := codectypes.NewAnyWithValue(c.clientState)
+	if err != nil {
+		return nil, err
+	}
+	return &clienttypes.QueryClientStateResponse{ClientState: anyClientState}, nil
+}
+
+func (c testProverChain) QueryClientConsensusState(height int64, dstClientConsHeight exported.Height) (*clienttypes.QueryConsensusStateResponse, error) {
+	if !c.clientState.GetLatestHeight().EQ(dstClientConsHeight) {
+		return nil, fmt.Errorf("consensus state not found: %v", dstClientConsHeight)
+	}
+	anyConsensusState, err := codectypes.NewAnyWithValue(c.consensusState)
+	if err != nil {
+		return nil, err
+	}
+	return &clienttypes.QueryConsensusStateResponse{ConsensusState: anyConsensusState}, nil
+}
+
+func (c testProverChain) ChainID() string {
+	return c.path.ChainID
+}
+
+func (c testProverChain) Codec() codec.ProtoCodecMarshaler {
+	return c.cdc
+}
+
+func (c testProverChain) Path() *core.PathEnd {
+	return c.path
+}
+
+// testCounterparty is the counterparty chain that has the client of the suite
+type testCounterparty struct {
+	core.LightClientIBCQueryierI
+
+	suite *LightClientTestSuite
+}
+
+func (c testCounterparty) GetLatestLightHeight() (int64, error) {
+	return 1, nil
+}
+
+func (c testCounterparty) QueryClientState(height int64) (*clienttypes.QueryClientStateResponse, error) {
+	anyClientState, err := codectypes.NewAnyWithValue(c.suite.getClientState())
+	if err != nil {
+		return nil, err
+	}
+	return &clienttypes.QueryClientStateResponse{ClientState: anyClientState}, nil
+}
+
+func (c testCounterparty) QueryClientConsensusState(height int64, dstClientConsHeight exported.Height) (*clienttypes.QueryConsensusStateResponse, error) {
+	anyConsensusState, err := codectypes.NewAnyWithValue(c.suite.getConsensusState(dstClientConsHeight))
+	if err != nil {
+		return nil, err
+	}
+	return &clienttypes.QueryConsensusStateResponse{ConsensusState: anyConsensusState}, nil
+}
+
+func (suite *LightClientTestSuite) TestProverHeader() {
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	chain := testProverChain{cdc: suite.cdc, path: &core.PathEnd{ChainID: "chain-0", ClientID: "testclient-0"}, latestHeight: 100}
+	counterparty := testCounterparty{suite: suite}
+	sequenceDir := suite.T().TempDir()
+	newProver := func(diversifier string, wallets []uint32, previousWallets []uint32) *ethmultisig.Prover {
+		prover, err := ethmultisig.NewProver(ethmultisig.ProverConfig{
+			Diversifier:     diversifier,
+			Wallets:         testWallets(wallets...),
+			PreviousWallets: testWallets(previousWallets...),
+			Prefix:          string(prefix.KeyPrefix),
+			SequenceDir:     sequenceDir,
+		}, chain)
+		suite.Require().NoError(err)
+		return prover
+	}
+	prover := newProver("tester", []uint32{0, 1, 2}, nil)
+	addresses := testAddresses(suite.prvKeys(0, 1, 2))
+	suite.createClient(addresses, "tester")
+
+	// the latest header asserts the configured signer set at the latest sequence without a signature
+	latest, provableHeight, queryableHeight, err := prover.UpdateLightWithHeader()
+	suite.Require().NoError(err)
+	suite.Require().Equal(clienttypes.NewHeight(0, 1), latest.GetHeight())
+	suite.Require().Equal(int64(100), provableHeight)
+	suite.Require().Equal(int64(100), queryableHeight)
+	suite.Require().Equal(addresses, (&ethmultisigtypes.ConsensusState{Addresses: latest.(*ethmultisigtypes.Header).NewAddresses}).GetAddresses())
+	suite.Require().Nil(latest.(*ethmultisigtypes.Header).Signature)
+
+	// no header is signed without a change of the config
+	header, err := prover.SetupHeader(counterparty, latest)
+	suite.Require().NoError(err)
+	suite.Require().Nil(header)
+	suite.Require().NoError(suite.verifyProverPacket(prover, prefix, 1))
+
+	// the previous signers rotate the client to the new signer set and diversifier at a new sequence
+	newAddresses := testAddresses(suite.prvKeys(3, 4))
+	prover = newProver("tester2", []uint32{3, 4}, []uint32{0, 1, 2})
+	header, err = prover.SetupHeader(counterparty, nil)
+	suite.Require().NoError(err)
+	suite.Require().Equal(clienttypes.NewHeight(0, 3), header.GetHeight())
+	suite.updateClient(suite.getClientState(), header.(*ethmultisigtypes.Header))
+	cons := suite.getConsensusState(header.GetHeight())
+	suite.Require().Equal(newAddresses, cons.GetAddresses())
+	suite.Require().Equal("tester2", cons.Diversifier)
+	suite.Require().NoError(suite.verifyProverPacket(prover, prefix, 2))
+	header, err = prover.SetupHeader(counterparty, nil)
+	suite.Require().NoError(err)
+	suite.Require().Nil(header)
+
+	// the new signers update the client on their own once it has been rotated
+	prover = newProver("tester3", []uint32{3, 4}, nil)
+	header, err = prover.SetupHeader(counterparty, nil)
+	suite.Require().NoError(err)
+	suite.Require().Equal(clienttypes.NewHeight(0, 5), header.GetHeight())
+	suite.updateClient(suite.getClientState(), header.(*ethmultisigtypes.Header))
+	suite.Require().NoError(suite.verifyProverPacket(prover, prefix, 3))
+
+	// a header is signed for a client that would expire even if the signer set is unchanged
+	clientState := suite.getClientState()
+	clientState.TrustingPeriod = uint64(2 * time.Hour)
+	suite.store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(suite.cdc, clientState))
+	header, err = prover.SetupHeader(counterparty, nil)
+	suite.Require().NoError(err)
+	suite.Require().Nil(header)
+	cons = suite.getConsensusState(clientState.GetLatestHeight())
+	cons.Timestamp = uint64(time.Now().Add(-time.Hour).UnixNano())
+	suite.setConsensusState(clientState.GetLatestHeight(), cons)
+	header, err = prover.SetupHeader(counterparty, nil)
+	suite.Require().NoError(err)
+	suite.updateClient(suite.getClientState(), header.(*ethmultisigtypes.Header))
+
+	// the prover needs the keys of the current signer set with enough power
+	prover = newProver("tester4", []uint32{5}, nil)
+	_, err = prover.SetupHeader(counterparty, nil)
+	suite.Require().Error(err)
+	prover = newProver("tester4", []uint32{5}, []uint32{3})
+	_, err = prover.SetupHeader(counterparty, nil)
+	suite.Require().Error(err)
+}
+
+func (suite *LightClientTestSuite) TestProverQueryHeight() {
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	counterparty := conntypes.NewCounterparty("counterparty-client", "counterparty-connection", prefix)
+	chain := testProverChain{
+		cdc:          suite.cdc,
+		path:         &core.PathEnd{ChainID: "chain-0", ClientID: "testclient-0", ConnectionID: "connection-0"},
+		latestHeight: 12,
+		connections: map[int64]conntypes.ConnectionEnd{
+			10: conntypes.NewConnectionEnd(conntypes.INIT, "testclient-0", counterparty, conntypes.ExportedVersionsToProto(conntypes.GetCompatibleVersions()), 0),
+			12: conntypes.NewConnectionEnd(conntypes.OPEN, "testclient-0", counterparty, conntypes.ExportedVersionsToProto(conntypes.GetCompatibleVersions()), 0),
+		},
+		clientState:    &ethmultisigtypes.ClientState{LatestHeight: client.Height{RevisionNumber: 0, RevisionHeight: 1}},
+		consensusState: &ethmultisigtypes.ConsensusState{Addresses: [][]byte{common.Address{1}.Bytes()}, Diversifier: "counterparty", Timestamp: 1},
+	}
+	prover, err := ethmultisig.NewProver(ethmultisig.ProverConfig{
+		Diversifier: "tester",
+		Wallets:     testWallets(0, 1, 2),
+		Prefix:      string(prefix.KeyPrefix),
+		SequenceDir: suite.T().TempDir(),
+	}, chain)
+	suite.Require().NoError(err)
+	suite.createClient(testAddresses(suite.prvKeys(0, 1, 2)), "tester")
+
+	// the proof is over the state at the requested height rather than the latest one
+	for _, height := range []int64{10, 12} {
+		res, err := prover.QueryConnectionWithProof(height)
+		suite.Require().NoError(err)
+		connection := chain.connections[height]
+		suite.Require().Equal(&connection, res.Connection)
+		suite.Require().NoError(suite.getClientState().VerifyConnectionState(suite.store, suite.cdc, res.ProofHeight, &prefix, res.Proof, "connection-0", connection))
+	}
+	res, err := prover.QueryConnectionWithProof(10)
+	suite.Require().NoError(err)
+	suite.Require().Error(suite.getClientState().VerifyConnectionState(suite.store, suite.cdc, res.ProofHeight, &prefix, res.Proof, "connection-0", chain.connections[12]))
+
+	// the heights that the chain cannot query are rejected
+	for _, height := range []int64{0, -1, 13} {
+		_, err = prover.QueryConnectionWithProof(height)
+		suite.Require().ErrorIs(err, ethmultisig.ErrHeightNotAvailable, "height=%d", height)
+		_, err = prover.QueryPacketCommitmentWithProof(height, 1)
+		suite.Require().ErrorIs(err, ethmultisig.ErrHeightNotAvailable, "height=%d", height)
+	}
+	_, err = prover.QueryConnectionWithProof(11)
+	suite.Require().Error(err)
+	suite.Require().Contains(err.Error(), "at height 11")
+}
+
+func (suite *LightClientTestSuite) TestProverHandshake() {
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	counterparty := conntypes.NewCounterparty("counterparty-client", "counterparty-connection", prefix)
+	chain := testProverChain{
+		cdc:          suite.cdc,
+		path:         &core.PathEnd{ChainID: "chain-0", ClientID: "testclient-0", ConnectionID: "connection-0"},
+		latestHeight: 10,
+		connections: map[int64]conntypes.ConnectionEnd{
+			10: conntypes.NewConnectionEnd(conntypes.TRYOPEN, "testclient-0", counterparty, conntypes.ExportedVersionsToProto(conntypes.GetCompatibleVersions()), 0),
+		},
+		clientState:    &ethmultisigtypes.ClientState{LatestHeight: client.Height{RevisionNumber: 0, RevisionHeight: 7}},
+		consensusState: &ethmultisigtypes.ConsensusState{Addresses: [][]byte{common.Address{1}.Bytes()}, Diversifier: "counterparty", Timestamp: 1},
+	}
+	prover, err := ethmultisig.NewProver(ethmultisig.ProverConfig{
+		Diversifier: "tester",
+		Wallets:     testWallets(0, 1, 2),
+		Prefix:      string(prefix.KeyPrefix),
+		SequenceDir: suite.T().TempDir(),
+	}, chain)
+	suite.Require().NoError(err)
+	suite.createClient(testAddresses(suite.prvKeys(0, 1, 2)), "tester")
+
+	// the three proofs of a handshake message share a proof height as the leaves of a batch
+	connRes, err := prover.QueryConnectionWithProof(10)
+	suite.Require().NoError(err)
+	csRes, err := prover.QueryClientStateWithProof(10)
+	suite.Require().NoError(err)
+	consRes, err := prover.QueryClientConsensusStateWithProof(10, chain.clientState.GetLatestHeight())
+	suite.Require().NoError(err)
+	suite.Require().Equal(connRes.ProofHeight, csRes.ProofHeight)
+	suite.Require().Equal(connRes.ProofHeight, consRes.ProofHeight)
+	suite.Require().NoError(suite.getClientState().VerifyConnectionState(suite.store, suite.cdc, connRes.ProofHeight, &prefix, connRes.Proof, "connection-0", chain.connections[10]))
+	suite.Require().NoError(suite.getClientState().VerifyClientState(suite.store, suite.cdc, csRes.ProofHeight, &prefix, "testclient-0", csRes.Proof, chain.clientState))
+	suite.Require().NoError(suite.getClientState().VerifyClientConsensusState(suite.store, suite.cdc, consRes.ProofHeight, "testclient-0", chain.clientState.GetLatestHeight(), &prefix, consRes.Proof, chain.consensusState))
+
+	// the proofs of the batch are returned only once, and the other proofs are signed with new sequences
+	csRes2, err := prover.QueryClientStateWithProof(10)
+	suite.Require().NoError(err)
+	suite.Require().True(csRes2.ProofHeight.GT(connRes.ProofHeight))
+	suite.Require().NoError(suite.getClientState().VerifyClientState(suite.store, suite.cdc, csRes2.ProofHeight, &prefix, "testclient-0", csRes2.Proof, chain.clientState))
+	var proofHeights []exported.Height
+	for seq := uint64(1); seq <= 2; seq++ {
+		commitment := crypto.Keccak256([]byte(fmt.Sprintf("packet-%d", seq)))
+		res, err := prover.SignPacketStateResponse(&chantypes.QueryPacketCommitmentResponse{Commitment: commitment}, "transfer", "channel-0", seq)
+		suite.Require().NoError(err)
+		proofHeights = append(proofHeights, res.ProofHeight)
+		suite.Require().NoError(suite.getClientState().VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, res.ProofHeight, 0, 0, &prefix, res.Proof, "transfer", "channel-0", seq, commitment))
+	}
+	suite.Require().True(proofHeights[0].GT(csRes2.ProofHeight))
+	suite.Require().True(proofHeights[1].GT(proofHeights[0]))
+}
+
+// testSigner is a Signer that counts its signatures and can return a broken signature.
+// A request can outlive the proof that stops waiting for it, so the fields are guarded by mu.
+type testSigner struct {
+	*ethmultisig.KeySigner
+
+	mu     sync.Mutex
+	signed int
+	sign   func(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+func (s *testSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	s.mu.Lock()
+	s.signed++
+	sign := s.sign
+	s.mu.Unlock()
+	if sign != nil {
+		return sign(ctx, hash)
+	}
+	return s.KeySigner.SignHash(ctx, hash)
+}
+
+func (s *testSigner) setSign(sign func(ctx context.Context, hash []byte) ([]byte, error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sign = sign
+}
+
+func (suite *LightClientTestSuite) TestProverSigner() {
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	chain := testProverChain{cdc: suite.cdc, path: &core.PathEnd{ChainID: "chain-0"}, latestHeight: 1}
+	keys := suite.prvKeys(0, 1, 2)
+	hdSigner, err := ethmultisig.NewHDWalletSigner(testMnemonicPhrase, "m/44'/60'/0'/0/0")
+	suite.Require().NoError(err)
+	suite.Require().Equal(crypto.PubkeyToAddress(keys[0].PublicKey), hdSigner.Address())
+
+	var signers []ethmultisig.Signer
+	for _, key := range keys {
+		signers = append(signers, &testSigner{KeySigner: ethmultisig.NewKeySigner(key)})
+	}
+	config := ethmultisig.ProverConfig{Diversifier: "tester", Prefix: string(prefix.KeyPrefix), SequenceDir: suite.T().TempDir()}
+	prover, err := ethmultisig.NewProverWithSigners(config, chain, signers, nil)
+	suite.Require().NoError(err)
+	suite.createClient(testAddresses(keys), "tester")
+	suite.Require().NoError(suite.verifyProverPacket(prover, prefix, 1))
+	for _, signer := range signers {
+		suite.Require().Equal(1, signer.(*testSigner).signed)
+	}
+	_, err = ethmultisig.NewProverWithSigners(config, chain, nil, nil)
+	suite.Require().Error(err)
+
+	// the signatures that the client would reject are caught by the prover
+	otherKey := suite.prvKeys(3)[0]
+	unavailable := func(context.Context, []byte) ([]byte, error) { return nil, fmt.Errorf("the signer is unavailable") }
+	for _, sign := range []func(ctx context.Context, hash []byte) ([]byte, error){
+		unavailable,
+		func(_ context.Context, hash []byte) ([]byte, error) { return crypto.Sign(hash, otherKey) },
+		func(context.Context, []byte) ([]byte, error) { return make([]byte, 65), nil },
+	} {
+		signers[1].(*testSigner).setSign(sign)
+		_, err = prover.SignPacketStateResponse(&chantypes.QueryPacketCommitmentResponse{Commitment: crypto.Keccak256(nil)}, "transfer", "channel-0", 2)
+		suite.Require().Error(err)
+	}
+
+	// with a threshold, the prover stops waiting once the signers that have signed reach it,
+	// and the signer bitmap has only those signers
+	addresses := testAddresses(keys)
+	clientState := suite.createClient(addresses, "tester")
+	consensusState := makeMultisigConsensusState(addresses, "tester", uint64(time.Now().UnixNano()))
+	consensusState.Threshold = 2
+	suite.setConsensusState(clientState.GetLatestHeight(), consensusState)
+	config.Addresses = []string{addresses[0].Hex(), addresses[1].Hex(), addresses[2].Hex()}
+	config.Threshold = 2
+	prover, err = ethmultisig.NewProverWithSigners(config, chain, signers, nil)
+	suite.Require().NoError(err)
+	canceled := make(chan struct{})
+	signers[1].(*testSigner).setSign(func(ctx context.Context, _ []byte) ([]byte, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	})
+	commitment := crypto.Keccak256(nil)
+	res, err := prover.SignPacketStateResponse(&chantypes.QueryPacketCommitmentResponse{Commitment: commitment}, "transfer", "channel-0", 2)
+	suite.Require().NoError(err)
+	<-canceled
+	var proof ethmultisigtypes.MultiSignature
+	suite.Require().NoError(proof.Unmarshal(res.Proof))
+	indexes, err := proof.SignerIndexes(len(addresses))
+	suite.Require().NoError(err)
+	suite.Require().Equal([]int{0, 2}, indexes)
+	suite.Require().NoError(suite.getClientState().VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, res.ProofHeight, 0, 0, &prefix, res.Proof, "transfer", "channel-0", 2, commitment))
+
+	// the signers that failed do not count and the proof fails if the others are short of the threshold
+	signers[1].(*testSigner).setSign(nil)
+	signers[2].(*testSigner).setSign(unavailable)
+	suite.Require().NoError(suite.verifyProverPacket(prover, prefix, 3))
+	signers[1].(*testSigner).setSign(unavailable)
+	suite.Require().Error(suite.verifyProverPacket(prover, prefix, 4))
+	signers[1].(*testSigner).setSign(nil)
+	signers[2].(*testSigner).setSign(nil)
+
+	// the sign requests are canceled with the context of the caller
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, signer := range signers {
+		signer.(*testSigner).setSign(func(ctx context.Context, _ []byte) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	}
+	multisig := ethmultisig.NewETHMultisig(suite.cdc, "tester", signers, prefix.KeyPrefix).WithContext(ctx)
+	_, _, err = multisig.SignPacketState(clienttypes.NewHeight(0, 5), "transfer", "channel-0", 5, commitment)
+	suite.Require().ErrorIs(err, context.Canceled)
+}
+
+// verifyProverPacket verifies the packet commitment signed by the prover with the client
+func (suite *LightClientTestSuite) verifyProverPacket(prover *ethmultisig.Prover, prefix commitmenttypes.MerklePrefix, seq uint64) error {
+	commitment := crypto.Keccak256([]byte(fmt.Sprintf("packet-%d", seq)))
+	res, err := prover.SignPacketStateResponse(&chantypes.QueryPacketCommitmentResponse{Commitment: commitment}, "transfer", "channel-0", seq)
+	if err != nil {
+		return err
+	}
+	return suite.getClientState().VerifyPacketCommitment(sdk.Context{}, suite.store, suite.cdc, res.ProofHeight, 0, 0, &prefix, res.Proof, "transfer", "channel-0", seq, commitment)
+}
+
+func testWallets(indexes ...uint32) []*ethmultisig.HDWallet {
+	var wallets []*ethmultisig.HDWallet
+	for _, idx := range indexes {
+		wallets = append(wallets, &ethmultisig.HDWallet{Mnemonic: testMnemonicPhrase, HdwPath: fmt.Sprintf("m/44'/60'/0'/0/%v", idx)})
+	}
+	return wallets
+}
+
+func testAddresses(keys []*ecdsa.PrivateKey) []common.Address {
+	var addresses []common.Address
+	for _, key := range keys {
+		addresses = append(addresses, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return addresses
+}
@@ -0,0 +1,82 @@
+package testing
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	ethmultisigtypes "github.com/datachainlab/ibc-ethmultisig-client/modules/light-clients/xx-ethmultisig/types"
+)
+
+// benchmarkMessages is the number of the sign bytes that the benchmarks verify in turn
+const benchmarkMessages = 512
+
+// BenchmarkVerifySignature compares the sequential recovery with the parallel recovery with and without the cache.
+// Each iteration verifies other sign bytes than the previous ones, as the client does with new proofs,
+// and the cache is smaller than the signatures of all the sign bytes, so it is measured only with its overhead.
+func BenchmarkVerifySignature(b *testing.B) {
+	for _, n := range []int{1, 7, 21, 100} {
+		addresses, signBytes, multiSigs := makeBenchmarkMultisigs(b, n, benchmarkMessages)
+		for _, mode := range []struct {
+			name      string
+			cacheSize int
+		}{
+			{"sequential", -1},
+			{"parallel", 0},
+			{"parallel-cached", benchmarkMessages / 2},
+		} {
+			b.Run(fmt.Sprintf("%s/signers=%d", mode.name, n), func(b *testing.B) {
+				if mode.cacheSize >= 0 {
+					recoverer, err := ethmultisigtypes.NewParallelRecoverer(runtime.GOMAXPROCS(0), mode.cacheSize)
+					if err != nil {
+						b.Fatal(err)
+					}
+					ethmultisigtypes.SetSignerRecoverer(recoverer)
+					defer ethmultisigtypes.SetSignerRecoverer(nil)
+				}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					j := i % len(multiSigs)
+					if err := ethmultisigtypes.VerifySignature(addresses, multiSigs[j], signBytes[j]); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// makeBenchmarkMultisigs returns the addresses of n keys and m sign bytes with the multisig of the keys over each of them
+func makeBenchmarkMultisigs(b *testing.B, n, m int) ([]common.Address, [][]byte, []*ethmultisigtypes.MultiSignature) {
+	var keys []*ecdsa.PrivateKey
+	var addresses []common.Address
+	for i := 0; i < n; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			b.Fatal(err)
+		}
+		keys = append(keys, key)
+		addresses = append(addresses, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	var signBytes [][]byte
+	var multiSigs []*ethmultisigtypes.MultiSignature
+	for i := 0; i < m; i++ {
+		bz := []byte(fmt.Sprintf("benchmark-%d", i))
+		hash := crypto.Keccak256(bz)
+		multiSig := &ethmultisigtypes.MultiSignature{}
+		for _, key := range keys {
+			sig, err := crypto.Sign(hash, key)
+			if err != nil {
+				b.Fatal(err)
+			}
+			multiSig.Signatures = append(multiSig.Signatures, sig)
+		}
+		signBytes = append(signBytes, bz)
+		multiSigs = append(multiSigs, multiSig)
+	}
+	return addresses, signBytes, multiSigs
+}
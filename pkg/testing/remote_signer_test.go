@@ -0,0 +1,302 @@
+package testing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/modules/core/23-commitment/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hyperledger-labs/yui-relayer/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ethmultisigtypes "github.com/datachainlab/ibc-ethmultisig-client/modules/light-clients/xx-ethmultisig/types"
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig"
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig/signer"
+)
+
+// testSignerServer wraps a signer server to count the sign requests and to delay or fail them
+type testSignerServer struct {
+	signer.SignerServer
+
+	requests int32
+	// before is called with the number of the request before the request is signed
+	before func(ctx context.Context, n int32) error
+}
+
+func (s *testSignerServer) Sign(ctx context.Context, req *signer.SignRequest) (*signer.SignResponse, error) {
+	n := atomic.AddInt32(&s.requests, 1)
+	if s.before != nil {
+		if err := s.before(ctx, n); err != nil {
+			return nil, err
+		}
+	}
+	return s.SignerServer.Sign(ctx, req)
+}
+
+// testCertificates are the PEM files of a CA and the server and client certificates that it issues
+type testCertificates struct {
+	caFile, serverCertFile, serverKeyFile, clientCertFile, clientKeyFile string
+}
+
+func (suite *LightClientTestSuite) TestRemoteSigner() {
+	prefix := commitmenttypes.NewMerklePrefix([]byte("ibc"))
+	chain := testProverChain{cdc: suite.cdc, path: &core.PathEnd{ChainID: "chain-0"}, latestHeight: 1}
+	certs := suite.generateCertificates()
+	keys := suite.prvKeys(0, 1, 2)
+	addresses := testAddresses(keys)
+
+	serverTLS, err := signer.ServerTLSConfig(certs.serverCertFile, certs.serverKeyFile, "")
+	suite.Require().NoError(err)
+	mutualTLS, err := signer.ServerTLSConfig(certs.serverCertFile, certs.serverKeyFile, certs.caFile)
+	suite.Require().NoError(err)
+
+	// every signer waits for the others, so the prover must request them at the same time
+	var waiting int32
+	barrier := make(chan struct{})
+	var servers []*testSignerServer
+	for range keys {
+		servers = append(servers, &testSignerServer{before: func(ctx context.Context, n int32) error {
+			if atomic.AddInt32(&waiting, 1) == int32(len(keys)) {
+				close(barrier)
+			}
+			select {
+			case <-barrier:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}})
+	}
+	for i, key := range keys {
+		servers[i].SignerServer = signer.NewServer(ethmultisig.NewKeySigner(key), []string{"tester"})
+	}
+	remoteSigners := []*ethmultisig.RemoteSigner{
+		{Endpoint: suite.serveSigner(servers[0], nil), Address: addresses[0].Hex(), Insecure: true},
+		{Endpoint: suite.serveSigner(servers[1], serverTLS), Address: addresses[1].Hex(), CaFile: certs.caFile},
+		{
+			Endpoint: suite.serveSigner(servers[2], mutualTLS), Address: addresses[2].Hex(), ServerName: "signer.test",
+			CaFile: certs.caFile, CertFile: certs.clientCertFile, KeyFile: certs.clientKeyFile,
+		},
+	}
+	config := ethmultisig.ProverConfig{
+		Diversifier: "tester", Prefix: string(prefix.KeyPrefix), SequenceDir: suite.T().TempDir(),
+		RemoteSigners: remoteSigners,
+	}
+	prover, err := ethmultisig.NewProver(config, chain)
+	suite.Require().NoError(err)
+	suite.createClient(addresses, "tester")
+	suite.Require().NoError(suite.verifyProverPacket(prover, prefix, 1))
+	for _, server := range servers {
+		suite.Require().Equal(int32(1), atomic.LoadInt32(&server.requests))
+	}
+
+	// a signer mixes with the wallets
+	mixed := config
+	mixed.Wallets = testWallets(0)
+	mixed.RemoteSigners = remoteSigners[1:]
+	prover, err = ethmultisig.NewProver(mixed, chain)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.verifyProverPacket(prover, prefix, 2))
+
+	ctx := context.Background()
+	dial := func(rs *ethmultisig.RemoteSigner) *signer.RemoteSigner {
+		s, err := rs.Dial()
+		suite.Require().NoError(err)
+		suite.T().Cleanup(func() { s.Close() })
+		return s
+	}
+	addr, err := dial(remoteSigners[2]).RemoteAddress(ctx)
+	suite.Require().NoError(err)
+	suite.Require().Equal(addresses[2], addr)
+
+	// the mutual TLS rejects a client without a certificate and the TLS rejects an unknown server certificate
+	_, err = dial(&ethmultisig.RemoteSigner{Endpoint: remoteSigners[2].Endpoint, Address: addresses[2].Hex(), CaFile: certs.caFile}).RemoteAddress(ctx)
+	suite.Require().Error(err)
+	_, err = dial(&ethmultisig.RemoteSigner{Endpoint: remoteSigners[1].Endpoint, Address: addresses[1].Hex(), CaFile: certs.clientCertFile}).RemoteAddress(ctx)
+	suite.Require().Error(err)
+	_, err = dial(&ethmultisig.RemoteSigner{Endpoint: remoteSigners[1].Endpoint, Address: addresses[1].Hex(), Insecure: true}).RemoteAddress(ctx)
+	suite.Require().Error(err)
+
+	// the signers reject the sign bytes that they cannot check without retrying
+	requests := atomic.LoadInt32(&servers[0].requests)
+	retrying := dial(&ethmultisig.RemoteSigner{Endpoint: remoteSigners[0].Endpoint, Address: addresses[0].Hex(), Insecure: true, MaxRetries: 3})
+	_, err = retrying.SignBytes(ctx, []byte("not sign bytes"))
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	_, err = retrying.SignHash(ctx, crypto.Keccak256(nil))
+	suite.Require().Error(err)
+	other := config
+	other.Diversifier = "other"
+	prover, err = ethmultisig.NewProver(other, chain)
+	suite.Require().NoError(err)
+	_, err = prover.SignPacketStateResponse(&chantypes.QueryPacketCommitmentResponse{Commitment: crypto.Keccak256(nil)}, "transfer", "channel-0", 3)
+	suite.Require().Error(err)
+	_, signBytes, err := ethmultisig.NewETHMultisig(suite.cdc, "other", ethmultisig.NewKeySigners(keys[0]), prefix.KeyPrefix).SignPacketState(clienttypes.NewHeight(0, 3), "transfer", "channel-0", 3, crypto.Keccak256(nil))
+	suite.Require().NoError(err)
+	_, err = retrying.SignBytes(ctx, signBytes)
+	suite.Require().Equal(codes.PermissionDenied, status.Code(err))
+	suite.Require().Equal(requests+3, atomic.LoadInt32(&servers[0].requests))
+
+	// a signature of another key than the configured address is rejected by the prover
+	wrong := config
+	wrong.RemoteSigners = []*ethmultisig.RemoteSigner{{Endpoint: remoteSigners[0].Endpoint, Address: addresses[1].Hex(), Insecure: true}}
+	prover, err = ethmultisig.NewProver(wrong, chain)
+	suite.Require().NoError(err)
+	_, err = prover.SignPacketStateResponse(&chantypes.QueryPacketCommitmentResponse{Commitment: crypto.Keccak256(nil)}, "transfer", "channel-0", 3)
+	suite.Require().Error(err)
+
+	for _, rs := range []*ethmultisig.RemoteSigner{
+		{Address: addresses[0].Hex(), Insecure: true},
+		{Endpoint: remoteSigners[0].Endpoint, Address: "signer", Insecure: true},
+		{Endpoint: remoteSigners[1].Endpoint, Address: addresses[1].Hex(), CaFile: filepath.Join(suite.T().TempDir(), "ca.pem")},
+	} {
+		_, err := rs.Dial()
+		suite.Require().Error(err)
+	}
+	_, err = ethmultisig.NewProver(ethmultisig.ProverConfig{Diversifier: "tester", SequenceDir: suite.T().TempDir()}, chain)
+	suite.Require().Error(err)
+}
+
+func (suite *LightClientTestSuite) TestRemoteSignerRetry() {
+	key := suite.prvKeys(0)[0]
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	_, signBytes, err := ethmultisig.NewETHMultisig(suite.cdc, "tester", ethmultisig.NewKeySigners(key), []byte("ibc")).SignPacketState(clienttypes.NewHeight(0, 1), "transfer", "channel-0", 1, crypto.Keccak256(nil))
+	suite.Require().NoError(err)
+	ctx := context.Background()
+
+	// the first two requests fail as if the signer were restarting
+	flaky := &testSignerServer{
+		SignerServer: signer.NewServer(ethmultisig.NewKeySigner(key), nil),
+		before: func(ctx context.Context, n int32) error {
+			if n <= 2 {
+				return status.Error(codes.Unavailable, "the signer is restarting")
+			}
+			return nil
+		},
+	}
+	endpoint := suite.serveSigner(flaky, nil)
+	s, err := signer.Dial(endpoint, address, signer.Options{MaxRetries: 1})
+	suite.Require().NoError(err)
+	defer s.Close()
+	_, err = s.SignBytes(ctx, signBytes)
+	suite.Require().Equal(codes.Unavailable, status.Code(err))
+	suite.Require().Equal(int32(2), atomic.LoadInt32(&flaky.requests))
+	atomic.StoreInt32(&flaky.requests, 0)
+	s, err = signer.Dial(endpoint, address, signer.Options{MaxRetries: 2})
+	suite.Require().NoError(err)
+	defer s.Close()
+	sig, err := s.SignBytes(ctx, signBytes)
+	suite.Require().NoError(err)
+	suite.Require().Equal(int32(3), atomic.LoadInt32(&flaky.requests))
+	signedBy, err := ethmultisigtypes.RecoverSigner(crypto.Keccak256(signBytes), sig)
+	suite.Require().NoError(err)
+	suite.Require().Equal(address, signedBy)
+
+	// the first request does not return until the client gives up
+	slow := &testSignerServer{
+		SignerServer: signer.NewServer(ethmultisig.NewKeySigner(key), nil),
+		before: func(ctx context.Context, n int32) error {
+			if n == 1 {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		},
+	}
+	endpoint = suite.serveSigner(slow, nil)
+	s, err = signer.Dial(endpoint, address, signer.Options{Timeout: 200 * time.Millisecond})
+	suite.Require().NoError(err)
+	defer s.Close()
+	_, err = s.SignBytes(ctx, signBytes)
+	suite.Require().Equal(codes.DeadlineExceeded, status.Code(err))
+	atomic.StoreInt32(&slow.requests, 0)
+	s, err = signer.Dial(endpoint, address, signer.Options{Timeout: 200 * time.Millisecond, MaxRetries: 1})
+	suite.Require().NoError(err)
+	defer s.Close()
+	_, err = s.SignBytes(ctx, signBytes)
+	suite.Require().NoError(err)
+	suite.Require().Equal(int32(2), atomic.LoadInt32(&slow.requests))
+
+	// the retries stop when the context of the caller is done
+	atomic.StoreInt32(&slow.requests, 0)
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = s.SignBytes(canceled, signBytes)
+	suite.Require().Error(err)
+	suite.Require().Equal(int32(0), atomic.LoadInt32(&slow.requests))
+}
+
+// serveSigner serves the signer server on a loopback port until the test ends and returns its endpoint
+func (suite *LightClientTestSuite) serveSigner(server signer.SignerServer, tlsConfig *tls.Config) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	gs := signer.NewGRPCServer(server, tlsConfig)
+	go gs.Serve(lis)
+	suite.T().Cleanup(gs.Stop)
+	return lis.Addr().String()
+}
+
+// generateCertificates generates a CA and a server and a client certificate issued by it.
+// The server certificate is valid for 127.0.0.1 and signer.test.
+func (suite *LightClientTestSuite) generateCertificates() testCertificates {
+	dir := suite.T().TempDir()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	suite.Require().NoError(err)
+	ca, err := x509.ParseCertificate(caDER)
+	suite.Require().NoError(err)
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		suite.Require().NoError(err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			DNSNames:     []string{"signer.test"},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		suite.Require().NoError(err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		suite.Require().NoError(err)
+		return suite.writePEM(dir, name+".pem", "CERTIFICATE", der), suite.writePEM(dir, name+"-key.pem", "EC PRIVATE KEY", keyDER)
+	}
+	certs := testCertificates{caFile: suite.writePEM(dir, "ca.pem", "CERTIFICATE", caDER)}
+	certs.serverCertFile, certs.serverKeyFile = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	certs.clientCertFile, certs.clientKeyFile = issue("client", 3, x509.ExtKeyUsageClientAuth)
+	return certs
+}
+
+func (suite *LightClientTestSuite) writePEM(dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	suite.Require().NoError(ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return path
+}
@@ -0,0 +1,200 @@
+package testing
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/datachainlab/ibc-ethmultisig-client/modules/relay/ethmultisig/sequence"
+)
+
+const (
+	sequenceHelperEnv  = "ETHMULTISIG_SEQUENCE_HELPER"
+	sequenceHelperRuns = 50
+)
+
+var sequenceStoreTypes = []string{sequence.StoreTypeFile, sequence.StoreTypeLevelDB}
+
+func incrementSequence(state sequence.State) (sequence.State, error) {
+	state.Sequence++
+	return state, nil
+}
+
+func TestSequenceStore(t *testing.T) {
+	for _, storeType := range sequenceStoreTypes {
+		t.Run(storeType, func(t *testing.T) {
+			dir := t.TempDir()
+			store, err := sequence.NewStore(storeType, dir, "chain-0/tester")
+			require.NoError(t, err)
+			state, err := store.Load()
+			require.NoError(t, err)
+			require.Equal(t, sequence.InitialState, state)
+
+			state, err = store.Update(func(state sequence.State) (sequence.State, error) {
+				return sequence.State{Sequence: state.Sequence + 1}, nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, sequence.State{Sequence: 2}, state)
+
+			// the sequence cannot go backwards and a failed update changes nothing
+			_, err = store.Update(func(state sequence.State) (sequence.State, error) {
+				return sequence.State{Sequence: 1}, nil
+			})
+			require.Error(t, err)
+			_, err = store.Update(func(state sequence.State) (sequence.State, error) {
+				return sequence.State{}, fmt.Errorf("failed to sign")
+			})
+			require.Error(t, err)
+
+			// the state survives a restart and is separate from the other keys
+			store, err = sequence.NewStore(storeType, dir, "chain-0/tester")
+			require.NoError(t, err)
+			state, err = store.Load()
+			require.NoError(t, err)
+			require.Equal(t, sequence.State{Sequence: 2}, state)
+			other, err := sequence.NewStore(storeType, dir, "chain-1/tester")
+			require.NoError(t, err)
+			state, err = other.Load()
+			require.NoError(t, err)
+			require.Equal(t, sequence.InitialState, state)
+		})
+	}
+
+	_, err := sequence.NewStore("memory", t.TempDir(), "chain-0/tester")
+	require.Error(t, err)
+}
+
+func TestFileSequenceStoreRecovery(t *testing.T) {
+	dir := t.TempDir()
+	store, err := sequence.NewFileStore(dir, "chain-0/tester")
+	require.NoError(t, err)
+	_, err = store.Update(incrementSequence)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "chain-0%2Ftester.json")
+	require.FileExists(t, path)
+
+	// a temporary file left by a crash during a write does not affect the state
+	require.NoError(t, ioutil.WriteFile(path+".tmp", []byte(`{"seq`), 0600))
+	state, err := store.Load()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), state.Sequence)
+	state, err = store.Update(incrementSequence)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), state.Sequence)
+
+	// a corrupted state is never reset, as the sequences could be handed out again
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"seq`), 0600))
+	_, err = store.Load()
+	require.Error(t, err)
+	_, err = store.Update(incrementSequence)
+	require.Error(t, err)
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"sequence":0}`), 0600))
+	_, err = store.Update(incrementSequence)
+	require.Error(t, err)
+}
+
+func TestSequenceStoreConcurrency(t *testing.T) {
+	for _, storeType := range sequenceStoreTypes {
+		t.Run(storeType, func(t *testing.T) {
+			dir := t.TempDir()
+			const goroutines = 8
+			var mu sync.Mutex
+			var sequences []uint64
+			var wg sync.WaitGroup
+			for i := 0; i < goroutines; i++ {
+				// every goroutine has its own store as if it were another prover
+				store, err := sequence.NewStore(storeType, dir, "chain-0/tester")
+				require.NoError(t, err)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < sequenceHelperRuns; j++ {
+						state, err := store.Update(incrementSequence)
+						if err != nil {
+							t.Error(err)
+							return
+						}
+						mu.Lock()
+						sequences = append(sequences, state.Sequence)
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+			requireUniqueSequences(t, sequences, goroutines*sequenceHelperRuns)
+		})
+	}
+}
+
+// TestSequenceStoreProcesses runs the provers in separate processes that share the directory of the store
+func TestSequenceStoreProcesses(t *testing.T) {
+	if os.Getenv(sequenceHelperEnv) != "" {
+		return
+	}
+	for _, storeType := range sequenceStoreTypes {
+		t.Run(storeType, func(t *testing.T) {
+			dir := t.TempDir()
+			const processes = 4
+			var cmds []*exec.Cmd
+			var outs []*strings.Builder
+			for i := 0; i < processes; i++ {
+				cmd := exec.Command(os.Args[0], "-test.run=^TestSequenceStoreHelperProcess$")
+				cmd.Env = append(os.Environ(), sequenceHelperEnv+"="+storeType+":"+dir)
+				out := new(strings.Builder)
+				cmd.Stdout = out
+				cmd.Stderr = out
+				require.NoError(t, cmd.Start())
+				cmds = append(cmds, cmd)
+				outs = append(outs, out)
+			}
+			var sequences []uint64
+			for i, cmd := range cmds {
+				require.NoError(t, cmd.Wait(), outs[i].String())
+				for _, line := range strings.Split(outs[i].String(), "\n") {
+					if !strings.HasPrefix(line, "sequence=") {
+						continue
+					}
+					seq, err := strconv.ParseUint(strings.TrimPrefix(line, "sequence="), 10, 64)
+					require.NoError(t, err)
+					sequences = append(sequences, seq)
+				}
+			}
+			requireUniqueSequences(t, sequences, processes*sequenceHelperRuns)
+		})
+	}
+}
+
+// TestSequenceStoreHelperProcess is a prover process of TestSequenceStoreProcesses
+func TestSequenceStoreHelperProcess(t *testing.T) {
+	env := os.Getenv(sequenceHelperEnv)
+	if env == "" {
+		t.Skip("run by TestSequenceStoreProcesses")
+	}
+	parts := strings.SplitN(env, ":", 2)
+	store, err := sequence.NewStore(parts[0], parts[1], "chain-0/tester")
+	require.NoError(t, err)
+	for i := 0; i < sequenceHelperRuns; i++ {
+		state, err := store.Update(incrementSequence)
+		require.NoError(t, err)
+		fmt.Printf("sequence=%d\n", state.Sequence)
+	}
+}
+
+func requireUniqueSequences(t *testing.T, sequences []uint64, n int) {
+	require.Len(t, sequences, n)
+	seen := make(map[uint64]bool)
+	for _, seq := range sequences {
+		require.False(t, seen[seq], "sequence %d is handed out twice", seq)
+		seen[seq] = true
+		// every sequence after the initial one is handed out
+		require.True(t, seq > sequence.InitialState.Sequence && seq <= sequence.InitialState.Sequence+uint64(n))
+	}
+}